@@ -0,0 +1,429 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package block
+
+import (
+	"encoding/binary"
+
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	keyAddressIndexRange = "block.addressIndexRange"
+
+	// height(8) + group(1) + index of transaction(4)
+	txLocatorKeyLen = 13
+	// txLocatorKey + index of event log(4)
+	eventLogLocatorKeyLen = txLocatorKeyLen + 4
+)
+
+// addressIndexRange is the range of heights of blocks whose transactions
+// are indexed. Patch transactions of the block at To+1 may be indexed, but
+// normal ones are indexed after the next block is finalized.
+type addressIndexRange struct {
+	From int64
+	To   int64
+}
+
+func txLocatorKeyOf(
+	addr []byte, height int64, group module.TransactionGroup, index int,
+) []byte {
+	key := make([]byte, len(addr)+txLocatorKeyLen, len(addr)+eventLogLocatorKeyLen)
+	copy(key, addr)
+	buf := key[len(addr):]
+	binary.BigEndian.PutUint64(buf[0:8], uint64(height))
+	buf[8] = byte(group)
+	binary.BigEndian.PutUint32(buf[9:13], uint32(index))
+	return key
+}
+
+func eventLogLocatorKeyOf(
+	addr []byte, height int64, group module.TransactionGroup, index int, eIndex int,
+) []byte {
+	key := txLocatorKeyOf(addr, height, group, index)
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(eIndex))
+	return append(key, buf[:]...)
+}
+
+func parseTxLocatorKey(key []byte) *transactionLocator {
+	return &transactionLocator{
+		BlockHeight:      int64(binary.BigEndian.Uint64(key[0:8])),
+		TransactionGroup: module.TransactionGroup(key[8]),
+		IndexInGroup:     int(binary.BigEndian.Uint32(key[9:13])),
+	}
+}
+
+func heightKeyOf(addr []byte, height int64) []byte {
+	key := make([]byte, len(addr)+8)
+	copy(key, addr)
+	binary.BigEndian.PutUint64(key[len(addr):], uint64(height))
+	return key
+}
+
+type eventLogInfo struct {
+	txInfo module.TransactionInfo
+	index  int
+}
+
+func (e *eventLogInfo) TransactionInfo() module.TransactionInfo {
+	return e.txInfo
+}
+
+func (e *eventLogInfo) Index() int {
+	return e.index
+}
+
+func (e *eventLogInfo) EventLog() (module.EventLog, error) {
+	rct, err := e.txInfo.GetReceipt()
+	if err != nil {
+		return nil, err
+	}
+	for it, idx := rct.EventLogIterator(), 0; it.Has(); log.Must(it.Next()) {
+		if idx == e.index {
+			return it.Get()
+		}
+		idx++
+	}
+	return nil, errors.NotFoundError.Errorf("NoEventLog(index=%d)", e.index)
+}
+
+type addressIndex struct {
+	m *manager
+}
+
+// indexTransactions indexes the transactions in the list with their
+// receipts.
+func indexTransactions(
+	dbase db.Database,
+	height int64,
+	group module.TransactionGroup,
+	txs module.TransactionList,
+	rcts module.ReceiptList,
+) error {
+	txBk, err := dbase.GetBucket(db.TransactionByAddress)
+	if err != nil {
+		return err
+	}
+	elBk, err := dbase.GetBucket(db.EventLogByAddress)
+	if err != nil {
+		return err
+	}
+	for it := txs.Iterator(); it.Has(); log.Must(it.Next()) {
+		tx, i, err := it.Get()
+		if err != nil {
+			return err
+		}
+		rct, err := rcts.Get(i)
+		if err != nil {
+			return err
+		}
+		id := tx.ID()
+		setTx := func(addr module.Address) error {
+			if addr == nil {
+				return nil
+			}
+			key := txLocatorKeyOf(addr.Bytes(), height, group, i)
+			return txBk.Set(key, id)
+		}
+		if err := setTx(tx.From()); err != nil {
+			return err
+		}
+		if err := setTx(rct.To()); err != nil {
+			return err
+		}
+		if err := setTx(rct.SCOREAddress()); err != nil {
+			return err
+		}
+		for eit, j := rct.EventLogIterator(), 0; eit.Has(); log.Must(eit.Next()) {
+			el, err := eit.Get()
+			if err != nil {
+				return err
+			}
+			if err := setTx(el.Address()); err != nil {
+				return err
+			}
+			key := eventLogLocatorKeyOf(el.Address().Bytes(), height, group, i, j)
+			if err := elBk.Set(key, id); err != nil {
+				return err
+			}
+			j++
+		}
+	}
+	return nil
+}
+
+// indexBlock indexes normal transactions of the block with receipts in the
+// result of the next block, and patch transactions of the next block.
+func (ai *addressIndex) indexBlock(
+	dbase db.Database, blk module.Block, next module.Block,
+) error {
+	if blk != nil {
+		rl, err := ai.m.sm.ReceiptListFromResult(next.Result(), module.TransactionGroupNormal)
+		if err != nil {
+			return err
+		}
+		err = indexTransactions(dbase, blk.Height(), module.TransactionGroupNormal,
+			blk.NormalTransactions(), rl)
+		if err != nil {
+			return err
+		}
+	}
+	rl, err := ai.m.sm.ReceiptListFromResult(next.Result(), module.TransactionGroupPatch)
+	if err != nil {
+		return err
+	}
+	return indexTransactions(dbase, next.Height(), module.TransactionGroupPatch,
+		next.PatchTransactions(), rl)
+}
+
+func (ai *addressIndex) getRange(dbase db.Database) (*addressIndexRange, error) {
+	bk, err := db.NewCodedBucket(dbase, db.ChainProperty, nil)
+	if err != nil {
+		return nil, err
+	}
+	r := new(addressIndexRange)
+	if err := bk.Get(db.Raw(keyAddressIndexRange), r); err != nil {
+		if errors.NotFoundError.Equals(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r, nil
+}
+
+func (ai *addressIndex) setRange(dbase db.Database, r *addressIndexRange) error {
+	bk, err := db.NewCodedBucket(dbase, db.ChainProperty, nil)
+	if err != nil {
+		return err
+	}
+	return bk.Set(db.Raw(keyAddressIndexRange), r)
+}
+
+// onFinalize indexes the transactions whose receipts are available after
+// the block is finalized. It's called while the block is written.
+func (ai *addressIndex) onFinalize(dbase db.Database, blk module.Block) error {
+	height := blk.Height()
+	prev, err := ai.m.getBlockByHeight(height - 1)
+	if err != nil {
+		if !errors.NotFoundError.Equals(err) {
+			return err
+		}
+		// the previous block isn't available for pruned genesis
+		prev = nil
+	}
+	if err := ai.indexBlock(dbase, prev, blk); err != nil {
+		return err
+	}
+	r, err := ai.getRange(dbase)
+	if err != nil {
+		return err
+	}
+	if r != nil && r.To == height-2 && prev != nil {
+		r.To = height - 1
+	} else {
+		// patch transactions of the previous block might not be indexed.
+		r = &addressIndexRange{From: height, To: height - 1}
+	}
+	return ai.setRange(dbase, r)
+}
+
+func (ai *addressIndex) Range() (int64, int64, error) {
+	ai.m.syncer.begin()
+	defer ai.m.syncer.end()
+
+	r, err := ai.getRange(ai.m.db())
+	if err != nil {
+		return 0, 0, err
+	}
+	if r == nil {
+		return 0, -1, nil
+	}
+	return r.From, r.To, nil
+}
+
+// iterate iterates index entries of the address in the bucket, and returns
+// the cursor of the next entry if there are more than limit entries.
+func (ai *addressIndex) iterate(
+	id db.BucketID, keyLen int,
+	addr module.Address, from, to int64, cursor []byte, limit int,
+	on func(key []byte) error,
+) ([]byte, error) {
+	if from > to {
+		return nil, nil
+	}
+	bk, err := ai.m.db().GetBucket(id)
+	if err != nil {
+		return nil, err
+	}
+	ab := addr.Bytes()
+	r := &db.Range{
+		Start: heightKeyOf(ab, from),
+		Limit: heightKeyOf(ab, to+1),
+	}
+	if cursor != nil {
+		if len(cursor) != keyLen {
+			return nil, errors.IllegalArgumentError.Errorf("InvalidCursor(%#x)", cursor)
+		}
+		start := append(append([]byte{}, ab...), cursor...)
+		if !r.Contains(start) {
+			return nil, errors.IllegalArgumentError.Errorf("CursorOutOfRange(%#x)", cursor)
+		}
+		r.Start = start
+	}
+	it, err := bk.NewIterator(r, false)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	cnt := 0
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(ab)+keyLen {
+			continue
+		}
+		if cnt == limit {
+			next := make([]byte, keyLen)
+			copy(next, key[len(ab):])
+			return next, nil
+		}
+		if err := on(key[len(ab):]); err != nil {
+			return nil, err
+		}
+		cnt++
+	}
+	return nil, it.Error()
+}
+
+func (ai *addressIndex) GetTransactions(
+	addr module.Address, from, to int64, cursor []byte, limit int,
+) ([]module.TransactionInfo, []byte, error) {
+	ai.m.syncer.begin()
+	defer ai.m.syncer.end()
+
+	var txs []module.TransactionInfo
+	next, err := ai.iterate(db.TransactionByAddress, txLocatorKeyLen,
+		addr, from, to, cursor, limit,
+		func(key []byte) error {
+			txInfo, err := ai.m.newTransactionInfo(parseTxLocatorKey(key))
+			if err != nil {
+				return err
+			}
+			txs = append(txs, txInfo)
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return txs, next, nil
+}
+
+func (ai *addressIndex) GetEventLogs(
+	addr module.Address, from, to int64, cursor []byte, limit int,
+) ([]module.EventLogInfo, []byte, error) {
+	ai.m.syncer.begin()
+	defer ai.m.syncer.end()
+
+	var els []module.EventLogInfo
+	next, err := ai.iterate(db.EventLogByAddress, eventLogLocatorKeyLen,
+		addr, from, to, cursor, limit,
+		func(key []byte) error {
+			txInfo, err := ai.m.newTransactionInfo(parseTxLocatorKey(key))
+			if err != nil {
+				return err
+			}
+			els = append(els, &eventLogInfo{
+				txInfo: txInfo,
+				index:  int(binary.BigEndian.Uint32(key[txLocatorKeyLen:])),
+			})
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return els, next, nil
+}
+
+// buildBlock indexes transactions of the block and updates the range of
+// indexed blocks. It returns false if there is no more block to index.
+func (ai *addressIndex) buildBlock(from int64) (int64, bool, error) {
+	ai.m.syncer.begin()
+	defer ai.m.syncer.end()
+
+	r, err := ai.getRange(ai.m.db())
+	if err != nil {
+		return 0, false, err
+	}
+	var height int64
+	if r == nil {
+		height = ai.m.finalized.block.Height() - 1
+		r = &addressIndexRange{From: height + 1, To: height}
+	} else {
+		height = r.From - 1
+	}
+	if height < from {
+		return height, false, nil
+	}
+	blk, err := ai.m.getBlockByHeight(height)
+	if err != nil {
+		return height, false, err
+	}
+	next, err := ai.m.getBlockByHeight(height + 1)
+	if err != nil {
+		return height, false, err
+	}
+	batch := db.NewBatch(ai.m.db())
+	if err := ai.indexBlock(batch, blk, next); err != nil {
+		return height, false, err
+	}
+	r.From = height
+	if err := ai.setRange(batch, r); err != nil {
+		return height, false, err
+	}
+	return height, true, batch.Write()
+}
+
+func (ai *addressIndex) Build(from int64, on func(height int64) error) error {
+	if gh := ai.m.chain.GenesisStorage().Height(); from < gh {
+		return errors.IllegalArgumentError.Errorf(
+			"InvalidHeight(from=%d,genesis=%d)", from, gh)
+	}
+	for {
+		height, ok, err := ai.buildBlock(from)
+		if err != nil || !ok {
+			return err
+		}
+		if on != nil {
+			if err := on(height); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (m *manager) GetAddressIndex() (module.AddressIndex, error) {
+	if m.addressIndex == nil {
+		return nil, errors.UnsupportedError.New("AddressIndexDisabled")
+	}
+	return m.addressIndex, nil
+}
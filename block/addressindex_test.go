@@ -0,0 +1,185 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/module"
+)
+
+type aiTransaction struct {
+	module.Transaction
+	id   []byte
+	from module.Address
+}
+
+func (tx *aiTransaction) ID() []byte {
+	return tx.id
+}
+
+func (tx *aiTransaction) From() module.Address {
+	return tx.from
+}
+
+type aiTransactionIterator struct {
+	txs []module.Transaction
+	idx int
+}
+
+func (it *aiTransactionIterator) Has() bool {
+	return it.idx < len(it.txs)
+}
+
+func (it *aiTransactionIterator) Next() error {
+	it.idx++
+	return nil
+}
+
+func (it *aiTransactionIterator) Get() (module.Transaction, int, error) {
+	return it.txs[it.idx], it.idx, nil
+}
+
+type aiTransactionList struct {
+	module.TransactionList
+	txs []module.Transaction
+}
+
+func (l *aiTransactionList) Iterator() module.TransactionIterator {
+	return &aiTransactionIterator{txs: l.txs}
+}
+
+type aiEventLog struct {
+	addr module.Address
+}
+
+func (el *aiEventLog) Address() module.Address {
+	return el.addr
+}
+
+func (el *aiEventLog) Indexed() [][]byte {
+	return nil
+}
+
+func (el *aiEventLog) Data() [][]byte {
+	return nil
+}
+
+type aiEventLogIterator struct {
+	logs []module.EventLog
+	idx  int
+}
+
+func (it *aiEventLogIterator) Has() bool {
+	return it.idx < len(it.logs)
+}
+
+func (it *aiEventLogIterator) Next() error {
+	it.idx++
+	return nil
+}
+
+func (it *aiEventLogIterator) Get() (module.EventLog, error) {
+	return it.logs[it.idx], nil
+}
+
+type aiReceipt struct {
+	module.Receipt
+	to   module.Address
+	logs []module.EventLog
+}
+
+func (r *aiReceipt) To() module.Address {
+	return r.to
+}
+
+func (r *aiReceipt) SCOREAddress() module.Address {
+	return nil
+}
+
+func (r *aiReceipt) EventLogIterator() module.EventLogIterator {
+	return &aiEventLogIterator{logs: r.logs}
+}
+
+type aiReceiptList struct {
+	module.ReceiptList
+	rcts []module.Receipt
+}
+
+func (l *aiReceiptList) Get(i int) (module.Receipt, error) {
+	return l.rcts[i], nil
+}
+
+func TestAddressIndex_Iterate(t *testing.T) {
+	eoa1 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+	eoa2 := common.MustNewAddressFromString("hx0000000000000000000000000000000000000002")
+	score := common.MustNewAddressFromString("cx0000000000000000000000000000000000000003")
+
+	dbase := newMapDB()
+	txs := &aiTransactionList{txs: []module.Transaction{
+		&aiTransaction{id: []byte{0}, from: eoa1},
+		&aiTransaction{id: []byte{1}, from: eoa2},
+	}}
+	rcts := &aiReceiptList{rcts: []module.Receipt{
+		&aiReceipt{to: eoa2},
+		&aiReceipt{to: score, logs: []module.EventLog{
+			&aiEventLog{addr: score}, &aiEventLog{addr: score},
+		}},
+	}}
+	for _, height := range []int64{1, 2, 3} {
+		err := indexTransactions(dbase, height, module.TransactionGroupNormal, txs, rcts)
+		assert.NoError(t, err)
+	}
+
+	ai := &addressIndex{m: &manager{
+		chainContext: &chainContext{chain: &testChain{database: dbase}},
+	}}
+	collect := func(addr module.Address, keyLen int, from, to int64, limit int) [][]byte {
+		var keys [][]byte
+		var cursor []byte
+		id := db.TransactionByAddress
+		if keyLen == eventLogLocatorKeyLen {
+			id = db.EventLogByAddress
+		}
+		for {
+			next, err := ai.iterate(id, keyLen, addr, from, to, cursor, limit,
+				func(key []byte) error {
+					keys = append(keys, append([]byte{}, key...))
+					return nil
+				},
+			)
+			assert.NoError(t, err)
+			if next == nil {
+				return keys
+			}
+			cursor = next
+		}
+	}
+
+	keys := collect(eoa1, txLocatorKeyLen, 1, 3, 2)
+	assert.Len(t, keys, 3)
+	for i, key := range keys {
+		loc := parseTxLocatorKey(key)
+		assert.EqualValues(t, i+1, loc.BlockHeight)
+		assert.Equal(t, module.TransactionGroupNormal, loc.TransactionGroup)
+		assert.Equal(t, 0, loc.IndexInGroup)
+	}
+
+	keys = collect(eoa2, txLocatorKeyLen, 2, 3, 1)
+	assert.Len(t, keys, 4)
+	assert.Equal(t, 0, parseTxLocatorKey(keys[0]).IndexInGroup)
+	assert.Equal(t, 1, parseTxLocatorKey(keys[1]).IndexInGroup)
+
+	keys = collect(score, txLocatorKeyLen, 1, 3, 10)
+	assert.Len(t, keys, 3)
+
+	keys = collect(score, eventLogLocatorKeyLen, 3, 3, 1)
+	assert.Len(t, keys, 2)
+	assert.Equal(t, 1, parseTxLocatorKey(keys[1]).IndexInGroup)
+
+	_, err := ai.iterate(db.TransactionByAddress, txLocatorKeyLen, eoa1, 1, 3, []byte{1}, 1,
+		func(key []byte) error { return nil })
+	assert.Error(t, err)
+}
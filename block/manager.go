@@ -130,6 +130,9 @@ type manager struct {
 	handlers       handlerList
 	activeHandlers handlerList
 	handlerContext handlerContext
+
+	// addressIndex is nil if the address index is disabled.
+	addressIndex *addressIndex
 }
 
 type handlerList []base.BlockHandler
@@ -604,6 +607,9 @@ func NewManager(
 		m.sm.GetNextBlockVersion(nil),
 	)
 	m.handlerContext.manager = m
+	if chain.AddressIndexEnabled() {
+		m.addressIndex = &addressIndex{m: m}
+	}
 	m.bntr.Logger = chain.Logger().WithFields(log.Fields{
 		log.FieldKeyModule: "BM|BNODE",
 	})
@@ -1095,8 +1101,7 @@ func (m *manager) finalize(bn *bnode, updatePCM bool) error {
 		m.bntr.TraceRef(bn)
 	}
 
-	err = block.(base.BlockVersionSpec).FinalizeHeader(m.chain.Database())
-	if err != nil {
+	if err = m.writeFinalizedBlock(block); err != nil {
 		return err
 	}
 	nextVer := m.sm.GetNextBlockVersion(m.finalized.in.mtransition().Result())
@@ -1104,22 +1109,6 @@ func (m *manager) finalize(bn *bnode, updatePCM bool) error {
 		m.activeHandlers = m.handlers.upTo(nextVer)
 	}
 
-	if err = WriteTransactionLocators(
-		m.db(),
-		block.Height(),
-		block.PatchTransactions(),
-		block.NormalTransactions(),
-	); err != nil {
-		return err
-	}
-	chainProp, err := m.bucketFor(db.ChainProperty)
-	if err != nil {
-		return err
-	}
-	if err = chainProp.Set(db.Raw(keyLastBlockHeight), block.Height()); err != nil {
-		return err
-	}
-
 	if updatePCM {
 		nextPCM, err := m.nextPCM.Update(m.finalized.block)
 		if err != nil {
@@ -1146,6 +1135,37 @@ func (m *manager) finalize(bn *bnode, updatePCM bool) error {
 	return nil
 }
 
+// writeFinalizedBlock writes the header, the transaction locators, the
+// last height of the block and the address index in a batch, so that the
+// block is stored entirely or not at all.
+func (m *manager) writeFinalizedBlock(block module.Block) error {
+	batch := db.NewBatch(m.db())
+	if err := block.(base.BlockVersionSpec).FinalizeHeader(batch); err != nil {
+		return err
+	}
+	if err := WriteTransactionLocators(
+		batch,
+		block.Height(),
+		block.PatchTransactions(),
+		block.NormalTransactions(),
+	); err != nil {
+		return err
+	}
+	chainProp, err := db.NewCodedBucket(batch, db.ChainProperty, nil)
+	if err != nil {
+		return err
+	}
+	if err = chainProp.Set(db.Raw(keyLastBlockHeight), block.Height()); err != nil {
+		return err
+	}
+	if m.addressIndex != nil {
+		if err := m.addressIndex.onFinalize(batch, block); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
 func WriteTransactionLocators(
 	dbase db.Database,
 	height int64,
@@ -1287,6 +1307,10 @@ func (m *manager) getTransactionInfo(id []byte) (module.TransactionInfo, error)
 	if err != nil {
 		return nil, err
 	}
+	return m.newTransactionInfo(loc)
+}
+
+func (m *manager) newTransactionInfo(loc *transactionLocator) (module.TransactionInfo, error) {
 	block, err := m.getBlockByHeight(loc.BlockHeight)
 	if err != nil {
 		return nil, errors.InvalidStateError.Wrapf(err, "block h=%d not found", loc.BlockHeight)
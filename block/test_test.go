@@ -88,6 +88,10 @@ func (c *testChain) Logger() log.Logger {
 	return log.GlobalLogger()
 }
 
+func (c *testChain) AddressIndexEnabled() bool {
+	return false
+}
+
 type testError struct {
 }
 
@@ -46,13 +46,14 @@ func (r *finalizeRequest) finalize(blk module.BlockData) error {
 		return err
 	}
 
-	if err = blk.(base.BlockVersionSpec).FinalizeHeader(r.dbase); err != nil {
+	batch := db.NewBatch(r.dbase)
+	if err = blk.(base.BlockVersionSpec).FinalizeHeader(batch); err != nil {
 		return err
 	}
-	if err = WriteTransactionLocators(r.dbase, blk.Height(), blk.PatchTransactions(), blk.NormalTransactions()); err != nil {
+	if err = WriteTransactionLocators(batch, blk.Height(), blk.PatchTransactions(), blk.NormalTransactions()); err != nil {
 		return err
 	}
-	return nil
+	return batch.Write()
 }
 
 func (r *finalizeRequest) OnValidate(t module.Transition, err error) {
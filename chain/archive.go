@@ -0,0 +1,115 @@
+package chain
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/icon-project/goloop/common/errors"
+)
+
+// ArchiveFrom returns the lowest height whose state is guaranteed to be
+// kept in archive mode. base is the height of the genesis storage, and
+// last is the height of the last block.
+func (c *Config) ArchiveFrom(base, last int64) int64 {
+	if c.ArchiveWindow > 0 && last-c.ArchiveWindow+1 > base {
+		return last - c.ArchiveWindow + 1
+	}
+	return base
+}
+
+// diskUsageTTL is the duration that disk usage of the database is cached.
+var diskUsageTTL = time.Minute
+
+type diskUsage struct {
+	size     int64
+	at       time.Time
+	updating bool
+}
+
+var (
+	diskUsageLock sync.Mutex
+	diskUsages    = make(map[string]*diskUsage)
+)
+
+// DiskUsage returns total size of the files in the database directory.
+// The directory is walked on the first call, then the cached size is
+// returned and it's updated in background after diskUsageTTL.
+func (c *Config) DiskUsage() (int64, error) {
+	dbDir := path.Join(c.AbsBaseDir(), DefaultDBDir)
+
+	diskUsageLock.Lock()
+	u, ok := diskUsages[dbDir]
+	if ok {
+		defer diskUsageLock.Unlock()
+		if !u.updating && time.Since(u.at) >= diskUsageTTL {
+			u.updating = true
+			go u.update(dbDir)
+		}
+		return u.size, nil
+	}
+	diskUsageLock.Unlock()
+
+	size, err := sizeOfDir(dbDir)
+	if err != nil {
+		return 0, err
+	}
+	diskUsageLock.Lock()
+	defer diskUsageLock.Unlock()
+	if _, ok := diskUsages[dbDir]; !ok {
+		diskUsages[dbDir] = &diskUsage{size: size, at: time.Now()}
+	}
+	return size, nil
+}
+
+// update walks the directory again. The previous size is kept on failure,
+// and it's retried after diskUsageTTL.
+func (u *diskUsage) update(dir string) {
+	size, err := sizeOfDir(dir)
+
+	diskUsageLock.Lock()
+	defer diskUsageLock.Unlock()
+	if err == nil {
+		u.size = size
+	}
+	u.at = time.Now()
+	u.updating = false
+}
+
+// sizeOfDir returns total size of the files in the directory.
+func sizeOfDir(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// checkArchive returns error if pruning at the height drops states
+// guaranteed in archive mode.
+func (c *singleChain) checkArchive(height int64) error {
+	if !c.cfg.Archive {
+		return nil
+	}
+	if c.cfg.ArchiveWindow <= 0 {
+		return errors.InvalidStateError.New("ArchiveMode(window=all)")
+	}
+	last := c.lastBlockHeight()
+	from := c.cfg.ArchiveFrom(c.cfg.GenesisStorage.Height(), last)
+	if height > from {
+		return errors.InvalidStateError.Errorf(
+			"InArchiveWindow(height=%d,from=%d,last=%d)", height, from, last)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package chain
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+type testGenesisStorage struct {
+	module.GenesisStorage
+	height int64
+}
+
+func (gs *testGenesisStorage) Height() int64 {
+	return gs.height
+}
+
+func TestConfig_ArchiveFrom(t *testing.T) {
+	tests := []struct {
+		name   string
+		window int64
+		base   int64
+		last   int64
+		from   int64
+	}{
+		{"WindowAll", 0, 0, 100, 0},
+		{"WindowAllWithBase", 0, 30, 100, 30},
+		{"InChain", 10, 0, 100, 91},
+		{"InChainWithBase", 10, 30, 100, 91},
+		{"SameAsChain", 101, 0, 100, 0},
+		{"LargerThanChain", 200, 0, 100, 0},
+		{"LargerThanChainWithBase", 80, 30, 100, 30},
+		{"OneBlock", 1, 0, 100, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{ArchiveWindow: tt.window}
+			assert.Equal(t, tt.from, cfg.ArchiveFrom(tt.base, tt.last))
+		})
+	}
+}
+
+func newArchiveTestChain(t *testing.T, archive bool, window, base, last int64) *singleChain {
+	dbase := db.NewMapDB()
+	assert.NoError(t, block.SetLastHeight(dbase, nil, last))
+	return &singleChain{
+		cfg: Config{
+			Archive:        archive,
+			ArchiveWindow:  window,
+			GenesisStorage: &testGenesisStorage{height: base},
+		},
+		database: dbase,
+	}
+}
+
+func TestSingleChain_CheckArchive(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive bool
+		window  int64
+		base    int64
+		height  int64
+		ok      bool
+	}{
+		{"NotArchive", false, 10, 0, 100, true},
+		{"WindowAll", true, 0, 0, 10, false},
+		{"BelowWindow", true, 10, 0, 50, true},
+		{"WindowStart", true, 10, 0, 91, true},
+		{"InWindow", true, 10, 0, 92, false},
+		{"LastBlock", true, 10, 0, 100, false},
+		{"LargerWindow", true, 200, 0, 1, false},
+		{"LargerWindowAtBase", true, 200, 30, 30, true},
+		{"LargerWindowAboveBase", true, 200, 30, 31, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newArchiveTestChain(t, tt.archive, tt.window, tt.base, 100)
+			err := c.checkArchive(tt.height)
+			if tt.ok {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.InvalidStateError.Equals(err), err)
+			}
+		})
+	}
+}
+
+func TestSingleChain_PruneInArchiveWindow(t *testing.T) {
+	c := newArchiveTestChain(t, true, 10, 0, 100)
+	err := c.Prune("", "", 92)
+	assert.True(t, errors.InvalidStateError.Equals(err), err)
+	assert.Contains(t, err.Error(), "InArchiveWindow")
+
+	c = newArchiveTestChain(t, true, 0, 0, 100)
+	err = c.Prune("", "", 10)
+	assert.True(t, errors.InvalidStateError.Equals(err), err)
+	assert.Contains(t, err.Error(), "ArchiveMode")
+
+	// the chain in Started state refuses the task itself, so it shows
+	// that pruning below the window passes the check of archive mode.
+	c = newArchiveTestChain(t, true, 10, 0, 100)
+	c.state = Started
+	err = c.Prune("", "", 91)
+	assert.True(t, errors.InvalidStateError.Equals(err), err)
+	assert.Contains(t, err.Error(), "InvalidState(state=")
+}
+
+func TestSingleChain_ResetInArchive(t *testing.T) {
+	c := newArchiveTestChain(t, true, 10, 0, 100)
+	err := c.Reset("", 0, nil)
+	assert.True(t, errors.InvalidStateError.Equals(err), err)
+}
+
+func TestConfig_DiskUsage(t *testing.T) {
+	baseDir, err := ioutil.TempDir("", "goloop-diskusage")
+	assert.NoError(t, err)
+	defer os.RemoveAll(baseDir)
+	dbDir := path.Join(baseDir, DefaultDBDir)
+	assert.NoError(t, os.MkdirAll(dbDir, 0700))
+	assert.NoError(t, ioutil.WriteFile(path.Join(dbDir, "a"), make([]byte, 100), 0600))
+
+	cfg := &Config{BaseDir: baseDir}
+	size, err := cfg.DiskUsage()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 100, size)
+
+	// cached until the TTL
+	assert.NoError(t, ioutil.WriteFile(path.Join(dbDir, "b"), make([]byte, 20), 0600))
+	size, err = cfg.DiskUsage()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 100, size)
+
+	// updated in background after the TTL
+	defer func(ttl time.Duration) { diskUsageTTL = ttl }(diskUsageTTL)
+	diskUsageTTL = 0
+	assert.Eventually(t, func() bool {
+		size, err := cfg.DiskUsage()
+		return err == nil && size == 120
+	}, 5*time.Second, 10*time.Millisecond)
+}
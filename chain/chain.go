@@ -113,6 +113,7 @@ const (
 	DefaultContractDir = "contract"
 	DefaultCacheDir    = "cache"
 	DefaultTmpDBDir    = "tmp"
+	DefaultAddressBook = "addressbook.json"
 )
 
 func (c *singleChain) Database() db.Database {
@@ -208,6 +209,14 @@ func (c *singleChain) MaxBlockTxBytes() int {
 	return ConfigDefaultMaxBlockTxBytes
 }
 
+func (c *singleChain) MaxSenderTxs() int {
+	return c.cfg.MaxSenderTxs
+}
+
+func (c *singleChain) MaxSenderTxBytes() int {
+	return c.cfg.MaxSenderTxBytes
+}
+
 func (c *singleChain) DefaultWaitTimeout() time.Duration {
 	if c.cfg.DefWaitTimeout > 0 {
 		return time.Duration(c.cfg.DefWaitTimeout) * time.Millisecond
@@ -246,10 +255,21 @@ func (c *singleChain) NephewsLimit() int {
 	return ConfigDefaultNephewLimit
 }
 
+func (c *singleChain) PeerBanDuration() time.Duration {
+	if c.cfg.PeerBanDuration > 0 {
+		return time.Duration(c.cfg.PeerBanDuration) * time.Second
+	}
+	return ConfigDefaultPeerBanDuration
+}
+
 func (c *singleChain) ValidateTxOnSend() bool {
 	return c.cfg.ValidateTxOnSend
 }
 
+func (c *singleChain) AddressIndexEnabled() bool {
+	return c.cfg.AddressIndex
+}
+
 func (c *singleChain) State() (string, int64, error) {
 	c.mtx.RLock()
 	defer c.mtx.RUnlock()
@@ -458,9 +478,27 @@ func (c *singleChain) _init() error {
 	return nil
 }
 
-func (c *singleChain) prepareManagers() error {
+func (c *singleChain) newNetworkManager() module.NetworkManager {
 	pr := network.PeerRoleFlag(c.cfg.Role)
-	c.nm = network.NewManager(c, c.nt, c.cfg.SeedAddr, pr.ToRoles()...)
+	nm := network.NewManager(c, c.nt, c.cfg.SeedAddr, pr.ToRoles()...)
+	if err := network.SetStaticPeers(nm, c.cfg.StaticPeers); err != nil {
+		c.logger.Warnf("Fail to set static peers err=%+v", err)
+	}
+	if err := network.SetTrustedPeers(nm, c.cfg.TrustedPeers); err != nil {
+		c.logger.Warnf("Fail to set trusted peers err=%+v", err)
+	}
+	if err := network.SetSendPolicies(nm, c.cfg.SendPolicies); err != nil {
+		c.logger.Warnf("Fail to set send policies err=%+v", err)
+	}
+	book := path.Join(c.cfg.AbsBaseDir(), DefaultAddressBook)
+	if err := network.SetAddressBook(nm, book); err != nil {
+		c.logger.Warnf("Fail to load address book file=%s err=%+v", book, err)
+	}
+	return nm
+}
+
+func (c *singleChain) prepareManagers() error {
+	c.nm = c.newNetworkManager()
 
 	chainDir := c.cfg.AbsBaseDir()
 	ContractDir := path.Join(chainDir, DefaultContractDir)
@@ -586,6 +624,9 @@ func (c *singleChain) Prune(gsfile string, dbtype string, height int64) error {
 	if dbtype == "" {
 		dbtype = c.cfg.DBType
 	}
+	if err := c.checkArchive(height); err != nil {
+		return err
+	}
 	task := newTaskPruning(c, gsfile, dbtype, height)
 	return c._runTask(task, false)
 }
@@ -658,6 +699,9 @@ func (c *singleChain) Verify() error {
 }
 
 func (c *singleChain) Reset(gs string, height int64, blockHash []byte) error {
+	if c.cfg.Archive {
+		return errors.InvalidStateError.New("ArchiveMode")
+	}
 	if len(gs) == 0 {
 		chainDir := c.cfg.AbsBaseDir()
 		const chainGenesisZipFileName = "genesis.zip"
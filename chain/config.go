@@ -20,6 +20,7 @@ const (
 	ConfigDefaultTxTimeout        = 5000 * time.Millisecond
 	ConfigDefaultChildrenLimit    = 10
 	ConfigDefaultNephewLimit      = 10
+	ConfigDefaultPeerBanDuration  = 10 * time.Minute
 )
 
 const (
@@ -38,16 +39,25 @@ type Config struct {
 
 	// static
 	SeedAddr         string `json:"seed_addr"`
+	StaticPeers      string `json:"static_peers,omitempty"`
+	TrustedPeers     string `json:"trusted_peers,omitempty"`
+	SendPolicies     string `json:"send_policies,omitempty"`
 	Role             uint   `json:"role"`
 	ConcurrencyLevel int    `json:"concurrency_level,omitempty"`
 	NormalTxPoolSize int    `json:"normal_tx_pool,omitempty"`
 	PatchTxPoolSize  int    `json:"patch_tx_pool,omitempty"`
 	MaxBlockTxBytes  int    `json:"max_block_tx_bytes,omitempty"`
+	MaxSenderTxs     int    `json:"max_sender_txs,omitempty"`
+	MaxSenderTxBytes int    `json:"max_sender_tx_bytes,omitempty"`
 	NodeCache        string `json:"node_cache,omitempty"`
 	AutoStart        bool   `json:"auto_start,omitempty"`
 	ChildrenLimit    *int   `json:"children_limit,omitempty"`
 	NephewsLimit     *int   `json:"nephews_limit,omitempty"`
+	PeerBanDuration  int64  `json:"peer_ban_duration,omitempty"`
 	ValidateTxOnSend bool   `json:"validate_tx_on_send,omitempty"`
+	AddressIndex     bool   `json:"address_index,omitempty"`
+	Archive          bool   `json:"archive,omitempty"`
+	ArchiveWindow    int64  `json:"archive_window,omitempty"`
 
 	// runtime
 	Channel        string `json:"channel"`
@@ -36,6 +36,10 @@ func (d *writerDatabase) Delete(key []byte) error {
 	panic("unsupported")
 }
 
+func (d *writerDatabase) NewIterator(r *db.Range, reverse bool) (db.Iterator, error) {
+	return db.NewEmptyIterator(), nil
+}
+
 func (d *writerDatabase) GetBucket(id db.BucketID) (db.Bucket, error) {
 	if id == db.BytesByHash || id == db.MerkleTrie {
 		return d, nil
@@ -77,6 +81,10 @@ func (d *readerDatabase) Delete(key []byte) error {
 	return errors.UnsupportedError.Errorf("GenesisStorageIsReadOnly")
 }
 
+func (d *readerDatabase) NewIterator(r *db.Range, reverse bool) (db.Iterator, error) {
+	return nil, errors.UnsupportedError.Errorf("GenesisStorageIsNotIterable")
+}
+
 func (d *readerDatabase) GetBucket(id db.BucketID) (db.Bucket, error) {
 	if id == db.BytesByHash || id == db.MerkleTrie {
 		return d, nil
@@ -36,7 +36,6 @@ import (
 	"github.com/icon-project/goloop/consensus"
 	"github.com/icon-project/goloop/icon/blockv0/lcstore"
 	"github.com/icon-project/goloop/icon/lcimporter"
-	"github.com/icon-project/goloop/network"
 )
 
 const (
@@ -182,8 +181,7 @@ func (t *taskImportICON) _import() (ret error) {
 	config.ProxyMgr = c.pm
 
 	// initialize network manager
-	pr := network.PeerRoleFlag(c.cfg.Role)
-	c.nm = network.NewManager(c, c.nt, c.cfg.SeedAddr, pr.ToRoles()...)
+	c.nm = c.newNetworkManager()
 
 	// initialize service manager
 	if sm, err := lcimporter.NewServiceManager(c, t.dbase, config, t); err != nil {
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	IndexAddressTask = "index_address"
+)
+
+var indexAddressStates = map[State]string{
+	Starting: "indexing starting",
+	Stopping: "indexing stopping",
+	Failed:   "indexing failed",
+	Finished: "indexing done",
+}
+
+type indexAddressParams struct {
+	From int64 `json:"from"`
+}
+
+// taskIndexAddress builds the address index for the blocks finalized
+// before the index is enabled.
+type taskIndexAddress struct {
+	chain   *singleChain
+	result  resultStore
+	from    int64
+	current int64
+	stopped int32
+}
+
+func (t *taskIndexAddress) String() string {
+	return fmt.Sprintf("IndexAddress(from=%d)", t.from)
+}
+
+func (t *taskIndexAddress) DetailOf(s State) string {
+	switch s {
+	case Started:
+		return fmt.Sprintf("indexing %d", atomic.LoadInt64(&t.current))
+	default:
+		if st, ok := indexAddressStates[s]; ok {
+			return st
+		} else {
+			return s.String()
+		}
+	}
+}
+
+func (t *taskIndexAddress) Start() error {
+	if err := t.chain.prepareManagers(); err != nil {
+		return err
+	}
+	ai, err := t.chain.bm.GetAddressIndex()
+	if err != nil {
+		t.chain.releaseManagers()
+		return err
+	}
+	go t.doIndex(ai)
+	return nil
+}
+
+func (t *taskIndexAddress) doIndex(ai module.AddressIndex) {
+	defer t.chain.releaseManagers()
+	err := ai.Build(t.from, t.OnIndex)
+	t.result.SetValue(err)
+}
+
+func (t *taskIndexAddress) OnIndex(height int64) error {
+	if atomic.LoadInt32(&t.stopped) != 0 {
+		return errors.ErrInterrupted
+	}
+	atomic.StoreInt64(&t.current, height)
+	return nil
+}
+
+func (t *taskIndexAddress) Stop() {
+	atomic.StoreInt32(&t.stopped, 1)
+}
+
+func (t *taskIndexAddress) Wait() error {
+	return t.result.Wait()
+}
+
+func taskIndexAddressFactory(c *singleChain, params json.RawMessage) (chainTask, error) {
+	p := new(indexAddressParams)
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, p); err != nil {
+			return nil, err
+		}
+	}
+	if p.From < c.GenesisStorage().Height() {
+		p.From = c.GenesisStorage().Height()
+	}
+	return &taskIndexAddress{
+		chain: c,
+		from:  p.From,
+	}, nil
+}
+
+func init() {
+	registerTaskFactory(IndexAddressTask, taskIndexAddressFactory)
+}
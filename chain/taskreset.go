@@ -29,7 +29,6 @@ import (
 	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/consensus/fastsync"
 	"github.com/icon-project/goloop/module"
-	"github.com/icon-project/goloop/network"
 	"github.com/icon-project/goloop/service"
 )
 
@@ -139,8 +138,7 @@ func (t *taskReset) _prepareBlocks(height int64, blockHash []byte) (module.Block
 
 	chainDir := c.cfg.AbsBaseDir()
 
-	pr := network.PeerRoleFlag(c.cfg.Role)
-	c.nm = network.NewManager(c, c.nt, c.cfg.SeedAddr, pr.ToRoles()...)
+	c.nm = c.newNetworkManager()
 
 	ContractDir := path.Join(chainDir, DefaultContractDir)
 	var err error
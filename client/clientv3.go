@@ -156,6 +156,55 @@ type BTPNetworkTypeInfo struct {
 	NetworkTypeID    jsonrpc.HexInt   `json:"networkTypeID"`
 }
 
+//refer service/manager.go storageProof
+type StorageProof struct {
+	Key   jsonrpc.HexBytes   `json:"key"`
+	Value *jsonrpc.HexBytes  `json:"value"`
+	Proof []jsonrpc.HexBytes `json:"proof"`
+}
+
+//refer service/manager.go accountProof.ToJSON
+type AccountProof struct {
+	Address       jsonrpc.Address    `json:"address"`
+	StateHash     jsonrpc.HexBytes   `json:"stateHash"`
+	Account       *jsonrpc.HexBytes  `json:"account"`
+	Proof         []jsonrpc.HexBytes `json:"proof"`
+	StorageHash   *jsonrpc.HexBytes  `json:"storageHash,omitempty"`
+	StorageProofs []StorageProof     `json:"storageProofs,omitempty"`
+}
+
+//refer server/v3/api_v3.go getTransactionsByAddress
+type TransactionsByAddress struct {
+	FromHeight   jsonrpc.HexInt   `json:"fromHeight"`
+	ToHeight     jsonrpc.HexInt   `json:"toHeight"`
+	Transactions []Transaction    `json:"transactions"`
+	Cursor       jsonrpc.HexBytes `json:"cursor,omitempty"`
+}
+
+//refer server/v3/api_v3.go LogInfo
+type EventLogInfo struct {
+	TxHash      jsonrpc.HexBytes `json:"txHash"`
+	BlockHash   jsonrpc.HexBytes `json:"blockHash"`
+	BlockHeight jsonrpc.HexInt   `json:"blockHeight"`
+	TxIndex     jsonrpc.HexInt   `json:"txIndex"`
+	EventIndex  jsonrpc.HexInt   `json:"eventIndex"`
+	EventLog    EventLog         `json:"eventLog"`
+}
+
+//refer server/v3/api_v3.go getEventLogsByAddress
+type EventLogsByAddress struct {
+	FromHeight jsonrpc.HexInt   `json:"fromHeight"`
+	ToHeight   jsonrpc.HexInt   `json:"toHeight"`
+	EventLogs  []EventLogInfo   `json:"eventLogs"`
+	Cursor     jsonrpc.HexBytes `json:"cursor,omitempty"`
+}
+
+//refer server/v3/api_v3.go LogsResult
+type LogsResult struct {
+	LastHeight jsonrpc.HexInt `json:"lastHeight"`
+	Logs       []EventLogInfo `json:"logs"`
+}
+
 //refer server/v3/api_v3.go:953 getBTPSourceInformation
 type BTPSourceInformation struct {
 	SrcNetworkUID  string           `json:"srcNetworkUID"`
@@ -304,6 +353,32 @@ func (c *ClientV3) SendRawTransaction(w module.Wallet, param map[string]interfac
 	return &result, nil
 }
 
+func (c *ClientV3) CancelTransaction(w module.Wallet, param *v3.CancelTransactionParam) (*jsonrpc.HexBytes, error) {
+	param.Timestamp = jsonrpc.HexInt(intconv.FormatInt(time.Now().UnixNano() / int64(time.Microsecond)))
+	js, err := json.Marshal(param)
+	if err != nil {
+		return nil, err
+	}
+
+	bs, err := transaction.SerializeJSON(js, nil, txSerializeExcludes)
+	if err != nil {
+		return nil, err
+	}
+	bs = append([]byte("icx_cancelTransaction."), bs...)
+	sig, err := w.Sign(crypto.SHA3Sum256(bs))
+	if err != nil {
+		return nil, err
+	}
+
+	param.Signature = base64.StdEncoding.EncodeToString(sig)
+
+	var result jsonrpc.HexBytes
+	if _, err = c.Do("icx_cancelTransaction", param, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 //using blockHeader.NextValidatorsHash
 func (c *ClientV3) GetDataByHash(param *v3.DataHashParam) ([]byte, error) {
 	var result []byte
@@ -351,6 +426,46 @@ func (c *ClientV3) GetProofForEvents(param *v3.ProofEventsParam) ([][][]byte, er
 	return result, nil
 }
 
+func (c *ClientV3) GetProofForAccount(param *v3.AddressParam) (*AccountProof, error) {
+	result := &AccountProof{}
+	if _, err := c.Do("icx_getProofForAccount", param, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *ClientV3) GetProofForStorage(param *v3.ProofStorageParam) (*AccountProof, error) {
+	result := &AccountProof{}
+	if _, err := c.Do("icx_getProofForStorage", param, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *ClientV3) GetTransactionsByAddress(param *v3.AddressIndexParam) (*TransactionsByAddress, error) {
+	result := &TransactionsByAddress{}
+	if _, err := c.Do("icx_getTransactionsByAddress", param, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *ClientV3) GetEventLogsByAddress(param *v3.AddressIndexParam) (*EventLogsByAddress, error) {
+	result := &EventLogsByAddress{}
+	if _, err := c.Do("icx_getEventLogsByAddress", param, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *ClientV3) GetLogs(param *v3.LogsParam) (*LogsResult, error) {
+	result := &LogsResult{}
+	if _, err := c.Do("icx_getLogs", param, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *ClientV3) GetBTPNetworkInfo(param *v3.BTPQueryParam) (*BTPNetworkInfo, error) {
 	ni := &BTPNetworkInfo{}
 	if _, err := c.Do("btp_getNetworkInfo", param, ni); err != nil {
@@ -529,12 +644,26 @@ func (c *ClientV3) wsClose(conn *websocket.Conn) {
 	conn.Close()
 }
 
+// wsReadJSONLoop reads notifications of the type of respPtr, and it
+// passes progress notifications as *server.ProgressNotification.
 func (c *ClientV3) wsReadJSONLoop(conn *websocket.Conn, respPtr interface{}, cb func(v interface{})) {
 	elem := reflect.ValueOf(respPtr).Elem()
 	for {
+		_, bs, err := conn.ReadMessage()
+		if err != nil {
+			cb(err)
+			return
+		}
+		var pn struct {
+			Progress *common.HexInt64 `json:"progress"`
+		}
+		if err = json.Unmarshal(bs, &pn); err == nil && pn.Progress != nil {
+			cb(&server.ProgressNotification{Progress: *pn.Progress})
+			continue
+		}
 		v := reflect.New(elem.Type())
 		ptr := v.Interface()
-		if err := conn.ReadJSON(ptr); err != nil {
+		if err = json.Unmarshal(bs, ptr); err != nil {
 			cb(err)
 			return
 		}
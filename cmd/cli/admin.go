@@ -122,6 +122,9 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 			genesisPath, _ := fs.GetString("genesis_template")
 			param := &node.ChainConfig{}
 			param.SeedAddr, _ = fs.GetString("seed")
+			param.StaticPeers, _ = fs.GetString("static_peers")
+			param.TrustedPeers, _ = fs.GetString("trusted_peers")
+			param.SendPolicies, _ = fs.GetString("send_policies")
 			param.Role, _ = fs.GetUint("role")
 			param.DBType, _ = fs.GetString("db_type")
 			param.Platform, _ = fs.GetString("platform")
@@ -129,6 +132,8 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 			param.NormalTxPoolSize, _ = fs.GetInt("normal_tx_pool")
 			param.PatchTxPoolSize, _ = fs.GetInt("patch_tx_pool")
 			param.MaxBlockTxBytes, _ = fs.GetInt("max_block_tx_bytes")
+			param.MaxSenderTxs, _ = fs.GetInt("max_sender_txs")
+			param.MaxSenderTxBytes, _ = fs.GetInt("max_sender_tx_bytes")
 			param.NodeCache, _ = fs.GetString("node_cache")
 			param.Channel, _ = fs.GetString("channel")
 			param.SecureSuites, _ = fs.GetString("secure_suites")
@@ -146,6 +151,10 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 				param.NephewsLimit = &nephewsLimit
 			}
 			param.ValidateTxOnSend, _ = fs.GetBool("validate_tx_on_send")
+			param.AddressIndex, _ = fs.GetBool("address_index")
+			param.Archive, _ = fs.GetBool("archive")
+			param.ArchiveWindow, _ = fs.GetInt64("archive_window")
+			param.PeerBanDuration, _ = fs.GetInt64("peer_ban_duration")
 
 			var buf *bytes.Buffer
 			if len(genesisZip) > 0 {
@@ -183,6 +192,9 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 	joinFlags.String("genesis", "", "Genesis storage path")
 	joinFlags.String("genesis_template", "", "Genesis template directory or file")
 	joinFlags.String("seed", "", "List of trust-seed ip-port, Comma separated string")
+	joinFlags.String("static_peers", "", "List of ip-port of peers always kept connected, Comma separated string")
+	joinFlags.String("trusted_peers", "", "List of addresses of trusted peers never banned (ADDRESS[@HOST:PORT]), Comma separated string")
+	joinFlags.String("send_policies", "", "Send priority(1:highest ~ 7:lowest) and bandwidth(bytes/s, 0:unlimited) of protocols, Comma separated PROTOCOL:PRIORITY[:BANDWIDTH] (ex: consensus:1,transaction:2,fastsync:3:1048576)")
 	joinFlags.Uint("role", 3, "[0:None, 1:Seed, 2:Validator, 3:Both]")
 	joinFlags.String("db_type", "goleveldb", "Name of database system("+strings.Join(db.RegisteredBackendTypes(), ", ")+")")
 	joinFlags.String("platform", "", "Name of service platform")
@@ -190,10 +202,12 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 	joinFlags.Int("normal_tx_pool", 0, "Size of normal transaction pool")
 	joinFlags.Int("patch_tx_pool", 0, "Size of patch transaction pool")
 	joinFlags.Int("max_block_tx_bytes", 0, "Max size of transactions in a block")
+	joinFlags.Int("max_sender_txs", 0, "Max number of transactions from a sender in the pool (0: no limit)")
+	joinFlags.Int("max_sender_tx_bytes", 0, "Max size of transactions from a sender in the pool (0: no limit)")
 	joinFlags.String("node_cache", chain.NodeCacheDefault, "Node cache (none,small,large)")
 	joinFlags.String("channel", "", "Channel")
 	joinFlags.String("secure_suites", "none,tls,ecdhe",
-		"Supported Secure suites with order (none,tls,ecdhe) - Comma separated string")
+		"Supported Secure suites with order (none,tls,ecdhe,tls13,noise) - Comma separated string")
 	joinFlags.String("secure_aeads", "chacha,aes128,aes256",
 		"Supported Secure AEAD with order (chacha,aes128,aes256) - Comma separated string")
 	joinFlags.Int64("default_wait_timeout", 0, "Default wait timeout in milli-second (0: disable)")
@@ -203,6 +217,10 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 	joinFlags.Int("children_limit", -1, "Maximum number of child connections (-1: uses system default value)")
 	joinFlags.Int("nephews_limit", -1, "Maximum number of nephew connections (-1: uses system default value)")
 	joinFlags.Bool("validate_tx_on_send", false, "Validate transaction on send")
+	joinFlags.Bool("address_index", false, "Index transactions and event logs by addresses")
+	joinFlags.Bool("archive", false, "Keep states of blocks in the archive window")
+	joinFlags.Int64("archive_window", 0, "Number of latest blocks whose states are kept in archive mode (0: all blocks)")
+	joinFlags.Int64("peer_ban_duration", 0, "Duration of banning misbehaving peers in second (0: uses system default value)")
 
 	leaveCmd := &cobra.Command{
 		Use:   "leave CID",
@@ -259,6 +277,100 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 	inspectCmd.Flags().StringP("format", "f", "", "Format the output using the given Go template")
 	inspectCmd.Flags().Bool("informal", false, "Inspect with informal data")
 
+	txPoolCmd := &cobra.Command{
+		Use:   "txpool CID [TX_HASH]",
+		Short: "Inspect transaction pool",
+		Args:  ArgsWithDefaultErrorFunc(cobra.RangeArgs(1, 2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reqUrl := node.UrlChain + "/" + args[0] + "/txpool"
+			params := &url.Values{}
+			fs := cmd.Flags()
+			content, _ := fs.GetBool("content")
+			from, _ := fs.GetString("from")
+			if len(args) > 1 {
+				reqUrl += "/" + args[1]
+			} else if content || from != "" {
+				reqUrl += "/content"
+				if from != "" {
+					params.Add("from", from)
+				}
+			}
+			var v interface{}
+			if _, err := adminClient.Get(reqUrl, &v, params); err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, v)
+		},
+	}
+	rootCmd.AddCommand(txPoolCmd)
+	txPoolCmd.Flags().Bool("content", false, "List transactions in the pool")
+	txPoolCmd.Flags().String("from", "", "List transactions sent by the address")
+
+	rootCmd.AddCommand(&cobra.Command{
+		Use:   "peers CID",
+		Short: "List reputations of peers",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reqUrl := node.UrlChain + "/" + args[0] + "/peer"
+			var v interface{}
+			if _, err := adminClient.Get(reqUrl, &v); err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, v)
+		},
+	})
+
+	rootCmd.AddCommand(&cobra.Command{
+		Use:   "addressbook CID",
+		Short: "List static peers, trusted peers and the address book",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reqUrl := node.UrlChain + "/" + args[0] + "/addressbook"
+			var v interface{}
+			if _, err := adminClient.Get(reqUrl, &v); err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, v)
+		},
+	})
+
+	banCmd := &cobra.Command{
+		Use:   "ban CID PEER_ID",
+		Short: "Ban the peer and close the connection with it",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &node.ChainBanParam{}
+			param.Duration, _ = cmd.Flags().GetInt64("duration")
+			if param.Duration < 0 {
+				return fmt.Errorf("duration should be zero or positive value")
+			}
+			var v string
+			reqUrl := node.UrlChain + "/" + args[0] + "/peer/" + args[1] + "/ban"
+			if _, err := adminClient.PostWithJson(reqUrl, param, &v); err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+	rootCmd.AddCommand(banCmd)
+	banCmd.Flags().Int64("duration", 0, "Ban duration in second (0: uses peer_ban_duration of the chain)")
+
+	rootCmd.AddCommand(&cobra.Command{
+		Use:   "unban CID PEER_ID",
+		Short: "Unban the peer",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var v string
+			reqUrl := node.UrlChain + "/" + args[0] + "/peer/" + args[1] + "/unban"
+			if _, err := adminClient.Post(reqUrl, &v); err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	})
+
 	opFunc := func(op string) func(cmd *cobra.Command, args []string) error {
 		return func(cmd *cobra.Command, args []string) error {
 			reqUrl := node.UrlChain + "/" + args[0] + "/" + op
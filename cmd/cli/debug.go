@@ -56,9 +56,12 @@ func NewDebugCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 		Short: "Get trace of the transaction",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			param := &v3.TransactionHashParam{
+			param := &v3.TraceParam{
 				Hash: jsonrpc.HexBytes(args[0]),
 			}
+			if mode, _ := cmd.Flags().GetString("mode"); mode != "" {
+				param.Mode = mode
+			}
 			trace, err := debugClient.Do("debug_getTrace", param, nil)
 			if err != nil {
 				return err
@@ -67,6 +70,7 @@ func NewDebugCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 		},
 	}
 	rootCmd.AddCommand(traceCmd)
+	traceCmd.Flags().String("mode", "", "Trace mode (invoke, callTree, stateDiff, stepProfile)")
 
 	return rootCmd, vc
 }
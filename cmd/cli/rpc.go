@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
 	"github.com/icon-project/goloop/client"
@@ -400,6 +401,169 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				return JsonPrettyPrintln(os.Stdout, raw)
 			},
 		})
+	proofForAccountCmd := &cobra.Command{
+		Use:   "proofforaccount ADDRESS",
+		Short: "GetProofForAccount",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &v3.AddressParam{Address: jsonrpc.Address(args[0])}
+			height, err := intconv.ParseInt(cmd.Flag("height").Value.String(), 64)
+			if err != nil {
+				return err
+			}
+			if height != -1 {
+				param.Height = jsonrpc.HexInt(intconv.FormatInt(height))
+			}
+			proof, err := rpcClient.GetProofForAccount(param)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, proof)
+		},
+	}
+	rootCmd.AddCommand(proofForAccountCmd)
+	flags = proofForAccountCmd.Flags()
+	flags.Int("height", -1, "BlockHeight")
+
+	proofForStorageCmd := &cobra.Command{
+		Use:   "proofforstorage SCORE KEYS",
+		Short: "GetProofForStorage",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			strs := strings.Split(args[1], ",")
+			keys := make([]jsonrpc.HexBytes, len(strs))
+			for i, str := range strs {
+				keys[i] = jsonrpc.HexBytes(str)
+			}
+			param := &v3.ProofStorageParam{
+				Score: jsonrpc.Address(args[0]),
+				Keys:  keys,
+			}
+			height, err := intconv.ParseInt(cmd.Flag("height").Value.String(), 64)
+			if err != nil {
+				return err
+			}
+			if height != -1 {
+				param.Height = jsonrpc.HexInt(intconv.FormatInt(height))
+			}
+			proof, err := rpcClient.GetProofForStorage(param)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, proof)
+		},
+	}
+	rootCmd.AddCommand(proofForStorageCmd)
+	flags = proofForStorageCmd.Flags()
+	flags.Int("height", -1, "BlockHeight")
+
+	addressIndexParam := func(cmd *cobra.Command, addr string) *v3.AddressIndexParam {
+		param := &v3.AddressIndexParam{Address: jsonrpc.Address(addr)}
+		fs := cmd.Flags()
+		if from, _ := fs.GetInt64("from"); from != -1 {
+			param.FromHeight = jsonrpc.HexInt(intconv.FormatInt(from))
+		}
+		if to, _ := fs.GetInt64("to"); to != -1 {
+			param.ToHeight = jsonrpc.HexInt(intconv.FormatInt(to))
+		}
+		if limit, _ := fs.GetInt("limit"); limit > 0 {
+			param.Limit = jsonrpc.HexInt(intconv.FormatInt(int64(limit)))
+		}
+		if cursor, _ := fs.GetString("cursor"); cursor != "" {
+			param.Cursor = jsonrpc.HexBytes(cursor)
+		}
+		return param
+	}
+	addAddressIndexFlags := func(fs *pflag.FlagSet) {
+		fs.Int64("from", -1, "From BlockHeight (default: first indexed block)")
+		fs.Int64("to", -1, "To BlockHeight (default: last indexed block)")
+		fs.Int("limit", 0, "Maximum number of items (default: server default)")
+		fs.String("cursor", "", "Cursor returned by the previous query")
+	}
+
+	logsCmd := &cobra.Command{
+		Use:   "logs FROM_HEIGHT [TO_HEIGHT]",
+		Short: "GetLogs",
+		Args:  ArgsWithDefaultErrorFunc(cobra.RangeArgs(1, 2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &v3.LogsParam{}
+			from, err := intconv.ParseInt(args[0], 64)
+			if err != nil {
+				return err
+			}
+			param.FromHeight = common.HexInt64{Value: from}
+			if len(args) > 1 {
+				to, err := intconv.ParseInt(args[1], 64)
+				if err != nil {
+					return err
+				}
+				param.ToHeight = &common.HexInt64{Value: to}
+			}
+			fs := cmd.Flags()
+			param.Signature, _ = fs.GetString("event")
+			addrs, _ := fs.GetStringSlice("addr")
+			for _, addr := range addrs {
+				param.Addrs = append(param.Addrs, common.MustNewAddressFromString(addr))
+			}
+			indexed, _ := fs.GetStringSlice("indexed")
+			for i := range indexed {
+				param.Indexed = append(param.Indexed, &indexed[i])
+			}
+			data, _ := fs.GetStringSlice("data")
+			for i := range data {
+				param.Data = append(param.Data, &data[i])
+			}
+			if limit, _ := fs.GetInt32("limit"); limit > 0 {
+				param.Limit = &common.HexInt32{Value: limit}
+			}
+			logs, err := rpcClient.GetLogs(param)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, logs)
+		},
+	}
+	rootCmd.AddCommand(logsCmd)
+	flags = logsCmd.Flags()
+	flags.StringSlice("addr", nil, "SCORE Addresses, comma-separated string")
+	flags.String("event", "", "Signature of Event")
+	flags.StringSlice("indexed", nil, "Indexed Arguments of Event, comma-separated string")
+	flags.StringSlice("data", nil, "Not indexed Arguments of Event, comma-separated string")
+	flags.Int32("limit", 0, "Maximum number of logs (default: server maximum)")
+	MarkAnnotationRequired(flags, "event")
+
+	txByAddressCmd := &cobra.Command{
+		Use:   "txbyaddress ADDRESS",
+		Short: "GetTransactionsByAddress",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := addressIndexParam(cmd, args[0])
+			txs, err := rpcClient.GetTransactionsByAddress(param)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, txs)
+		},
+	}
+	rootCmd.AddCommand(txByAddressCmd)
+	addAddressIndexFlags(txByAddressCmd.Flags())
+
+	eventLogsByAddressCmd := &cobra.Command{
+		Use:   "eventlogsbyaddress ADDRESS",
+		Short: "GetEventLogsByAddress",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := addressIndexParam(cmd, args[0])
+			els, err := rpcClient.GetEventLogsByAddress(param)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, els)
+		},
+	}
+	rootCmd.AddCommand(eventLogsByAddressCmd)
+	addAddressIndexFlags(eventLogsByAddressCmd.Flags())
+
 	scoreStatusCmd := &cobra.Command{
 		Use:   "scorestatus ADDRESS",
 		Short: "Get status of the smart contract",
@@ -1011,6 +1175,14 @@ func NewSendTxCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comman
 	return rootCmd
 }
 
+// printNotification prints notifications including progress notifications
+// of the monitor.
+func printNotification(v interface{}) {
+	if _, ok := v.(error); !ok {
+		JsonPrettyPrintln(os.Stdout, v)
+	}
+}
+
 func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Command {
 	var rpcClient client.ClientV3
 	rootCmd, vc := NewCommand(parentCmd, parentVc, "monitor", "Monitor")
@@ -1028,6 +1200,9 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 				return err
 			}
 			param := &server.BlockRequest{Height: common.HexInt64{Value: height}}
+			if progress, _ := cmd.Flags().GetInt64("progress"); progress > 0 {
+				param.ProgressInterval = common.HexInt64{Value: progress}
+			}
 			fs, err := cmd.Flags().GetStringArray("filter")
 			if err != nil {
 				return err
@@ -1049,9 +1224,8 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 				param.EventFilters = append(param.EventFilters, ef)
 			}
 			OnInterrupt(rpcClient.Cleanup)
-			err = rpcClient.MonitorBlock(param, func(v *server.BlockNotification) {
-				JsonPrettyPrintln(os.Stdout, v)
-			}, nil)
+			err = rpcClient.Monitor("/block", param, &server.BlockNotification{},
+				printNotification, nil)
 			if err != nil {
 				return err
 			}
@@ -1062,6 +1236,8 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 	monitorBlockFlags := monitorBlockCmd.Flags()
 	monitorBlockFlags.StringArray("filter", nil,
 		"EventFilter raw json file or json string")
+	monitorBlockFlags.Int64("progress", 0,
+		"Interval of progress notifications in blocks (0: disabled)")
 
 	monitorEventCmd := &cobra.Command{
 		Use:   "event HEIGHT",
@@ -1101,8 +1277,10 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 			if sig := cmd.Flag("event").Value.String(); sig != "" {
 				param.Signature = sig
 			}
-			if addr := cmd.Flag("addr").Value.String(); addr != "" {
-				param.Addr = common.MustNewAddressFromString(addr)
+			if addrs, err := cmd.Flags().GetStringSlice("addr"); err == nil && len(addrs) > 0 {
+				for _, addr := range addrs {
+					param.Addrs = append(param.Addrs, common.MustNewAddressFromString(addr))
+				}
 			}
 			if evtIndexed, err := cmd.Flags().GetStringSlice("indexed"); err == nil && len(evtIndexed) > 0 {
 				param.Indexed = make([]*string, len(evtIndexed))
@@ -1116,10 +1294,30 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 					param.Data[i] = &v
 				}
 			}
+			if progress, _ := cmd.Flags().GetInt64("progress"); progress > 0 {
+				param.ProgressInterval = common.HexInt64{Value: progress}
+			}
+			if after, _ := cmd.Flags().GetStringSlice("after"); len(after) > 0 {
+				if len(after) != 3 {
+					return errors.Errorf("InvalidParameter (after)")
+				}
+				var pos [3]int64
+				for i, v := range after {
+					n, err := intconv.ParseInt(v, 64)
+					if err != nil {
+						return err
+					}
+					pos[i] = n
+				}
+				param.After = &server.EventPosition{
+					Height: common.HexInt64{Value: pos[0]},
+					Index:  common.HexInt32{Value: int32(pos[1])},
+					Event:  common.HexInt32{Value: int32(pos[2])},
+				}
+			}
 			OnInterrupt(rpcClient.Cleanup)
-			err := rpcClient.MonitorEvent(param, func(v *server.EventNotification) {
-				JsonPrettyPrintln(os.Stdout, v)
-			}, nil)
+			err := rpcClient.Monitor("/event", param, &server.EventNotification{},
+				printNotification, nil)
 			if err != nil {
 				return err
 			}
@@ -1129,11 +1327,13 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 
 	rootCmd.AddCommand(monitorEventCmd)
 	monitorEventFlags := monitorEventCmd.Flags()
-	monitorEventFlags.String("addr", "", "SCORE Address")
+	monitorEventFlags.StringSlice("addr", nil, "SCORE Addresses, comma-separated string")
 	monitorEventFlags.String("event", "", "Signature of Event")
 	monitorEventFlags.StringSlice("indexed", nil, "Indexed Arguments of Event, comma-separated string")
 	monitorEventFlags.StringSlice("data", nil, "Not indexed Arguments of Event, comma-separated string")
 	monitorEventFlags.String("raw", "", "EventFilter raw json file or json-string")
+	monitorEventFlags.Int64("progress", 0, "Interval of progress notifications in blocks (0: disabled)")
+	monitorEventFlags.StringSlice("after", nil, "Resume after the event, HEIGHT,INDEX,EVENT")
 
 	monitorBTPCmd := &cobra.Command{
 		Use:   "btp HEIGHT",
@@ -1171,10 +1371,13 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 				}
 			}
 
+			if progress, _ := cmd.Flags().GetInt64("progress"); progress > 0 {
+				param.ProgressInterval = common.HexInt64{Value: progress}
+			}
+
 			OnInterrupt(rpcClient.Cleanup)
-			err := rpcClient.MonitorBtp(param, func(v *server.BTPNotification) {
-				JsonPrettyPrintln(os.Stdout, v)
-			}, nil)
+			err := rpcClient.Monitor("/btp", param, &server.BTPNotification{},
+				printNotification, nil)
 			if err != nil {
 				return err
 			}
@@ -1187,6 +1390,8 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 		"BTP Network ID")
 	monitorBTPFlags.String("proofFlag", "",
 		"Proof Included for BTP Header(include proof : 0x1, only btp header : 0x0)")
+	monitorBTPFlags.Int64("progress", 0,
+		"Interval of progress notifications in blocks (0: disabled)")
 
 	return rootCmd
 }
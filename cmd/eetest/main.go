@@ -120,6 +120,10 @@ func (cc *callContext) OnSetFeeProportion(portion int) {
 	fmt.Printf("CallContext.OnSetPortion(portion=%d)", portion)
 }
 
+func (cc *callContext) OnSteps(stepType string, size int, steps *big.Int) {
+	fmt.Printf("CallContext.OnSteps(type=%s,size=%d,steps=%s)\n", stepType, size, steps)
+}
+
 func (cc *callContext) SetCode(code []byte) error {
 	fmt.Println("CallContext.SetCode")
 	return nil
@@ -24,7 +24,10 @@ import (
 	"github.com/icon-project/goloop/common/wallet"
 	"github.com/icon-project/goloop/network"
 	"github.com/icon-project/goloop/server"
+	"github.com/icon-project/goloop/server/eth"
+	"github.com/icon-project/goloop/server/graphql"
 	"github.com/icon-project/goloop/server/metric"
+	"github.com/icon-project/goloop/server/rosetta"
 	"github.com/icon-project/goloop/service/eeproxy"
 )
 
@@ -41,11 +44,16 @@ type GoChainConfig struct {
 	RPCDump       bool   `json:"rpc_dump"`
 	RPCDebug      bool   `json:"rpc_debug"`
 	RPCRosetta    bool   `json:"rpc_rosetta"`
+	RPCEth        bool   `json:"rpc_eth"`
+	RPCGraphQL    bool   `json:"rpc_graphql"`
+	GraphQLCost   int    `json:"rpc_graphql_max_cost,omitempty"`
 	RPCBatchLimit int    `json:"rpc_batch_limit,omitempty"`
 	EEInstances   int    `json:"ee_instances"`
 	Engines       string `json:"engines"`
 	WSMaxSession  int    `json:"ws_max_session"`
 
+	RPCRateLimit *server.RateLimitConfig `json:"rpc_rate_limit,omitempty"`
+
 	Key          []byte          `json:"key,omitempty"`
 	KeyStoreData json.RawMessage `json:"key_store"`
 	KeyStorePass string          `json:"key_password"`
@@ -117,8 +125,14 @@ func main() {
 	flag.BoolVar(&cfg.RPCDump, "rpc_dump", false, "JSON-RPC Request, Response Dump flag")
 	flag.BoolVar(&cfg.RPCDebug, "rpc_debug", false, "JSON-RPC Debug enable")
 	flag.BoolVar(&cfg.RPCRosetta, "rpc_rosetta", false, "JSON-RPC Rosetta enable")
+	flag.BoolVar(&cfg.RPCEth, "rpc_eth", false, "JSON-RPC Ethereum compatible API enable")
+	flag.BoolVar(&cfg.RPCGraphQL, "rpc_graphql", false, "GraphQL API enable")
+	flag.IntVar(&cfg.GraphQLCost, "rpc_graphql_max_cost", graphql.DefaultMaxCost, "GraphQL maximum cost of a query")
 	flag.IntVar(&cfg.RPCBatchLimit, "rpc_batch_limit", 10, "JSON-RPC batch limit")
 	flag.StringVar(&cfg.SeedAddr, "seed", "", "Ip-port of Seed")
+	flag.StringVar(&cfg.StaticPeers, "static_peers", "", "Ip-ports of peers always kept connected (comma separated)")
+	flag.StringVar(&cfg.TrustedPeers, "trusted_peers", "", "Addresses of trusted peers never banned with optional network addresses (ADDRESS[@HOST:PORT], comma separated)")
+	flag.StringVar(&cfg.SendPolicies, "send_policies", "", "Send priorities and bandwidths of protocols (PROTOCOL:PRIORITY[:BANDWIDTH], comma separated)")
 	flag.StringVar(&genesisStorage, "genesis_storage", "", "Genesis storage path")
 	flag.StringVar(&genesisPath, "genesis", "", "Genesis template directory or file")
 	flag.StringVar(&cfg.DBType, "db_type", "goleveldb", fmt.Sprintf("Name of database system (%s)", strings.Join(db.GetSupportedTypes(), ", ")))
@@ -136,8 +150,14 @@ func main() {
 	flag.IntVar(&cfg.NormalTxPoolSize, "normal_tx_pool", 0, "Normal transaction pool size")
 	flag.IntVar(&cfg.PatchTxPoolSize, "patch_tx_pool", 0, "Patch transaction pool size")
 	flag.IntVar(&cfg.MaxBlockTxBytes, "max_block_tx_bytes", 0, "Maximum size of transactions in a block")
+	flag.IntVar(&cfg.MaxSenderTxs, "max_sender_txs", 0, "Maximum number of transactions from a sender in the pool")
+	flag.IntVar(&cfg.MaxSenderTxBytes, "max_sender_tx_bytes", 0, "Maximum size of transactions from a sender in the pool")
 	flag.StringVar(&cfg.NodeCache, "node_cache", chain.NodeCacheDefault, "Node cache (none,small,large)")
 	flag.BoolVar(&cfg.ValidateTxOnSend, "validate_tx_on_send", false, "Validate transaction on send")
+	flag.BoolVar(&cfg.AddressIndex, "address_index", false, "Index transactions and event logs by addresses")
+	flag.BoolVar(&cfg.Archive, "archive", false, "Keep states of blocks in the archive window")
+	flag.Int64Var(&cfg.ArchiveWindow, "archive_window", 0, "Number of latest blocks whose states are kept in archive mode (0: all blocks)")
+	flag.Int64Var(&cfg.PeerBanDuration, "peer_ban_duration", 0, "Duration of banning misbehaving peers in second (0: uses system default value)")
 	cfg.ChildrenLimit = flag.Int("children_limit", -1, "Maximum number of child connections (-1: uses system default value)")
 	cfg.NephewsLimit = flag.Int("nephews_limit", -1, "Maximum number of nephew connections (-1: uses system default value)")
 	flag.StringVar(&cfg.LogLevel, "log_level", "debug", "Main log level")
@@ -460,6 +480,8 @@ func Execute(cmd *cobra.Command, args []string) {
 	}
 	log.Infof("Version : %s", version)
 	log.Infof("Build   : %s", build)
+	rosetta.NodeVersion = version
+	eth.ClientVersion = "goloop/" + version
 
 	metric.Initialize(wallet)
 	nt := network.NewTransport(cfg.P2PAddr, wallet, logger)
@@ -490,8 +512,12 @@ func Execute(cmd *cobra.Command, args []string) {
 		JSONRPCDump:         cfg.RPCDump,
 		JSONRPCIncludeDebug: cfg.RPCDebug,
 		JSONRPCRosetta:      cfg.RPCRosetta,
+		JSONRPCEth:          cfg.RPCEth,
+		GraphQL:             cfg.RPCGraphQL,
+		GraphQLMaxCost:      cfg.GraphQLCost,
 		JSONRPCBatchLimit:   cfg.RPCBatchLimit,
 		WSMaxSession:        cfg.WSMaxSession,
+		RateLimit:           cfg.RPCRateLimit,
 	}
 	srv := server.NewManager(config, wallet, logger)
 	hex.EncodeToString(wallet.Address().ID())
@@ -7,6 +7,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/icon-project/goloop/cmd/cli"
+	"github.com/icon-project/goloop/server/eth"
+	"github.com/icon-project/goloop/server/rosetta"
 )
 
 var (
@@ -20,6 +22,8 @@ type ErrorWithResponse interface {
 }
 
 func main() {
+	rosetta.NodeVersion = version
+	eth.ClientVersion = "goloop/" + version
 	rootCmd, rootVc := cli.NewCommand(nil, nil, "goloop", "Goloop CLI")
 	rootCmd.AddCommand(&cobra.Command{
 		Use:   "version",
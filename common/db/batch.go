@@ -0,0 +1,239 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package db
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Batch groups writes to multiple buckets of a database. Buckets returned
+// by GetBucket of the batch keep writes in the batch, and reads of them
+// reflect the writes in the batch. Write applies all the writes to the
+// database atomically if the database supports it.
+type Batch interface {
+	Database
+	Write() error
+	Reset()
+	Len() int
+}
+
+type batchOp struct {
+	id    BucketID
+	key   []byte
+	value []byte // nil for deletion
+}
+
+// batchWriter is implemented by databases which can write multiple
+// operations atomically.
+type batchWriter interface {
+	writeBatch(ops []batchOp) error
+}
+
+// writeBatch writes operations to the database. Operations are written one
+// by one if the database doesn't support atomic writes.
+func writeBatch(database Database, ops []batchOp) error {
+	if bw, ok := database.(batchWriter); ok {
+		return bw.writeBatch(ops)
+	}
+	buckets := make(map[BucketID]Bucket)
+	for _, op := range ops {
+		bk, ok := buckets[op.id]
+		if !ok {
+			var err error
+			if bk, err = database.GetBucket(op.id); err != nil {
+				return err
+			}
+			buckets[op.id] = bk
+		}
+		if op.value == nil {
+			if err := bk.Delete(op.key); err != nil {
+				return err
+			}
+		} else {
+			if err := bk.Set(op.key, op.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bucketIDsOf returns sorted IDs of the buckets used by the operations.
+func bucketIDsOf(ops []batchOp) []BucketID {
+	idMap := make(map[BucketID]bool)
+	for _, op := range ops {
+		idMap[op.id] = true
+	}
+	ids := make([]BucketID, 0, len(idMap))
+	for id := range idMap {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+type batchBucket struct {
+	batch *batch
+	id    BucketID
+	data  map[string][]byte
+	real  Bucket
+}
+
+func (bk *batchBucket) Get(key []byte) ([]byte, error) {
+	bk.batch.lock.Lock()
+	defer bk.batch.lock.Unlock()
+
+	if value, ok := bk.data[string(key)]; ok {
+		return value, nil
+	}
+	return bk.real.Get(key)
+}
+
+func (bk *batchBucket) Has(key []byte) (bool, error) {
+	bk.batch.lock.Lock()
+	defer bk.batch.lock.Unlock()
+
+	if value, ok := bk.data[string(key)]; ok {
+		return value != nil, nil
+	}
+	return bk.real.Has(key)
+}
+
+func (bk *batchBucket) Set(key []byte, value []byte) error {
+	if value == nil {
+		return errors.New("IllegalArgument")
+	}
+	bk.batch.lock.Lock()
+	defer bk.batch.lock.Unlock()
+
+	k2 := make([]byte, len(key))
+	copy(k2, key)
+	v2 := make([]byte, len(value))
+	copy(v2, value)
+	bk.data[string(key)] = v2
+	bk.batch.ops = append(bk.batch.ops, batchOp{bk.id, k2, v2})
+	return nil
+}
+
+func (bk *batchBucket) Delete(key []byte) error {
+	bk.batch.lock.Lock()
+	defer bk.batch.lock.Unlock()
+
+	k2 := make([]byte, len(key))
+	copy(k2, key)
+	bk.data[string(key)] = nil
+	bk.batch.ops = append(bk.batch.ops, batchOp{bk.id, k2, nil})
+	return nil
+}
+
+func (bk *batchBucket) NewIterator(r *Range, reverse bool) (Iterator, error) {
+	bk.batch.lock.Lock()
+	defer bk.batch.lock.Unlock()
+
+	base, err := bk.real.NewIterator(r, reverse)
+	if err != nil {
+		return nil, err
+	}
+	overlay := make([]kvEntry, 0, len(bk.data))
+	for k, v := range bk.data {
+		key := []byte(k)
+		if r.Contains(key) {
+			overlay = append(overlay, kvEntry{key, v})
+		}
+	}
+	return newMergedIterator(overlay, base, reverse), nil
+}
+
+type batch struct {
+	lock     sync.Mutex
+	database Database
+	buckets  map[BucketID]*batchBucket
+	ops      []batchOp
+}
+
+func (b *batch) GetBucket(id BucketID) (Bucket, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if bk, ok := b.buckets[id]; ok {
+		return bk, nil
+	}
+	real, err := b.database.GetBucket(id)
+	if err != nil {
+		return nil, err
+	}
+	bk := &batchBucket{
+		batch: b,
+		id:    id,
+		data:  make(map[string][]byte),
+		real:  real,
+	}
+	b.buckets[id] = bk
+	return bk, nil
+}
+
+func (b *batch) Close() error {
+	return nil
+}
+
+func (b *batch) Write() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if len(b.ops) == 0 {
+		return nil
+	}
+	if err := writeBatch(b.database, b.ops); err != nil {
+		return err
+	}
+	b.resetInLock()
+	return nil
+}
+
+func (b *batch) resetInLock() {
+	b.ops = nil
+	for _, bk := range b.buckets {
+		bk.data = make(map[string][]byte)
+	}
+}
+
+func (b *batch) Reset() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.resetInLock()
+}
+
+func (b *batch) Len() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return len(b.ops)
+}
+
+// NewBatch returns a new batch for the database.
+func NewBatch(database Database) Batch {
+	return &batch{
+		database: database,
+		buckets:  make(map[BucketID]*batchBucket),
+	}
+}
@@ -1,6 +1,8 @@
 package db
 
 import (
+	"strings"
+
 	"github.com/icon-project/goloop/common/crypto"
 	"github.com/icon-project/goloop/common/errors"
 )
@@ -11,6 +13,10 @@ type Bucket interface {
 	Has(key []byte) (bool, error)
 	Set(key []byte, value []byte) error
 	Delete(key []byte) error
+
+	// NewIterator returns an iterator over the keys in the range.
+	// nil range means whole keys of the bucket.
+	NewIterator(r *Range, reverse bool) (Iterator, error)
 }
 
 type BucketID string
@@ -63,11 +69,54 @@ const (
 	// ChainProperty is general key value map for chain property.
 	ChainProperty BucketID = "C"
 
+	// TransactionByAddress maps transaction hash from address, height,
+	// transaction group and index of the transaction. It's used only if
+	// the address index is enabled.
+	TransactionByAddress BucketID = "A"
+
+	// EventLogByAddress maps transaction hash from address, height,
+	// transaction group, index of the transaction and index of the event
+	// log. It's used only if the address index is enabled.
+	EventLogByAddress BucketID = "E"
+
 	// ListByMerkleRootBase is the base for the bucket that maps list
 	// from network type dependent merkle root(list)
 	ListByMerkleRootBase BucketID = "L"
 )
 
+// bucketIDs has IDs of buckets above. IDs of buckets registered with
+// RegisterHasher are also used by the node.
+var bucketIDs = []BucketID{
+	MerkleTrie,
+	BytesByHash,
+	TransactionLocatorByHash,
+	BlockHeaderHashByHeight,
+	ChainProperty,
+	TransactionByAddress,
+	EventLogByAddress,
+	ListByMerkleRootBase,
+}
+
+func isExtendedBucketID(id, base BucketID) bool {
+	return len(id) > len(base) && strings.HasPrefix(string(id), string(base))
+}
+
+// hasExtendedBucketID returns true if the id is a prefix of the id of
+// another bucket used by the node.
+func hasExtendedBucketID(id BucketID) bool {
+	for _, bid := range bucketIDs {
+		if isExtendedBucketID(bid, id) {
+			return true
+		}
+	}
+	for bid := range hasherMap {
+		if isExtendedBucketID(bid, id) {
+			return true
+		}
+	}
+	return false
+}
+
 // internalKey returns key prefixed with the bucket's id.
 func internalKey(id BucketID, key []byte) []byte {
 	buf := make([]byte, len(key)+len(id))
@@ -117,3 +117,38 @@ func (b *CodedBucket) Put(value interface{}) error {
 	}
 	return err
 }
+
+// NewIterator returns an iterator over the entries in the range.
+// Keys and values are decoded with the codec of the bucket.
+func (b *CodedBucket) NewIterator(r *Range, reverse bool) (*CodedIterator, error) {
+	iter, err := b.dbBucket.NewIterator(r, reverse)
+	if err != nil {
+		return nil, err
+	}
+	return &CodedIterator{iter, b.codec}, nil
+}
+
+// NewPrefixIterator returns an iterator over the entries of which key
+// has the prefix. Encoded bytes of prefix is used unless it's Raw.
+func (b *CodedBucket) NewPrefixIterator(prefix interface{}, reverse bool) (*CodedIterator, error) {
+	prefixBS, err := b._marshal(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return b.NewIterator(PrefixRange(prefixBS), reverse)
+}
+
+type CodedIterator struct {
+	Iterator
+	codec codec.Codec
+}
+
+// GetKey decodes the key of the current entry.
+func (it *CodedIterator) GetKey(key interface{}) error {
+	return it.codec.Unmarshal(bytes.NewBuffer(it.Key()), key)
+}
+
+// GetValue decodes the value of the current entry.
+func (it *CodedIterator) GetValue(value interface{}) error {
+	return it.codec.Unmarshal(bytes.NewBuffer(it.Value()), value)
+}
@@ -67,6 +67,10 @@ func (c *databaseContext) WithFlags(flags Flags) Context {
 	return &databaseContext{c.Database, newFlags}
 }
 
+func (c *databaseContext) writeBatch(ops []batchOp) error {
+	return writeBatch(c.Database, ops)
+}
+
 func (c *databaseContext) GetFlag(name string) interface{} {
 	return c.flags.Get(name)
 }
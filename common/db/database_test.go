@@ -17,9 +17,13 @@
 package db
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/crypto"
 )
 
 func testDatabase_GetSetDelete(t *testing.T, creator dbCreator) {
@@ -150,3 +154,315 @@ func TestDatabase_SetReopenGet(t *testing.T) {
 		})
 	}
 }
+
+func collectEntries(t *testing.T, bk Bucket, r *Range, reverse bool) []string {
+	iter, err := bk.NewIterator(r, reverse)
+	assert.NoError(t, err)
+	defer iter.Release()
+
+	var entries []string
+	for iter.Next() {
+		entries = append(entries, string(iter.Key())+"="+string(iter.Value()))
+	}
+	assert.NoError(t, iter.Error())
+	return entries
+}
+
+func testDatabase_Iterator(t *testing.T, creator dbCreator) {
+	dir := t.TempDir()
+	testDB, err := creator("test", dir)
+	assert.NoError(t, err)
+	defer testDB.Close()
+
+	bucket, _ := testDB.GetBucket("I")
+	other, _ := testDB.GetBucket("J")
+	for _, k := range []string{"b1", "a1", "a2", "a3", "c"} {
+		assert.NoError(t, bucket.Set([]byte(k), []byte("v"+k)))
+	}
+	assert.NoError(t, other.Set([]byte("a4"), []byte("va4")))
+
+	assert.Equal(t,
+		[]string{"a1=va1", "a2=va2", "a3=va3", "b1=vb1", "c=vc"},
+		collectEntries(t, bucket, nil, false))
+	assert.Equal(t,
+		[]string{"c=vc", "b1=vb1", "a3=va3", "a2=va2", "a1=va1"},
+		collectEntries(t, bucket, nil, true))
+	assert.Equal(t,
+		[]string{"a1=va1", "a2=va2", "a3=va3"},
+		collectEntries(t, bucket, PrefixRange([]byte("a")), false))
+	assert.Equal(t,
+		[]string{"a3=va3", "a2=va2", "a1=va1"},
+		collectEntries(t, bucket, PrefixRange([]byte("a")), true))
+	assert.Equal(t,
+		[]string{"a2=va2", "a3=va3"},
+		collectEntries(t, bucket, &Range{Start: []byte("a2"), Limit: []byte("b1")}, false))
+	assert.Equal(t,
+		[]string{"a3=va3", "a2=va2"},
+		collectEntries(t, bucket, &Range{Start: []byte("a2"), Limit: []byte("b1")}, true))
+	assert.Empty(t, collectEntries(t, bucket, PrefixRange([]byte("d")), false))
+	assert.Equal(t, []string{"a4=va4"}, collectEntries(t, other, nil, false))
+}
+
+func TestDatabase_Iterator(t *testing.T) {
+	for name, be := range backends {
+		t.Run(string(name), func(t *testing.T) {
+			testDatabase_Iterator(t, be)
+		})
+	}
+	t.Run("layerdb", func(t *testing.T) {
+		var creator dbCreator = func(name string, dir string) (Database, error) {
+			origin := NewMapDB()
+			return NewLayerDB(origin), nil
+		}
+		testDatabase_Iterator(t, creator)
+	})
+}
+
+func trieNodeWithPrefix(prefix byte) ([]byte, []byte) {
+	for i := 0; ; i++ {
+		value := []byte(fmt.Sprintf("node%d", i))
+		key := MerkleTrie.Hasher().Hash(value)
+		if key[0] == prefix {
+			return key, value
+		}
+	}
+}
+
+func testDatabase_IteratorWithMerkleTrie(t *testing.T, name BackendType, creator dbCreator) {
+	dir := t.TempDir()
+	testDB, err := creator("test", dir)
+	assert.NoError(t, err)
+	defer testDB.Close()
+
+	trie, _ := testDB.GetBucket(MerkleTrie)
+	bucket, _ := testDB.GetBucket(TransactionLocatorByHash)
+	nk, nv := trieNodeWithPrefix(byte(TransactionLocatorByHash[0]))
+	assert.NoError(t, trie.Set(nk, nv))
+	assert.NoError(t, bucket.Set([]byte("txkey"), []byte("loc")))
+
+	assert.Equal(t, []string{"txkey=loc"}, collectEntries(t, bucket, nil, false))
+	assert.Equal(t, []string{"txkey=loc"}, collectEntries(t, bucket, nil, true))
+	if name == GoLevelDBBackend {
+		_, err := trie.NewIterator(nil, false)
+		assert.Error(t, err)
+		return
+	}
+	assert.Equal(t,
+		[]string{string(nk) + "=" + string(nv)},
+		collectEntries(t, trie, nil, false))
+	assert.Equal(t,
+		[]string{string(nk) + "=" + string(nv)},
+		collectEntries(t, trie, nil, true))
+}
+
+func TestDatabase_IteratorWithMerkleTrie(t *testing.T) {
+	for name, be := range backends {
+		t.Run(string(name), func(t *testing.T) {
+			testDatabase_IteratorWithMerkleTrie(t, name, be)
+		})
+	}
+}
+
+func TestGoLevelDB_IteratorExtendedBucket(t *testing.T) {
+	testDB, err := NewGoLevelDB("test", t.TempDir())
+	assert.NoError(t, err)
+	defer testDB.Close()
+
+	// iterability doesn't depend on buckets opened by the process
+	bk, _ := testDB.GetBucket("X")
+	_, _ = testDB.GetBucket("XY")
+	iter, err := bk.NewIterator(nil, false)
+	assert.NoError(t, err)
+	iter.Release()
+
+	bk, _ = testDB.GetBucket(MerkleTrie)
+	_, err = bk.NewIterator(nil, false)
+	assert.Error(t, err)
+
+	// buckets of network types of BTP are registered with their hashers
+	RegisterHasher("ZX", sha3Hasher{})
+	t.Cleanup(func() {
+		unregisterHasher("ZX")
+	})
+	bk, _ = testDB.GetBucket("Z")
+	_, err = bk.NewIterator(nil, false)
+	assert.Error(t, err)
+}
+
+func unregisterHasher(bk BucketID) {
+	delete(hasherMap, bk)
+}
+
+func TestGoLevelDB_IteratorHashLengthKey(t *testing.T) {
+	testDB, err := NewGoLevelDB("test", t.TempDir())
+	assert.NoError(t, err)
+	defer testDB.Close()
+
+	// the key makes the internal key of hash length
+	key := strings.Repeat("k", crypto.HashLen-1)
+	bk, _ := testDB.GetBucket("I")
+	assert.NoError(t, bk.Set([]byte(key), []byte("v")))
+	assert.Equal(t, []string{key + "=v"}, collectEntries(t, bk, nil, false))
+}
+
+func TestGoLevelDB_LegacyMerkleTrieKey(t *testing.T) {
+	testDB, err := NewGoLevelDB("test", t.TempDir())
+	assert.NoError(t, err)
+	defer testDB.Close()
+
+	// old versions wrote keys of MerkleTrie without the prefix
+	nk, nv := trieNodeWithPrefix(byte('I'))
+	assert.NoError(t, testDB.db.Put(nk, nv, nil))
+
+	trie, _ := testDB.GetBucket(MerkleTrie)
+	value, err := trie.Get(nk)
+	assert.NoError(t, err)
+	assert.Equal(t, nv, value)
+	has, err := trie.Has(nk)
+	assert.NoError(t, err)
+	assert.True(t, has)
+
+	assert.NoError(t, trie.Delete(nk))
+	has, err = trie.Has(nk)
+	assert.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestLayerDB_IteratorMerged(t *testing.T) {
+	origin := NewMapDB()
+	bk, _ := origin.GetBucket("I")
+	for _, k := range []string{"a", "b", "c", "d"} {
+		assert.NoError(t, bk.Set([]byte(k), []byte("o"+k)))
+	}
+
+	ldb := NewLayerDB(origin)
+	lbk, _ := ldb.GetBucket("I")
+	assert.NoError(t, lbk.Set([]byte("b"), []byte("lb")))
+	assert.NoError(t, lbk.Set([]byte("e"), []byte("le")))
+	assert.NoError(t, lbk.Delete([]byte("c")))
+	assert.NoError(t, lbk.Delete([]byte("a")))
+
+	assert.Equal(t,
+		[]string{"b=lb", "d=od", "e=le"},
+		collectEntries(t, lbk, nil, false))
+	assert.Equal(t,
+		[]string{"e=le", "d=od", "b=lb"},
+		collectEntries(t, lbk, nil, true))
+	assert.Equal(t,
+		[]string{"a=oa", "b=ob", "c=oc", "d=od"},
+		collectEntries(t, bk, nil, false))
+
+	assert.NoError(t, ldb.Flush(true))
+	assert.Equal(t,
+		[]string{"b=lb", "d=od", "e=le"},
+		collectEntries(t, bk, nil, false))
+}
+
+func TestCodedBucket_Iterator(t *testing.T) {
+	dbase := NewMapDB()
+	bk, err := NewCodedBucket(dbase, "I", nil)
+	assert.NoError(t, err)
+	for i := int64(1); i <= 3; i++ {
+		assert.NoError(t, bk.Set(Raw([]byte{'k', byte(i)}), i*10))
+	}
+
+	iter, err := bk.NewPrefixIterator(Raw("k"), true)
+	assert.NoError(t, err)
+	defer iter.Release()
+
+	var values []int64
+	for iter.Next() {
+		var v int64
+		assert.NoError(t, iter.GetValue(&v))
+		values = append(values, v)
+	}
+	assert.Equal(t, []int64{30, 20, 10}, values)
+}
+
+func testDatabase_Batch(t *testing.T, creator dbCreator) {
+	dir := t.TempDir()
+	testDB, err := creator("test", dir)
+	assert.NoError(t, err)
+	defer testDB.Close()
+
+	bk1, _ := testDB.GetBucket("B1")
+	bk2, _ := testDB.GetBucket("B2")
+	assert.NoError(t, bk1.Set([]byte("old"), []byte("value")))
+
+	batch := NewBatch(testDB)
+	bb1, err := batch.GetBucket("B1")
+	assert.NoError(t, err)
+	bb2, err := batch.GetBucket("B2")
+	assert.NoError(t, err)
+
+	assert.NoError(t, bb1.Set([]byte("k1"), []byte("v1")))
+	assert.NoError(t, bb2.Set([]byte("k2"), []byte("v2")))
+	assert.NoError(t, bb1.Delete([]byte("old")))
+	assert.Equal(t, 3, batch.Len())
+
+	// writes are visible through the batch
+	value, err := bb1.Get([]byte("k1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v1"), value)
+	has, err := bb1.Has([]byte("old"))
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	// but not through the database before Write
+	has, err = bk1.Has([]byte("k1"))
+	assert.NoError(t, err)
+	assert.False(t, has)
+	has, err = bk1.Has([]byte("old"))
+	assert.NoError(t, err)
+	assert.True(t, has)
+
+	assert.NoError(t, batch.Write())
+	assert.Equal(t, 0, batch.Len())
+
+	value, err = bk1.Get([]byte("k1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v1"), value)
+	value, err = bk2.Get([]byte("k2"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v2"), value)
+	has, err = bk1.Has([]byte("old"))
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	// reset drops all writes
+	assert.NoError(t, bb2.Set([]byte("k3"), []byte("v3")))
+	batch.Reset()
+	assert.NoError(t, batch.Write())
+	has, err = bk2.Has([]byte("k3"))
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	// empty key fails the whole batch
+	assert.NoError(t, bb2.Set([]byte("k4"), []byte("v4")))
+	assert.NoError(t, bb1.Set([]byte{}, []byte("v")))
+	assert.Error(t, batch.Write())
+	has, err = bk2.Has([]byte("k4"))
+	assert.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestDatabase_Batch(t *testing.T) {
+	for name, be := range backends {
+		t.Run(string(name), func(t *testing.T) {
+			testDatabase_Batch(t, be)
+		})
+	}
+	t.Run("layerdb", func(t *testing.T) {
+		var creator dbCreator = func(name string, dir string) (Database, error) {
+			origin := NewMapDB()
+			return NewLayerDB(origin), nil
+		}
+		testDatabase_Batch(t, creator)
+	})
+	t.Run("context", func(t *testing.T) {
+		var creator dbCreator = func(name string, dir string) (Database, error) {
+			return WithFlags(NewMapDB(), Flags{"test": true}), nil
+		}
+		testDatabase_Batch(t, creator)
+	})
+}
@@ -4,8 +4,11 @@ import (
 	"path/filepath"
 	"sync"
 
+	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 const GoLevelDBBackend BackendType = "goleveldb"
@@ -61,6 +64,26 @@ func (db *GoLevelDB) GetBucket(id BucketID) (Bucket, error) {
 	}
 }
 
+func (db *GoLevelDB) writeBatch(ops []batchOp) error {
+	for _, op := range ops {
+		if len(op.key) == 0 {
+			return errors.Errorf("Illegal Key:%x", op.key)
+		}
+	}
+	batch := new(leveldb.Batch)
+	for _, op := range ops {
+		if op.value == nil {
+			batch.Delete(goLevelKey(op.id, op.key))
+			if op.id == MerkleTrie {
+				batch.Delete(op.key)
+			}
+		} else {
+			batch.Put(goLevelKey(op.id, op.key), op.value)
+		}
+	}
+	return db.db.Write(batch, nil)
+}
+
 func (db *GoLevelDB) Close() error {
 	return db.db.Close()
 }
@@ -75,8 +98,26 @@ type goLevelBucket struct {
 	db *leveldb.DB
 }
 
+// goLevelMerkleTriePrefix is the prefix of keys of MerkleTrie. Other
+// buckets use their ids as prefixes, and no bucket id starts with it, so
+// keys of MerkleTrie are kept out of ranges of other buckets.
+const goLevelMerkleTriePrefix = "\x00"
+
+// goLevelKey returns the key of the bucket in the database.
+func goLevelKey(id BucketID, key []byte) []byte {
+	if id == MerkleTrie {
+		return internalKey(goLevelMerkleTriePrefix, key)
+	}
+	return internalKey(id, key)
+}
+
+// Get returns the value of the key. Databases written by old versions have
+// keys of MerkleTrie without the prefix, so they are also looked up.
 func (bucket *goLevelBucket) Get(key []byte) ([]byte, error) {
-	value, err := bucket.db.Get(internalKey(bucket.id, key), nil)
+	value, err := bucket.db.Get(goLevelKey(bucket.id, key), nil)
+	if err == leveldb.ErrNotFound && bucket.id == MerkleTrie {
+		value, err = bucket.db.Get(key, nil)
+	}
 	if err == leveldb.ErrNotFound {
 		return nil, nil
 	} else {
@@ -85,13 +126,101 @@ func (bucket *goLevelBucket) Get(key []byte) ([]byte, error) {
 }
 
 func (bucket *goLevelBucket) Has(key []byte) (bool, error) {
-	return bucket.db.Has(internalKey(bucket.id, key), nil)
+	has, err := bucket.db.Has(goLevelKey(bucket.id, key), nil)
+	if err == nil && !has && bucket.id == MerkleTrie {
+		return bucket.db.Has(key, nil)
+	}
+	return has, err
 }
 
 func (bucket *goLevelBucket) Set(key []byte, value []byte) error {
-	return bucket.db.Put(internalKey(bucket.id, key), value, nil)
+	return bucket.db.Put(goLevelKey(bucket.id, key), value, nil)
 }
 
 func (bucket *goLevelBucket) Delete(key []byte) error {
+	if bucket.id == MerkleTrie {
+		batch := new(leveldb.Batch)
+		batch.Delete(goLevelKey(bucket.id, key))
+		batch.Delete(key)
+		return bucket.db.Write(batch, nil)
+	}
 	return bucket.db.Delete(internalKey(bucket.id, key), nil)
 }
+
+// NewIterator returns an iterator over the keys of the bucket. All buckets
+// share one key space, so it fails for the bucket whose id is a prefix of
+// the id of another bucket. It also fails for MerkleTrie, whose keys
+// written by old versions have no prefix. Those keys may appear in
+// iteration of other buckets of databases written by old versions.
+func (bucket *goLevelBucket) NewIterator(r *Range, reverse bool) (Iterator, error) {
+	if hasExtendedBucketID(bucket.id) {
+		return nil, errors.Errorf("NotSupportedIteration(bucket=%q)", bucket.id)
+	}
+	var ir util.Range
+	if r != nil && r.Start != nil {
+		ir.Start = internalKey(bucket.id, r.Start)
+	} else {
+		ir.Start = []byte(bucket.id)
+	}
+	if r != nil && r.Limit != nil {
+		ir.Limit = internalKey(bucket.id, r.Limit)
+	} else {
+		ir.Limit = PrefixRange([]byte(bucket.id)).Limit
+	}
+	return &goLevelIterator{
+		iter:    bucket.db.NewIterator(&ir, nil),
+		prefix:  len(bucket.id),
+		reverse: reverse,
+	}, nil
+}
+
+//----------------------------------------
+// Iterator
+
+var _ Iterator = (*goLevelIterator)(nil)
+
+// goLevelIterator strips the bucket id from the keys.
+type goLevelIterator struct {
+	iter    iterator.Iterator
+	prefix  int
+	reverse bool
+	started bool
+}
+
+func (it *goLevelIterator) Next() bool {
+	return it.move()
+}
+
+func (it *goLevelIterator) move() bool {
+	if !it.started {
+		it.started = true
+		if it.reverse {
+			return it.iter.Last()
+		}
+		return it.iter.First()
+	}
+	if it.reverse {
+		return it.iter.Prev()
+	}
+	return it.iter.Next()
+}
+
+func (it *goLevelIterator) Key() []byte {
+	key := it.iter.Key()
+	if key == nil {
+		return nil
+	}
+	return key[it.prefix:]
+}
+
+func (it *goLevelIterator) Value() []byte {
+	return it.iter.Value()
+}
+
+func (it *goLevelIterator) Error() error {
+	return it.iter.Error()
+}
+
+func (it *goLevelIterator) Release() {
+	it.iter.Release()
+}
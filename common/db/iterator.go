@@ -0,0 +1,258 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package db
+
+import (
+	"bytes"
+	"sort"
+)
+
+// Range specifies a range of keys. Start is inclusive and Limit is
+// exclusive. nil Start or Limit means there is no boundary on that side.
+type Range struct {
+	Start []byte
+	Limit []byte
+}
+
+// Contains returns whether the key is in the range.
+func (r *Range) Contains(key []byte) bool {
+	if r == nil {
+		return true
+	}
+	if r.Start != nil && bytes.Compare(key, r.Start) < 0 {
+		return false
+	}
+	if r.Limit != nil && bytes.Compare(key, r.Limit) >= 0 {
+		return false
+	}
+	return true
+}
+
+// PrefixRange returns the range covering all keys starting with the prefix.
+func PrefixRange(prefix []byte) *Range {
+	var limit []byte
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if c := prefix[i]; c < 0xff {
+			limit = make([]byte, i+1)
+			copy(limit, prefix)
+			limit[i] = c + 1
+			break
+		}
+	}
+	return &Range{
+		Start: prefix,
+		Limit: limit,
+	}
+}
+
+// Iterator iterates key-value pairs of a bucket in key order (or in reverse
+// key order). It's positioned before the first entry, so Next should be
+// called before reading Key and Value. Returned slices are valid only until
+// the next call of Next. Release must be called after use.
+type Iterator interface {
+	Next() bool
+	Key() []byte
+	Value() []byte
+	Error() error
+	Release()
+}
+
+// NewPrefixIterator returns an iterator over the keys having the prefix.
+func NewPrefixIterator(bk Bucket, prefix []byte, reverse bool) (Iterator, error) {
+	return bk.NewIterator(PrefixRange(prefix), reverse)
+}
+
+type kvEntry struct {
+	key   []byte
+	value []byte
+}
+
+// sliceIterator iterates over pre-sorted entries.
+type sliceIterator struct {
+	entries []kvEntry
+	index   int
+	reverse bool
+}
+
+func (it *sliceIterator) Next() bool {
+	if it.index < len(it.entries) {
+		it.index += 1
+	}
+	return it.index < len(it.entries)
+}
+
+func (it *sliceIterator) current() *kvEntry {
+	if it.index < 0 || it.index >= len(it.entries) {
+		return nil
+	}
+	if it.reverse {
+		return &it.entries[len(it.entries)-1-it.index]
+	}
+	return &it.entries[it.index]
+}
+
+func (it *sliceIterator) Key() []byte {
+	if e := it.current(); e != nil {
+		return e.key
+	}
+	return nil
+}
+
+func (it *sliceIterator) Value() []byte {
+	if e := it.current(); e != nil {
+		return e.value
+	}
+	return nil
+}
+
+func (it *sliceIterator) Error() error {
+	return nil
+}
+
+func (it *sliceIterator) Release() {
+	it.entries = nil
+	it.index = 0
+}
+
+func newSliceIterator(entries []kvEntry, reverse bool) *sliceIterator {
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+	return &sliceIterator{
+		entries: entries,
+		index:   -1,
+		reverse: reverse,
+	}
+}
+
+// emptyIterator is used for buckets without any entry.
+type emptyIterator struct{}
+
+func (it emptyIterator) Next() bool {
+	return false
+}
+
+func (it emptyIterator) Key() []byte {
+	return nil
+}
+
+func (it emptyIterator) Value() []byte {
+	return nil
+}
+
+func (it emptyIterator) Error() error {
+	return nil
+}
+
+func (it emptyIterator) Release() {
+	// do nothing
+}
+
+func NewEmptyIterator() Iterator {
+	return emptyIterator{}
+}
+
+// mergedIterator iterates over the entries of the overlay and the base.
+// The overlay has priority over the base, and nil value in the overlay
+// means that the entry is deleted.
+type mergedIterator struct {
+	overlay *sliceIterator
+	base    Iterator
+	reverse bool
+
+	oValid, oNext bool
+	bValid, bNext bool
+	current       Iterator
+}
+
+func (it *mergedIterator) compare(k1, k2 []byte) int {
+	if it.reverse {
+		return bytes.Compare(k2, k1)
+	}
+	return bytes.Compare(k1, k2)
+}
+
+func (it *mergedIterator) Next() bool {
+	// advance the sources lazily, because the key and the value of the
+	// current entry may not be valid after it's advanced.
+	if it.oNext {
+		it.oValid, it.oNext = it.overlay.Next(), false
+	}
+	if it.bNext {
+		it.bValid, it.bNext = it.base.Next(), false
+	}
+	for it.oValid || it.bValid {
+		if it.oValid && it.bValid {
+			c := it.compare(it.overlay.Key(), it.base.Key())
+			if c == 0 {
+				it.bValid = it.base.Next()
+				continue
+			}
+			if c > 0 {
+				it.current, it.bNext = it.base, true
+				return true
+			}
+		} else if it.bValid {
+			it.current, it.bNext = it.base, true
+			return true
+		}
+		if it.overlay.Value() == nil {
+			it.oValid = it.overlay.Next()
+			continue
+		}
+		it.current, it.oNext = it.overlay, true
+		return true
+	}
+	it.current = nil
+	return false
+}
+
+func (it *mergedIterator) Key() []byte {
+	if it.current != nil {
+		return it.current.Key()
+	}
+	return nil
+}
+
+func (it *mergedIterator) Value() []byte {
+	if it.current != nil {
+		return it.current.Value()
+	}
+	return nil
+}
+
+func (it *mergedIterator) Error() error {
+	return it.base.Error()
+}
+
+func (it *mergedIterator) Release() {
+	it.overlay.Release()
+	it.base.Release()
+	it.oValid, it.bValid = false, false
+	it.oNext, it.bNext = false, false
+	it.current = nil
+}
+
+func newMergedIterator(overlay []kvEntry, base Iterator, reverse bool) Iterator {
+	return &mergedIterator{
+		overlay: newSliceIterator(overlay, reverse),
+		base:    base,
+		reverse: reverse,
+		oNext:   true,
+		bNext:   true,
+	}
+}
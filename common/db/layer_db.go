@@ -66,6 +66,24 @@ func (bk *layerBucket) Delete(key []byte) error {
 	}
 }
 
+func (bk *layerBucket) NewIterator(r *Range, reverse bool) (Iterator, error) {
+	bk.lock.Lock()
+	defer bk.lock.Unlock()
+
+	base, err := bk.real.NewIterator(r, reverse)
+	if err != nil || bk.data == nil {
+		return base, err
+	}
+	overlay := make([]kvEntry, 0, len(bk.data))
+	for k, v := range bk.data {
+		key := []byte(k)
+		if r.Contains(key) {
+			overlay = append(overlay, kvEntry{key, v})
+		}
+	}
+	return newMergedIterator(overlay, base, reverse), nil
+}
+
 func (bk *layerBucket) Flush(write bool) error {
 	bk.lock.Lock()
 	defer bk.lock.Unlock()
@@ -131,6 +149,38 @@ func (ldb *layerDB) Flush(write bool) error {
 	return nil
 }
 
+func (ldb *layerDB) writeBatch(ops []batchOp) error {
+	for _, op := range ops {
+		if len(op.key) == 0 {
+			return errors.Errorf("Illegal Key:%x", op.key)
+		}
+		if _, err := ldb.GetBucket(op.id); err != nil {
+			return err
+		}
+	}
+
+	ldb.lock.Lock()
+	defer ldb.lock.Unlock()
+
+	if ldb.flushed {
+		return writeBatch(ldb.real, ops)
+	}
+	for _, id := range bucketIDsOf(ops) {
+		bk := ldb.buckets[string(id)]
+		bk.lock.Lock()
+		defer bk.lock.Unlock()
+	}
+	for _, op := range ops {
+		var v2 []byte
+		if op.value != nil {
+			v2 = make([]byte, len(op.value))
+			copy(v2, op.value)
+		}
+		ldb.buckets[string(op.id)].data[string(op.key)] = v2
+	}
+	return nil
+}
+
 func (ldb *layerDB) Close() error {
 	return nil
 }
@@ -145,6 +195,10 @@ func (c *layerDBContext) WithFlags(flags Flags) Context {
 	return &layerDBContext{c.LayerDB, newFlags}
 }
 
+func (c *layerDBContext) writeBatch(ops []batchOp) error {
+	return writeBatch(c.LayerDB, ops)
+}
+
 func (c *layerDBContext) GetFlag(name string) interface{} {
 	return c.flags.Get(name)
 }
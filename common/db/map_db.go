@@ -56,6 +56,35 @@ func (t *mapDatabase) GetBucket(id BucketID) (Bucket, error) {
 	return bk, nil
 }
 
+func (t *mapDatabase) writeBatch(ops []batchOp) error {
+	for _, op := range ops {
+		if len(op.key) == 0 {
+			return errors.Errorf("Illegal Key:%x", op.key)
+		}
+	}
+	ids := bucketIDsOf(ops)
+	bks := make(map[BucketID]*mapBucket, len(ids))
+	for _, id := range ids {
+		bk, _ := t.GetBucket(id)
+		bks[id] = bk.(*mapBucket)
+	}
+	for _, id := range ids {
+		bks[id].mutex.Lock()
+		defer bks[id].mutex.Unlock()
+	}
+	for _, op := range ops {
+		if configLogMapDB {
+			log.Printf("mapBucket[%s].writeBatch(%x,%x)", bks[op.id].id, op.key, op.value)
+		}
+		if op.value == nil {
+			delete(bks[op.id].real, string(op.key))
+		} else {
+			bks[op.id].real[string(op.key)] = string(op.value)
+		}
+	}
+	return nil
+}
+
 func (t *mapDatabase) Close() error {
 	return nil
 }
@@ -120,3 +149,19 @@ func (t *mapBucket) Delete(k []byte) error {
 	delete(t.real, string(k))
 	return nil
 }
+
+func (t *mapBucket) NewIterator(r *Range, reverse bool) (Iterator, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	entries := make([]kvEntry, 0, len(t.real))
+	for k, v := range t.real {
+		key := []byte(k)
+		if r.Contains(key) {
+			entries = append(entries, kvEntry{key, []byte(v)})
+		}
+	}
+	if configLogMapDB {
+		log.Printf("mapBucket[%s].NewIterator(%+v,%v) -> %d", t.id, r, reverse, len(entries))
+	}
+	return newSliceIterator(entries, reverse), nil
+}
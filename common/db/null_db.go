@@ -30,6 +30,10 @@ func (*nullBucket) Delete(key []byte) error {
 	panic("NullBucket.Delete() Unsupported")
 }
 
+func (*nullBucket) NewIterator(r *Range, reverse bool) (Iterator, error) {
+	return NewEmptyIterator(), nil
+}
+
 func NewNullDB() *nullDB {
 	return &nullDB{}
 }
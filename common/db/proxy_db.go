@@ -36,6 +36,13 @@ func (bk *proxyBucket) Delete(key []byte) error {
 	return errors.New("ProxyIsNotRealized")
 }
 
+func (bk *proxyBucket) NewIterator(r *Range, reverse bool) (Iterator, error) {
+	if bk.real != nil {
+		return bk.real.NewIterator(r, reverse)
+	}
+	return nil, errors.New("ProxyIsNotRealized")
+}
+
 type proxyDB struct {
 	real    Database
 	buckets map[string]*proxyBucket
@@ -57,6 +64,13 @@ func (pdb *proxyDB) GetBucket(id BucketID) (Bucket, error) {
 	return bk, nil
 }
 
+func (pdb *proxyDB) writeBatch(ops []batchOp) error {
+	if pdb.real != nil {
+		return writeBatch(pdb.real, ops)
+	}
+	return errors.New("ProxyIsNotRealized")
+}
+
 func (pdb *proxyDB) Close() error {
 	return nil
 }
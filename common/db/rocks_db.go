@@ -20,13 +20,15 @@
 package db
 
 import (
-	"errors"
+	"bytes"
 	"os"
 	"path"
 	"reflect"
 	"sync"
 	"unsafe"
 
+	"github.com/pkg/errors"
+
 	"github.com/icon-project/goloop/common/log"
 )
 
@@ -246,6 +248,157 @@ func (db *RocksDB) deleteValue(cf *C.rocksdb_column_family_handle_t, k []byte) e
 	return nil
 }
 
+func (db *RocksDB) writeBatch(ops []batchOp) error {
+	for _, op := range ops {
+		if len(op.key) == 0 {
+			return errors.Errorf("Illegal Key:%x", op.key)
+		}
+	}
+	cfs := make(map[BucketID]*C.rocksdb_column_family_handle_t)
+	for _, op := range ops {
+		if _, ok := cfs[op.id]; !ok {
+			bk, err := db.GetBucket(op.id)
+			if err != nil {
+				return err
+			}
+			cfs[op.id] = bk.(*RocksBucket).cf
+		}
+	}
+
+	wb := C.rocksdb_writebatch_create()
+	defer C.rocksdb_writebatch_destroy(wb)
+	for _, op := range ops {
+		cKey := (*C.char)(unsafe.Pointer(&op.key[0]))
+		if op.value == nil {
+			C.rocksdb_writebatch_delete_cf(wb, cfs[op.id], cKey, C.size_t(len(op.key)))
+		} else {
+			var cValue *C.char
+			if len(op.value) > 0 {
+				cValue = (*C.char)(unsafe.Pointer(&op.value[0]))
+			}
+			C.rocksdb_writebatch_put_cf(wb, cfs[op.id], cKey, C.size_t(len(op.key)), cValue, C.size_t(len(op.value)))
+		}
+	}
+	var cErr *C.char
+	C.rocksdb_write(db.db, db.wo, wb, &cErr)
+	if cErr != nil {
+		defer C.rocksdb_free(unsafe.Pointer(cErr))
+		return errors.New(C.GoString(cErr))
+	}
+	return nil
+}
+
+func (db *RocksDB) newIterator(cf *C.rocksdb_column_family_handle_t, r *Range, reverse bool) *rocksIterator {
+	it := &rocksIterator{
+		iter:    C.rocksdb_create_iterator_cf(db.db, db.ro, cf),
+		reverse: reverse,
+	}
+	if r != nil {
+		it.start, it.limit = r.Start, r.Limit
+	}
+	return it
+}
+
+type rocksIterator struct {
+	iter    *C.rocksdb_iterator_t
+	start   []byte
+	limit   []byte
+	reverse bool
+	started bool
+	key     []byte
+	value   []byte
+	err     error
+}
+
+func (it *rocksIterator) seek() {
+	if it.reverse {
+		if len(it.limit) > 0 {
+			cKey := (*C.char)(unsafe.Pointer(&it.limit[0]))
+			C.rocksdb_iter_seek_for_prev(it.iter, cKey, C.size_t(len(it.limit)))
+			if C.rocksdb_iter_valid(it.iter) != 0 && bytes.Equal(it.currentKey(), it.limit) {
+				C.rocksdb_iter_prev(it.iter)
+			}
+		} else {
+			C.rocksdb_iter_seek_to_last(it.iter)
+		}
+	} else {
+		if len(it.start) > 0 {
+			cKey := (*C.char)(unsafe.Pointer(&it.start[0]))
+			C.rocksdb_iter_seek(it.iter, cKey, C.size_t(len(it.start)))
+		} else {
+			C.rocksdb_iter_seek_to_first(it.iter)
+		}
+	}
+}
+
+func (it *rocksIterator) currentKey() []byte {
+	var cLen C.size_t
+	cKey := C.rocksdb_iter_key(it.iter, &cLen)
+	return C.GoBytes(unsafe.Pointer(cKey), C.int(cLen))
+}
+
+func (it *rocksIterator) currentValue() []byte {
+	var cLen C.size_t
+	cValue := C.rocksdb_iter_value(it.iter, &cLen)
+	return C.GoBytes(unsafe.Pointer(cValue), C.int(cLen))
+}
+
+func (it *rocksIterator) Next() bool {
+	it.key, it.value = nil, nil
+	if it.iter == nil || it.err != nil {
+		return false
+	}
+	if !it.started {
+		it.started = true
+		it.seek()
+	} else if it.reverse {
+		C.rocksdb_iter_prev(it.iter)
+	} else {
+		C.rocksdb_iter_next(it.iter)
+	}
+	if C.rocksdb_iter_valid(it.iter) == 0 {
+		var cErr *C.char
+		C.rocksdb_iter_get_error(it.iter, &cErr)
+		if cErr != nil {
+			defer C.rocksdb_free(unsafe.Pointer(cErr))
+			it.err = errors.New(C.GoString(cErr))
+		}
+		return false
+	}
+	key := it.currentKey()
+	if it.reverse {
+		if it.start != nil && bytes.Compare(key, it.start) < 0 {
+			return false
+		}
+	} else {
+		if it.limit != nil && bytes.Compare(key, it.limit) >= 0 {
+			return false
+		}
+	}
+	it.key, it.value = key, it.currentValue()
+	return true
+}
+
+func (it *rocksIterator) Key() []byte {
+	return it.key
+}
+
+func (it *rocksIterator) Value() []byte {
+	return it.value
+}
+
+func (it *rocksIterator) Error() error {
+	return it.err
+}
+
+func (it *rocksIterator) Release() {
+	if it.iter != nil {
+		C.rocksdb_iter_destroy(it.iter)
+		it.iter = nil
+	}
+	it.key, it.value = nil, nil
+}
+
 type RocksBucket struct {
 	cf *C.rocksdb_column_family_handle_t
 	db *RocksDB
@@ -266,3 +419,7 @@ func (b *RocksBucket) Set(key []byte, value []byte) error {
 func (b *RocksBucket) Delete(key []byte) error {
 	return b.db.deleteValue(b.cf, key)
 }
+
+func (b *RocksBucket) NewIterator(r *Range, reverse bool) (Iterator, error) {
+	return b.db.newIterator(b.cf, r, reverse), nil
+}
@@ -354,11 +354,13 @@ func (cs *consensus) OnReceive(
 	if err != nil {
 		cs.log.Warnf("malformed consensus message: OnReceive(subprotocol:%v, from:%v): %+v\n", sp, common.HexPre(id.Bytes()), err)
 		cs.log.Warnf("message: %s", codec.DumpRLP("  ", bs))
+		cs.ph.Report(id, module.ReportInvalidMessage)
 		return false, err
 	}
 	cs.log.Debugf("OnReceive(msg:%v, from:%v)\n", msg, common.HexPre(id.Bytes()))
 	if err = msg.Verify(); err != nil {
 		cs.log.Warnf("consensus message verify failed: OnReceive(msg:%v, from:%v): %+v\n", msg, common.HexPre(id.Bytes()), err)
+		cs.ph.Report(id, module.ReportInvalidMessage)
 		return false, err
 	}
 	switch m := msg.(type) {
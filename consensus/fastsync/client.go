@@ -444,6 +444,7 @@ func (f *fetcher) _doSend() {
 			}
 			f.timer = nil
 			f.cancel()
+			f.cl.ph.Report(f.id, module.ReportTimeout)
 			f.cl.onResult(f, errors.Errorf("Timed out"), nil, nil)
 		})
 		f.timer = timer
@@ -490,6 +491,7 @@ func (f *fetcher) onReceive(pi module.ProtocolInfo, b []byte) {
 		var msg BlockMetadata
 		_, err := codec.UnmarshalFromBytes(b, &msg)
 		if err != nil {
+			f.cl.ph.Report(f.id, module.ReportInvalidMessage)
 			return
 		}
 		if msg.RequestID != f.requestID {
@@ -514,6 +516,7 @@ func (f *fetcher) onReceive(pi module.ProtocolInfo, b []byte) {
 		var msg BlockData
 		_, err := codec.UnmarshalFromBytes(b, &msg)
 		if err != nil {
+			f.cl.ph.Report(f.id, module.ReportInvalidMessage)
 			return
 		}
 		if msg.RequestID != f.requestID {
@@ -535,10 +538,13 @@ func (f *fetcher) onReceive(pi module.ProtocolInfo, b []byte) {
 			r := io.MultiReader(bufs...)
 			blk, err := f.cl.bm.NewBlockDataFromReader(r)
 			if err != nil {
+				f.cl.ph.Report(f.id, module.ReportInvalidData)
 				f.cl.onResult(f, err, nil, nil)
 			} else if blk.Height() != f.height {
+				f.cl.ph.Report(f.id, module.ReportInvalidData)
 				f.cl.onResult(f, errors.Errorf("bad Height"), nil, nil)
 			} else {
+				f.cl.ph.Report(f.id, module.ReportValidMessage)
 				f.cl.onResult(f, nil, blk, f.voteList)
 			}
 		} else if f.left < 0 {
@@ -547,6 +553,7 @@ func (f *fetcher) onReceive(pi module.ProtocolInfo, b []byte) {
 				f.timer.Stop()
 				f.timer = nil
 			}
+			f.cl.ph.Report(f.id, module.ReportInvalidData)
 			f.cl.onResult(f, errors.Errorf("bad data"), nil, nil)
 		}
 	}
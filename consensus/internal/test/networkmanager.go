@@ -170,6 +170,9 @@ func (ph *tProtocolHandler) GetPeers() []module.PeerID {
 	return ph.nm.GetPeers()
 }
 
+func (ph *tProtocolHandler) Report(id module.PeerID, r module.PeerReport) {
+}
+
 func createAPeerID() module.PeerID {
 	return network.NewPeerIDFromAddress(wallet.New().Address())
 }
@@ -0,0 +1,154 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consensus_test
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/consensus"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/test"
+)
+
+func lastHeight(t *testing.T, n *test.Node) int64 {
+	blk, err := n.BM.GetLastBlock()
+	assert.NoError(t, err)
+	return blk.Height()
+}
+
+func TestConsensus_SimNetworkPartition(t *testing.T) {
+	sn := test.NewSimNetwork(1)
+	defer sn.Close()
+	sn.SetDefaultLink(test.LinkConfig{
+		Latency: 5 * time.Millisecond,
+		Jitter:  5 * time.Millisecond,
+		Loss:    0.01,
+	})
+
+	f := test.NewFixture(t, test.AddDefaultNode(false), test.AddValidatorNodes(4))
+	defer f.Close()
+
+	sn.Interconnect(f.Nodes)
+	for _, n := range f.Nodes {
+		err := n.CS.Start()
+		assert.NoError(t, err)
+	}
+	f.WaitForBlock(2)
+
+	// no majority in both partitions
+	v := f.Validators
+	sn.Partition(
+		[]module.PeerID{v[0].NM.ID(), v[1].NM.ID()},
+		[]module.PeerID{v[2].NM.ID(), v[3].NM.ID()},
+	)
+	h := lastHeight(t, v[0])
+	_, dropped := sn.Stats()
+
+	// validators keep sending messages across the partition without blocks
+	assert.Eventually(t, func() bool {
+		_, d := sn.Stats()
+		return d >= dropped+50
+	}, 10*time.Second, 10*time.Millisecond)
+	for _, n := range v {
+		assert.LessOrEqual(t, lastHeight(t, n), h+1)
+	}
+
+	sn.Heal()
+	for _, n := range v {
+		blk := n.WaitForBlock(h + 3)
+		assert.EqualValues(t, h+3, blk.Height())
+	}
+}
+
+func TestConsensus_SimNetworkRoundChange(t *testing.T) {
+	sn := test.NewSimNetwork(2)
+	defer sn.Close()
+
+	f := test.NewFixture(t, test.AddDefaultNode(false), test.AddValidatorNodes(4))
+	defer f.Close()
+
+	sn.Interconnect(f.Nodes)
+	for _, n := range f.Nodes {
+		err := n.CS.Start()
+		assert.NoError(t, err)
+	}
+	f.WaitForBlock(2)
+
+	// isolated validator cannot propose, so others change round for the
+	// heights of the validator.
+	v := f.Validators
+	isolated := v[3]
+	sn.Isolate(isolated.NM.ID())
+	h := lastHeight(t, v[0])
+	v[0].WaitForBlock(h + 5)
+	for height := h + 2; height <= h+5; height++ {
+		blk, err := v[0].BM.GetBlockByHeight(height)
+		assert.NoError(t, err)
+		assert.False(t, blk.Proposer().Equal(isolated.Address()))
+	}
+
+	sn.Heal()
+	blk := isolated.WaitForBlock(h + 6)
+	assert.EqualValues(t, h+6, blk.Height())
+}
+
+func TestConsensus_SimNetworkWALRecovery(t *testing.T) {
+	sn := test.NewSimNetwork(3)
+	defer sn.Close()
+
+	wals := make(map[string]*test.WAL)
+	f := test.NewFixture(t,
+		test.AddDefaultNode(false),
+		test.AddValidatorNodes(4),
+		test.UseConfig(&test.FixtureConfig{
+			NewCS: func(ctx *test.NodeContext) module.Consensus {
+				wm := test.NewWAL()
+				wals[ctx.Base] = wm
+				return consensus.New(ctx.C, path.Join(ctx.Base, "wal"), wm, nil, nil, nil)
+			},
+		}),
+	)
+	defer f.Close()
+
+	sn.Interconnect(f.Nodes)
+	for _, n := range f.Nodes {
+		err := n.CS.Start()
+		assert.NoError(t, err)
+	}
+	f.WaitForBlock(2)
+
+	// restart consensus of the validator with its WAL while it's isolated
+	v := f.Validators
+	n := v[3]
+	sn.Isolate(n.NM.ID())
+	h := lastHeight(t, v[0])
+	v[0].WaitForBlock(h + 2)
+	n.CS.Term()
+	n.CS = consensus.New(n.Chain, path.Join(n.Base, "wal"), wals[n.Base], nil, nil, nil)
+	err := n.CS.Start()
+	assert.NoError(t, err)
+
+	sn.Heal()
+	blk := n.WaitForBlock(h + 4)
+	assert.EqualValues(t, h+4, blk.Height())
+	blk = v[0].WaitForBlock(h + 5)
+	assert.EqualValues(t, h+5, blk.Height())
+}
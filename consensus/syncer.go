@@ -312,10 +312,12 @@ func (s *syncer) OnReceive(sp module.ProtocolInfo, bs []byte,
 	msg, err := UnmarshalMessage(sp.Uint16(), bs)
 	if err != nil {
 		s.log.Warnf("OnReceive: error=%+v\n", err)
+		s.ph.Report(id, module.ReportInvalidMessage)
 		return false, err
 	}
 	s.log.Debugf("OnReceive %v From:%v\n", msg, common.HexPre(id.Bytes()))
 	if err := msg.Verify(); err != nil {
+		s.ph.Report(id, module.ReportInvalidMessage)
 		return false, err
 	}
 	var idx int
@@ -27,6 +27,9 @@ module.exports = {
                 children: [
                     '/jsonrpc_v3',
                     '/btp_extension',
+                    '/rosetta_api',
+                    '/eth_jsonrpc',
+                    '/graphql',
                 ]
             },
             {
@@ -34,6 +37,7 @@ module.exports = {
                 children: [
                     '/goloop_admin_api',
                     ['/goloop_cli', "Goloop CLI"],
+                    ['/archive_mode', "Archive Mode"],
                     ['/metric', "Metric"],
                 ]
             },
@@ -88,6 +88,14 @@ func (c *testChain) MaxBlockTxBytes() int {
 	return 2 * 1024 * 1024
 }
 
+func (c *testChain) MaxSenderTxs() int {
+	return 0
+}
+
+func (c *testChain) MaxSenderTxBytes() int {
+	return 0
+}
+
 func (c *testChain) TransactionTimeout() time.Duration {
 	return time.Second * 5
 }
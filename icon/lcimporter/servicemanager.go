@@ -262,6 +262,22 @@ func (sm *ServiceManager) HasTransaction(id []byte) bool {
 	return false
 }
 
+func (sm *ServiceManager) CancelTransaction(req interface{}) ([]byte, error) {
+	return nil, errors.ErrInvalidState
+}
+
+func (sm *ServiceManager) GetTransactionPoolStatus() []module.TransactionPoolStatus {
+	return nil
+}
+
+func (sm *ServiceManager) GetPendingTransactions(from module.Address) []*module.PendingTransaction {
+	return nil
+}
+
+func (sm *ServiceManager) GetPendingTransaction(id []byte) *module.PendingTransaction {
+	return nil
+}
+
 func (sm *ServiceManager) SendTransactionAndWait(result []byte, height int64, tx interface{}) ([]byte, <-chan interface{}, error) {
 	return nil, nil, errors.ErrInvalidState
 }
@@ -282,6 +298,10 @@ func (sm *ServiceManager) ExecuteTransaction(result []byte, vh []byte, js []byte
 	return nil, errors.ErrInvalidState
 }
 
+func (sm *ServiceManager) TraceTransaction(result []byte, vh []byte, js []byte, bi module.BlockInfo, ti module.TraceInfo) (module.Receipt, error) {
+	return nil, errors.ErrInvalidState
+}
+
 func (sm *ServiceManager) AddSyncRequest(id db.BucketID, key []byte) error {
 	return errors.ErrInvalidState
 }
@@ -377,6 +397,10 @@ func (sm *ServiceManager) GetSCOREStatus(result []byte, addr module.Address) (mo
 	return nil, common.ErrInvalidState
 }
 
+func (sm *ServiceManager) GetAccountProof(result []byte, addr module.Address, keys [][]byte) (module.AccountProof, error) {
+	return nil, common.ErrInvalidState
+}
+
 func NewServiceManagerWithExecutor(chain module.Chain, ex *Executor, ps BlockV1ProofStorage, vs []*common.Address, cb ImportCallback) (*ServiceManager, error) {
 	logger := chain.Logger()
 	dbase := chain.Database()
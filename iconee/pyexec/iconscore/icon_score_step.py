@@ -13,6 +13,7 @@
 # limitations under the License.
 
 from enum import Enum, auto
+from typing import Optional
 
 from ..base.exception import IconServiceBaseException, ExceptionCode
 from ..utils import to_camel_case
@@ -109,11 +110,13 @@ class IconScoreStepCounter(object):
     def __init__(self,
                  step_costs: dict,
                  step_limit: int,
-                 refund_handler: callable) -> None:
+                 refund_handler: callable,
+                 steps_handler: Optional[callable] = None) -> None:
         """Constructor
 
         :param step_costs: a dict of base step costs
         :param step_limit: step limit for current context type
+        :param steps_handler: handler to report steps of each charge
         """
         converted_step_costs = {}
         for key, value in step_costs.items():
@@ -126,6 +129,7 @@ class IconScoreStepCounter(object):
         self._step_limit: int = step_limit
         self._step_used: int = 0
         self._refund_handler = refund_handler
+        self._steps_handler = steps_handler
 
         self._schema: int = self.get_step_cost(StepType.SCHEMA)
         if self._schema == 0:
@@ -179,10 +183,11 @@ class IconScoreStepCounter(object):
         base = self.get_base_step(step_type)
         step: int = base + self.get_step_cost(step_type) * count
         if step == 0:
+            self._report_step(step_type, count, step)
             return self._step_used
-        return self.consume_step(step_type, step)
+        return self.consume_step(step_type, step, count)
 
-    def consume_step(self, step_type: StepType, step: int) -> int:
+    def consume_step(self, step_type: StepType, step: int, count: int = -1) -> int:
         step_used: int = self._step_used + step
 
         while step_used > self._step_limit:
@@ -195,6 +200,7 @@ class IconScoreStepCounter(object):
                     self._step_limit, step_used, step, step_type)
 
         self._step_used = step_used
+        self._report_step(step_type, count, step)
         return step_used
 
     def refund_step(self, count: int) -> None:
@@ -203,6 +209,16 @@ class IconScoreStepCounter(object):
         else:
             steps: int = self._refund_base + self.get_step_cost(StepType.DELETE) * count
         self.add_step(steps)
+        self._report_step(StepType.SET, -1, steps)
+
+    def _report_step(self, step_type: StepType, count: int, step: int) -> None:
+        """ Reports steps of the charge. Negative count is for adjusting
+        steps of the previous charge.
+        """
+        if self._steps_handler is not None:
+            if step_type == StepType.LOG:
+                step_type = StepType.EVENT_LOG
+            self._steps_handler(step_type.value, count, step)
 
     def add_step(self, amount: int) -> None:
         # Assuming amount is always less than the current limit
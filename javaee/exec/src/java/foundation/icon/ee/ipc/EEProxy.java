@@ -71,6 +71,7 @@ public class EEProxy extends Proxy {
         public static final int GETOBJGRAPH = 13;
         public static final int SETOBJGRAPH = 14;
         public static final int SETFEEPCT = 15;
+        public static final int STEPS = 17;
     }
 
     public static class SetValueFlag {
@@ -221,6 +222,10 @@ public class EEProxy extends Proxy {
                 flags ? objectGraph.getGraphData() : null);
     }
 
+    public void steps(String type, int size, BigInteger steps) throws IOException {
+        sendMessage(MsgType.STEPS, type, size, steps);
+    }
+
     public void log(int level, int flag, String msg) throws IOException {
         sendMessage(MsgType.LOG, level, flag, msg);
     }
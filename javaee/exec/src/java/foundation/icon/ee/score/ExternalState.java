@@ -261,6 +261,16 @@ public class ExternalState implements IExternalState {
         return option;
     }
 
+    @Override
+    public void onSteps(String type, int size, long steps) {
+        try {
+            proxy.steps(type, size, BigInteger.valueOf(steps));
+        } catch (IOException e) {
+            logger.debug("[onSteps] {}", e.getMessage());
+            RuntimeAssertionError.unexpected(e);
+        }
+    }
+
     @Override
     public StepCost getStepCost() {
         return stepCost;
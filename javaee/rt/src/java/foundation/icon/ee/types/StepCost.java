@@ -29,6 +29,13 @@ public class StepCost {
     public static final String DELETE_BASE = "deleteBase";
     public static final String LOG_BASE = "logBase";
 
+    // types of operations reported in step profile mode
+    public static final String OP_GET = "get";
+    public static final String OP_SET = "set";
+    public static final String OP_REPLACE = "replace";
+    public static final String OP_DELETE = "delete";
+    public static final String OP_EVENT_LOG = "eventLog";
+
     private final Map<String, BigInteger> costMap;
 
     public StepCost(Map<String, BigInteger> costMap) {
@@ -12,6 +12,7 @@ import foundation.icon.ee.types.Address;
 import foundation.icon.ee.types.Bytes;
 import foundation.icon.ee.types.ManualRevertException;
 import foundation.icon.ee.types.Status;
+import foundation.icon.ee.types.StepCost;
 import foundation.icon.ee.types.Transaction;
 import foundation.icon.ee.util.Crypto;
 import foundation.icon.ee.util.LogMarker;
@@ -429,6 +430,9 @@ public class BlockchainRuntimeImpl implements IBlockchainRuntime {
         }
         var stepCost = externalState.getStepCost();
         IInstrumentation.charge(stepCost.eventLog(len));
+        if (externalState.isStepProfile()) {
+            externalState.onSteps(StepCost.OP_EVENT_LOG, len, stepCost.eventLog(len));
+        }
         externalState.event(bindexed, bdata);
     }
 
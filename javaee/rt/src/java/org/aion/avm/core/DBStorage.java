@@ -16,6 +16,7 @@
 
 package org.aion.avm.core;
 
+import foundation.icon.ee.types.StepCost;
 import i.IDBStorage;
 import i.IInstrumentation;
 
@@ -57,6 +58,12 @@ public class DBStorage implements IDBStorage {
         return IInstrumentation.attachedThreadInstrumentation.get().tryChargeEnergy(cost);
     }
 
+    private void report(String type, int size, int cost) {
+        if (ctx.isStepProfile()) {
+            ctx.onSteps(type, size, cost);
+        }
+    }
+
     public void setBytes(byte[] k, byte[] v) {
         if (ctx.isReadOnly()) {
             throw new IllegalStateException();
@@ -68,14 +75,21 @@ public class DBStorage implements IDBStorage {
                 ctx.putStorage(k, null, prevSize -> {
                     if (prevSize > 0) {
                         chargeImmediately(stepCost.setStorageDelete(prevSize) - rb);
+                        report(StepCost.OP_DELETE, prevSize,
+                                stepCost.setStorageDelete(prevSize));
+                    } else {
+                        report(StepCost.OP_DELETE, 0, rb);
                     }
                 });
             } else {
                 var prev = ctx.getStorage(k);
                 if (prev != null) {
                     chargeImmediately(stepCost.setStorageDelete(prev.length));
+                    report(StepCost.OP_DELETE, prev.length,
+                            stepCost.setStorageDelete(prev.length));
                 } else {
                     chargeImmediately(rb);
+                    report(StepCost.OP_DELETE, 0, rb);
                 }
                 ctx.putStorage(k, null, null);
             }
@@ -85,14 +99,23 @@ public class DBStorage implements IDBStorage {
                     if (prevSize > 0) {
                         chargeImmediately(-stepCost.setBase() + stepCost.replaceBase()
                                 + prevSize * stepCost.delete());
+                        report(StepCost.OP_REPLACE, v.length,
+                                stepCost.setStorageReplace(prevSize, v.length));
+                    } else {
+                        report(StepCost.OP_SET, v.length,
+                                stepCost.setStorageSet(v.length));
                     }
                 });
             } else {
                 var prev = ctx.getStorage(k);
                 if (prev != null) {
                     chargeImmediately(stepCost.setStorageReplace(prev.length, v.length));
+                    report(StepCost.OP_REPLACE, v.length,
+                            stepCost.setStorageReplace(prev.length, v.length));
                 } else {
                     chargeImmediately(stepCost.setStorageSet(v.length));
+                    report(StepCost.OP_SET, v.length,
+                            stepCost.setStorageSet(v.length));
                 }
                 ctx.putStorage(k, v, null);
             }
@@ -106,6 +129,7 @@ public class DBStorage implements IDBStorage {
         if (value != null)
             len = value.length;
         charge(stepCost.getStorage(len));
+        report(StepCost.OP_GET, len, stepCost.getStorage(len));
         return value;
     }
 
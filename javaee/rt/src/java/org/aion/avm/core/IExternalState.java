@@ -16,6 +16,7 @@ import java.util.function.IntConsumer;
 public interface IExternalState {
     int OPTION_READ_ONLY = 1;
     int OPTION_TRACE = 2;
+    int OPTION_STEP_PROFILE = 4;
 
     long REVISION_PURGE_ENUM_CACHE = 1 << 22;
     long REVISION_FIX_MAP_VALUES = 1 << 24;
@@ -186,6 +187,20 @@ public interface IExternalState {
         return (getOption() & OPTION_TRACE) != 0;
     }
 
+    default boolean isStepProfile() {
+        return (getOption() & OPTION_STEP_PROFILE) != 0;
+    }
+
+    /**
+     * Reports steps charged for the operation in step profile mode.
+     *
+     * @param type type of the operation (one of StepCost.OP_*)
+     * @param size size of the operation, or -1 for adjusting steps of
+     *             the previous operation
+     * @param steps charged steps
+     */
+    void onSteps(String type, int size, long steps);
+
     StepCost getStepCost();
 
     long getRevision();
@@ -168,6 +168,10 @@ type BlockManager interface {
 	// NewConsensusInfo returns a ConsensusInfo with blk's proposer and
 	// votes in blk.
 	NewConsensusInfo(blk Block) (ConsensusInfo, error)
+
+	// GetAddressIndex returns the index of transactions and event logs by
+	// addresses. It returns error if the index is not enabled for the chain.
+	GetAddressIndex() (AddressIndex, error)
 }
 
 type TransactionInfo interface {
@@ -177,3 +181,33 @@ type TransactionInfo interface {
 	Transaction() (Transaction, error)
 	GetReceipt() (Receipt, error)
 }
+
+type EventLogInfo interface {
+	TransactionInfo() TransactionInfo
+	Index() int
+	EventLog() (EventLog, error)
+}
+
+// AddressIndex indexes finalized transactions by from address, to address
+// and addresses of SCOREs emitting events, and event logs by addresses
+// of SCOREs emitting them.
+type AddressIndex interface {
+	// Range returns the range of heights of blocks whose transactions are
+	// indexed. If there is no indexed block, from is greater than to.
+	Range() (from int64, to int64, err error)
+
+	// GetTransactions returns transactions related to the address in blocks
+	// from the height `from` to the height `to`. It returns up to limit
+	// transactions starting from the cursor (nil for the first), and the
+	// cursor for the following ones if there are more.
+	GetTransactions(addr Address, from, to int64, cursor []byte, limit int) ([]TransactionInfo, []byte, error)
+
+	// GetEventLogs returns event logs of the address like GetTransactions.
+	GetEventLogs(addr Address, from, to int64, cursor []byte, limit int) ([]EventLogInfo, []byte, error)
+
+	// Build indexes transactions of finalized blocks from the height `from`
+	// to the height of the block just before already indexed blocks.
+	// on is called after each block is indexed, and Build stops if it
+	// returns an error.
+	Build(from int64, on func(height int64) error) error
+}
@@ -32,12 +32,16 @@ type Chain interface {
 	NormalTxPoolSize() int
 	PatchTxPoolSize() int
 	MaxBlockTxBytes() int
+	MaxSenderTxs() int
+	MaxSenderTxBytes() int
 	DefaultWaitTimeout() time.Duration
 	MaxWaitTimeout() time.Duration
 	TransactionTimeout() time.Duration
 	ChildrenLimit() int
 	NephewsLimit() int
+	PeerBanDuration() time.Duration
 	ValidateTxOnSend() bool
+	AddressIndexEnabled() bool
 	Genesis() []byte
 	GenesisStorage() GenesisStorage
 	CommitVoteSetDecoder() CommitVoteSetDecoder
@@ -36,8 +36,26 @@ type ProtocolHandler interface {
 	Multicast(pi ProtocolInfo, b []byte, role Role) error
 	Unicast(pi ProtocolInfo, b []byte, id PeerID) error
 	GetPeers() []PeerID
+	// Report changes reputation score of the peer. Peers with too low
+	// score are banned for a while.
+	Report(id PeerID, r PeerReport)
 }
 
+// PeerReport is the amount of change of the reputation score reported by
+// protocol handlers. Negative values are penalties.
+type PeerReport int
+
+// ReportTimeout is for requests not answered in time. Slow peers aren't
+// always misbehaving, so it's lighter than ReportFailedResponse, and
+// timeouts alone don't get the peer banned.
+const (
+	ReportValidMessage   PeerReport = 1
+	ReportTimeout        PeerReport = -1
+	ReportFailedResponse PeerReport = -5
+	ReportInvalidMessage PeerReport = -20
+	ReportInvalidData    PeerReport = -50
+)
+
 type BroadcastType byte
 type Role string
 
@@ -4,6 +4,7 @@ import (
 	"container/list"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/icon-project/goloop/common/db"
 )
@@ -242,6 +243,12 @@ type SCOREStatus interface {
 	ToJSON(height int64, version JSONVersion) (interface{}, error)
 }
 
+// AccountProof is merkle proof of an account in the world state with
+// merkle proofs of storage values of the account.
+type AccountProof interface {
+	ToJSON(version JSONVersion) (interface{}, error)
+}
+
 // Options for finalize
 const (
 	FinalizeNormalTransaction = 1 << iota
@@ -297,6 +304,22 @@ type TransitionManager interface {
 	WaitForTransaction(parent Transition, bi BlockInfo, cb func()) bool
 }
 
+// TransactionPoolStatus is the status of a transaction pool.
+type TransactionPoolStatus struct {
+	Group TransactionGroup
+	Size  int
+	Used  int
+	Bytes int
+}
+
+// PendingTransaction is a transaction waiting in a transaction pool.
+type PendingTransaction struct {
+	Transaction
+	Group   TransactionGroup
+	AddedAt time.Time
+	Direct  bool
+}
+
 type ServiceManager interface {
 	TransitionManager
 
@@ -353,6 +376,10 @@ type ServiceManager interface {
 	// GetSCOREStatus returns status of the contract
 	GetSCOREStatus(result []byte, addr Address) (SCOREStatus, error)
 
+	// GetAccountProof returns merkle proof of the account in the state
+	// including proofs of storage values for the keys.
+	GetAccountProof(result []byte, addr Address, keys [][]byte) (AccountProof, error)
+
 	// GetMembers returns network member list
 	GetMembers(result []byte) (MemberList, error)
 
@@ -383,6 +410,22 @@ type ServiceManager interface {
 	// HasTransaction returns whether it has specified transaction in the pool
 	HasTransaction(id []byte) bool
 
+	// CancelTransaction removes the transaction from the transaction pool
+	// with the cancel request signed by the sender of the transaction.
+	// It returns the hash of the canceled transaction.
+	CancelTransaction(req interface{}) ([]byte, error)
+
+	// GetTransactionPoolStatus returns status of the transaction pools.
+	GetTransactionPoolStatus() []TransactionPoolStatus
+
+	// GetPendingTransactions returns transactions in the transaction pools.
+	// If from is not nil, it returns only transactions sent by the address.
+	GetPendingTransactions(from Address) []*PendingTransaction
+
+	// GetPendingTransaction returns the transaction in the transaction pools.
+	// It returns nil if there is no such transaction.
+	GetPendingTransaction(id []byte) *PendingTransaction
+
 	// SendTransactionAndWait send transaction and return channel for result
 	SendTransactionAndWait(result []byte, height int64, tx interface{}) ([]byte, <-chan interface{}, error)
 
@@ -402,6 +445,11 @@ type ServiceManager interface {
 	// It ignores supplied step limit.
 	ExecuteTransaction(result []byte, vh []byte, js []byte, bi BlockInfo) (Receipt, error)
 
+	// TraceTransaction executes the transaction on the specified state
+	// like ExecuteTransaction, and traces the execution with ti.
+	// ti.Callback.OnEnd is called before it returns.
+	TraceTransaction(result []byte, vh []byte, js []byte, bi BlockInfo, ti TraceInfo) (Receipt, error)
+
 	// AddSyncRequest add sync request for specified data.
 	AddSyncRequest(id db.BucketID, key []byte) error
 }
@@ -41,6 +41,9 @@ const (
 	TraceModeNone TraceMode = iota
 	TraceModeInvoke
 	TraceModeBalanceChange
+	TraceModeCallTree
+	TraceModeStateDiff
+	TraceModeStepProfile
 )
 
 type OpType int
@@ -70,6 +73,55 @@ const (
 	EPhaseExecutionEnd
 )
 
+// TraceCall is the information of a call given to TraceCallback with
+// TraceModeCallTree.
+type TraceCall struct {
+	Type   string
+	From   Address
+	To     Address
+	Value  *big.Int
+	Method string
+	Params interface{}
+}
+
+// TraceContractState is the state of a contract in TraceAccountState.
+type TraceContractState struct {
+	Status       string
+	CodeHash     []byte
+	EEType       string
+	ContentType  string
+	DeployTxHash []byte
+	AuditTxHash  []byte
+}
+
+// TraceAccountState is the state of an account in TraceAccountDiff.
+type TraceAccountState struct {
+	Balance       *big.Int
+	IsContract    bool
+	ContractOwner Address
+	Disabled      bool
+	Blocked       bool
+	Contract      *TraceContractState
+	NextContract  *TraceContractState
+}
+
+// TraceStorageChange is a change of a storage value of an account.
+// Before or After is nil if there is no value.
+type TraceStorageChange struct {
+	Key    []byte
+	Before []byte
+	After  []byte
+}
+
+// TraceAccountDiff is the change of an account given to TraceCallback with
+// TraceModeStateDiff. Before or After is nil if the account is empty.
+type TraceAccountDiff struct {
+	Address Address
+	Before  *TraceAccountState
+	After   *TraceAccountState
+	Storage []*TraceStorageChange
+}
+
 type TraceCallback interface {
 	OnLog(level TraceLevel, msg string)
 	OnEnd(e error)
@@ -80,4 +132,20 @@ type TraceCallback interface {
 	OnFrameEnter() error
 	OnFrameExit(success bool) error
 	OnBalanceChange(opType OpType, from, to Address, amount *big.Int) error
+
+	// Following are called only with TraceModeCallTree or TraceModeStepProfile
+	OnCallStart(call *TraceCall) error
+	OnCallEnd(status error, stepUsed *big.Int) error
+
+	// Following is called only with TraceModeCallTree
+	OnEvent(addr Address, indexed, data [][]byte) error
+
+	// Following is called only with TraceModeStateDiff
+	OnStateDiff(diffs []*TraceAccountDiff) error
+
+	// Following is called only with TraceModeStepProfile. It's called when
+	// the steps for count units of stepType are charged. For the operations
+	// charged by the execution environment, steps are calculated with
+	// the step costs of the chain.
+	OnSteps(stepType string, count int, steps *big.Int) error
 }
@@ -0,0 +1,473 @@
+package network
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	DefaultAddressBookSize       = 1000
+	DefaultAddressBookSavePeriod = 1 * time.Minute
+)
+
+// AddressBookEntry is the record of the address learned through queries or
+// connections. Success and Failure are the number of connections and
+// failures of dialing.
+type AddressBookEntry struct {
+	Address  NetAddress   `json:"address"`
+	ID       string       `json:"id,omitempty"`
+	Role     PeerRoleFlag `json:"role"`
+	LastSeen *time.Time   `json:"lastSeen,omitempty"`
+	RTT      string       `json:"rtt,omitempty"`
+	Success  int          `json:"success"`
+	Failure  int          `json:"failure"`
+}
+
+type addressBook struct {
+	mtx      sync.Mutex
+	file     string
+	entries  map[NetAddress]*AddressBookEntry
+	dirty    bool
+	lastSave time.Time
+	logger   log.Logger
+}
+
+func newAddressBook(l log.Logger) *addressBook {
+	return &addressBook{
+		entries: make(map[NetAddress]*AddressBookEntry),
+		logger:  l,
+	}
+}
+
+// setFile loads entries from the file, and the book is saved to the file
+// after that. Missing file is not an error.
+func (b *addressBook) setFile(file string) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.file = file
+	b.lastSave = time.Now()
+	bs, err := ioutil.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.WithStack(err)
+	}
+	var l []*AddressBookEntry
+	if err := json.Unmarshal(bs, &l); err != nil {
+		return errors.Wrapf(err, "InvalidAddressBook(file=%s)", file)
+	}
+	for _, e := range l {
+		if len(e.Address) > 0 && len(b.entries) < DefaultAddressBookSize {
+			b.entries[e.Address] = e
+		}
+	}
+	return nil
+}
+
+func (b *addressBook) _save() error {
+	if len(b.file) == 0 {
+		return nil
+	}
+	bs, err := json.MarshalIndent(b._list(), "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	tmp := b.file + ".tmp"
+	if err := ioutil.WriteFile(tmp, bs, 0644); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := os.Rename(tmp, b.file); err != nil {
+		return errors.WithStack(err)
+	}
+	b.dirty = false
+	return nil
+}
+
+// save writes entries to the file if they are changed. Without force, it
+// writes at most once for DefaultAddressBookSavePeriod.
+func (b *addressBook) save(now time.Time, force bool) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if !b.dirty || (!force && now.Sub(b.lastSave) < DefaultAddressBookSavePeriod) {
+		return
+	}
+	b.lastSave = now
+	if err := b._save(); err != nil {
+		b.logger.Warnf("fail to save address book file=%s err=%+v", b.file, err)
+	}
+}
+
+// _evict removes the entry seen least recently for a new one.
+func (b *addressBook) _evict() {
+	var old *AddressBookEntry
+	for _, e := range b.entries {
+		if old == nil || old.LastSeen != nil && (e.LastSeen == nil || e.LastSeen.Before(*old.LastSeen)) {
+			old = e
+		}
+	}
+	if old != nil {
+		delete(b.entries, old.Address)
+	}
+}
+
+func (b *addressBook) _get(na NetAddress) *AddressBookEntry {
+	e, ok := b.entries[na]
+	if !ok {
+		if len(b.entries) >= DefaultAddressBookSize {
+			b._evict()
+		}
+		e = &AddressBookEntry{Address: na}
+		b.entries[na] = e
+	}
+	b.dirty = true
+	return e
+}
+
+// add records addresses learned with the role.
+func (b *addressBook) add(r PeerRoleFlag, nas ...NetAddress) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	for _, na := range nas {
+		if e, ok := b.entries[na]; ok {
+			if !e.Role.Has(r) {
+				e.Role.SetFlag(r)
+				b.dirty = true
+			}
+		} else if len(b.entries) < DefaultAddressBookSize {
+			b._get(na).Role = r
+		}
+	}
+}
+
+func (b *addressBook) onConnect(p *Peer, now time.Time) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	e := b._get(p.NetAddress())
+	e.ID = p.ID().String()
+	e.LastSeen = &now
+	e.Success++
+}
+
+func (b *addressBook) onDialFailure(na NetAddress) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if e, ok := b.entries[na]; ok {
+		e.Failure++
+		b.dirty = true
+	}
+}
+
+func (b *addressBook) onRole(p *Peer) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if e, ok := b.entries[p.NetAddress()]; ok && e.Role != p.Role() {
+		e.Role = p.Role()
+		b.dirty = true
+	}
+}
+
+func (b *addressBook) onRtt(p *Peer, rtt time.Duration) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if e, ok := b.entries[p.NetAddress()]; ok {
+		e.RTT = rtt.String()
+		b.dirty = true
+	}
+}
+
+func (b *addressBook) onClose(p *Peer, now time.Time) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if e, ok := b.entries[p.NetAddress()]; ok && e.ID == p.ID().String() {
+		e.LastSeen = &now
+		b.dirty = true
+	}
+}
+
+// addressOf returns the address seen most recently for the peer.
+func (b *addressBook) addressOf(id module.PeerID) (NetAddress, bool) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	var found *AddressBookEntry
+	ids := id.String()
+	for _, e := range b.entries {
+		if e.ID == ids && e.LastSeen != nil &&
+			(found == nil || found.LastSeen.Before(*e.LastSeen)) {
+			found = e
+		}
+	}
+	if found == nil {
+		return "", false
+	}
+	return found.Address, true
+}
+
+// addresses returns addresses having the role.
+func (b *addressBook) addresses(r PeerRoleFlag) []NetAddress {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	l := make([]NetAddress, 0)
+	for na, e := range b.entries {
+		if e.Role.Has(r) {
+			l = append(l, na)
+		}
+	}
+	return l
+}
+
+func (b *addressBook) _list() []*AddressBookEntry {
+	l := make([]*AddressBookEntry, 0, len(b.entries))
+	for _, e := range b.entries {
+		ec := *e
+		l = append(l, &ec)
+	}
+	sort.Slice(l, func(i, j int) bool {
+		return l[i].Address < l[j].Address
+	})
+	return l
+}
+
+func (b *addressBook) list() []*AddressBookEntry {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	return b._list()
+}
+
+func (p2p *PeerToPeer) isStaticPeer(p *Peer) bool {
+	return p2p.staticPeers.Contains(p.DialNetAddress()) ||
+		p2p.trustedPeers.Contains(p.ID())
+}
+
+// setTrustedPeers replaces trusted peers and their configured addresses.
+func (p2p *PeerToPeer) setTrustedPeers(peers []*TrustedPeer) {
+	p2p.trustedMtx.Lock()
+	defer p2p.trustedMtx.Unlock()
+
+	ids := make([]module.PeerID, 0, len(peers))
+	p2p.trustedAddrs = make(map[string]NetAddress)
+	for _, tp := range peers {
+		ids = append(ids, tp.ID)
+		if len(tp.Address) > 0 {
+			p2p.trustedAddrs[tp.ID.String()] = tp.Address
+		}
+	}
+	p2p.trustedPeers.ClearAndAdd(ids...)
+}
+
+// trustedAddressOf returns the address of the trusted peer. The configured
+// address is used first, then the address in the address book.
+func (p2p *PeerToPeer) trustedAddressOf(id module.PeerID) (NetAddress, bool) {
+	p2p.trustedMtx.Lock()
+	na, ok := p2p.trustedAddrs[id.String()]
+	p2p.trustedMtx.Unlock()
+	if ok {
+		return na, true
+	}
+	return p2p.book.addressOf(id)
+}
+
+// dialStaticPeers dials to static peers and trusted peers not connected.
+// Addresses of trusted peers come from the configuration or the address
+// book.
+func (p2p *PeerToPeer) dialStaticPeers() {
+	dialed := make(map[NetAddress]bool)
+	for _, p := range p2p.getPeers(false) {
+		dialed[p.DialNetAddress()] = true
+	}
+	for _, na := range p2p.staticPeers.Array() {
+		if !dialed[na] && !p2p.hasNetAddress(na) {
+			p2p.logger.Debugln("dialStaticPeers", "dial to static peer", na)
+			if err := p2p.dial(na); err != nil {
+				p2p.book.onDialFailure(na)
+			}
+		}
+	}
+	for _, id := range p2p.trustedPeers.Array() {
+		if p2p.getPeer(id, false) != nil {
+			continue
+		}
+		if na, ok := p2p.trustedAddressOf(id); ok && !p2p.hasNetAddress(na) {
+			p2p.logger.Debugln("dialStaticPeers", "dial to trusted peer", id, na)
+			if err := p2p.dial(na); err != nil {
+				p2p.book.onDialFailure(na)
+			}
+		}
+	}
+}
+
+// StaticPeers has static peers and trusted peers, and entries of the
+// address book for the admin API.
+type StaticPeers struct {
+	Static      []NetAddress        `json:"static"`
+	Trusted     []string            `json:"trusted"`
+	AddressBook []*AddressBookEntry `json:"addressBook"`
+}
+
+func parseNetAddresses(s string) []NetAddress {
+	l := make([]NetAddress, 0)
+	for _, v := range strings.Split(s, ",") {
+		if na := NetAddress(strings.TrimSpace(v)); len(na) > 0 {
+			l = append(l, na)
+		}
+	}
+	return l
+}
+
+// TrustedPeer is the trusted peer with the address to dial. Address is
+// empty if it's not configured.
+type TrustedPeer struct {
+	ID      module.PeerID
+	Address NetAddress
+}
+
+func (tp *TrustedPeer) String() string {
+	if len(tp.Address) > 0 {
+		return tp.ID.String() + "@" + string(tp.Address)
+	}
+	return tp.ID.String()
+}
+
+// ParseTrustedPeers parses comma separated trusted peers. Each peer is the
+// address of the peer optionally followed by "@" and the network address
+// like "hx...@10.0.0.2:7100".
+func ParseTrustedPeers(s string) ([]*TrustedPeer, error) {
+	l := make([]*TrustedPeer, 0)
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); len(v) == 0 {
+			continue
+		}
+		var na NetAddress
+		if idx := strings.Index(v, "@"); idx >= 0 {
+			host, port, err := net.SplitHostPort(v[idx+1:])
+			if err != nil || len(host) == 0 || len(port) == 0 {
+				return nil, errors.IllegalArgumentError.Errorf("InvalidPeerAddress(%s)", v)
+			}
+			na = NetAddress(v[idx+1:])
+			v = v[:idx]
+		}
+		ids, err := ParsePeerIDs(v)
+		if err != nil {
+			return nil, err
+		} else if len(ids) != 1 {
+			return nil, errors.IllegalArgumentError.Errorf("InvalidPeerID(%s)", v)
+		}
+		l = append(l, &TrustedPeer{ID: ids[0], Address: na})
+	}
+	return l, nil
+}
+
+// ParsePeerIDs parses comma separated addresses of peers.
+func ParsePeerIDs(s string) ([]module.PeerID, error) {
+	l := make([]module.PeerID, 0)
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); len(v) == 0 {
+			continue
+		}
+		addr, err := common.NewAddressFromString(v)
+		if err != nil || addr.IsContract() {
+			return nil, errors.IllegalArgumentError.Errorf("InvalidPeerID(%s)", v)
+		}
+		l = append(l, NewPeerIDFromAddress(addr))
+	}
+	return l, nil
+}
+
+// SetAddressBook loads the address book from the file, and the book is
+// saved to the file while the network manager is running.
+func SetAddressBook(nm module.NetworkManager, file string) error {
+	m, err := managerOf(nm)
+	if err != nil {
+		return err
+	}
+	if err := m.p2p.book.setFile(file); err != nil {
+		return err
+	}
+	m.p2p.seeds.Merge(m.p2p.book.addresses(p2pRoleSeed)...)
+	m.p2p.roots.Merge(m.p2p.book.addresses(p2pRoleRoot)...)
+	return nil
+}
+
+// SetStaticPeers sets comma separated addresses of peers which the dialer
+// always keeps connected.
+func SetStaticPeers(nm module.NetworkManager, peers string) error {
+	m, err := managerOf(nm)
+	if err != nil {
+		return err
+	}
+	var l []NetAddress
+	for _, na := range parseNetAddresses(peers) {
+		if na != m.p2p.NetAddress() {
+			l = append(l, na)
+		}
+	}
+	m.p2p.staticPeers.ClearAndAdd(l...)
+	return nil
+}
+
+// SetTrustedPeers sets comma separated trusted peers parsed by
+// ParseTrustedPeers. They are always allowed and kept connected, and never
+// banned.
+func SetTrustedPeers(nm module.NetworkManager, peers string) error {
+	m, err := managerOf(nm)
+	if err != nil {
+		return err
+	}
+	l, err := ParseTrustedPeers(peers)
+	if err != nil {
+		return err
+	}
+	m.p2p.setTrustedPeers(l)
+	for _, tp := range l {
+		m.p2p.reputations.unban(tp.ID, time.Now())
+	}
+	return nil
+}
+
+// GetStaticPeers returns static peers, trusted peers and entries of the
+// address book.
+func GetStaticPeers(nm module.NetworkManager) (*StaticPeers, error) {
+	m, err := managerOf(nm)
+	if err != nil {
+		return nil, err
+	}
+	sp := &StaticPeers{
+		Static:      m.p2p.staticPeers.Array(),
+		Trusted:     make([]string, 0),
+		AddressBook: m.p2p.book.list(),
+	}
+	sort.Slice(sp.Static, func(i, j int) bool {
+		return sp.Static[i] < sp.Static[j]
+	})
+	for _, id := range m.p2p.trustedPeers.Array() {
+		tp := &TrustedPeer{ID: id}
+		m.p2p.trustedMtx.Lock()
+		tp.Address = m.p2p.trustedAddrs[id.String()]
+		m.p2p.trustedMtx.Unlock()
+		sp.Trusted = append(sp.Trusted, tp.String())
+	}
+	sort.Strings(sp.Trusted)
+	return sp, nil
+}
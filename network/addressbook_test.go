@@ -0,0 +1,147 @@
+package network
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/server/metric"
+)
+
+func Test_addressBook_Update(t *testing.T) {
+	b := newAddressBook(log.GlobalLogger())
+	now := time.Now()
+	p1 := generatePeer()
+	p2 := generatePeer()
+	na := generateNetAddress()
+
+	b.add(p2pRoleSeed, p1.NetAddress(), na)
+	b.add(p2pRoleRoot, p1.NetAddress())
+	assert.Len(t, b.list(), 2)
+	assert.ElementsMatch(t, []NetAddress{p1.NetAddress(), na}, b.addresses(p2pRoleSeed))
+	assert.Equal(t, []NetAddress{p1.NetAddress()}, b.addresses(p2pRoleRoot))
+
+	// only connected peers have the address
+	_, ok := b.addressOf(p1.ID())
+	assert.False(t, ok)
+	b.onConnect(p1, now)
+	b.onConnect(p2, now)
+	b.onRtt(p1, 10*time.Millisecond)
+	b.onDialFailure(na)
+	b.onClose(p1, now.Add(time.Second))
+
+	addr, ok := b.addressOf(p1.ID())
+	assert.True(t, ok)
+	assert.Equal(t, p1.NetAddress(), addr)
+
+	l := b.list()
+	assert.Len(t, l, 3)
+	for _, e := range l {
+		switch e.Address {
+		case p1.NetAddress():
+			assert.Equal(t, p1.ID().String(), e.ID)
+			assert.Equal(t, "10ms", e.RTT)
+			assert.Equal(t, 1, e.Success)
+			assert.True(t, now.Add(time.Second).Equal(*e.LastSeen))
+		case p2.NetAddress():
+			assert.Equal(t, PeerRoleFlag(p2pRoleNone), e.Role)
+			assert.Equal(t, 1, e.Success)
+		case na:
+			assert.Equal(t, 1, e.Failure)
+			assert.Nil(t, e.LastSeen)
+		}
+	}
+}
+
+func Test_addressBook_Save(t *testing.T) {
+	dir, err := ioutil.TempDir("", "addressbook")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := path.Join(dir, "addressbook.json")
+
+	b := newAddressBook(log.GlobalLogger())
+	assert.NoError(t, b.setFile(file))
+	p := generatePeer()
+	now := time.Now()
+	b.onConnect(p, now)
+
+	// saved after the period unless it's forced
+	b.save(now, false)
+	_, err = os.Stat(file)
+	assert.True(t, os.IsNotExist(err))
+	b.save(now.Add(DefaultAddressBookSavePeriod), false)
+	_, err = os.Stat(file)
+	assert.NoError(t, err)
+
+	b2 := newAddressBook(log.GlobalLogger())
+	assert.NoError(t, b2.setFile(file))
+	addr, ok := b2.addressOf(p.ID())
+	assert.True(t, ok)
+	assert.Equal(t, p.NetAddress(), addr)
+	assert.Len(t, b2.list(), 1)
+}
+
+func Test_ParseTrustedPeers(t *testing.T) {
+	id1 := generatePeerID()
+	id2 := generatePeerID()
+
+	l, err := ParseTrustedPeers(id1.String() + ", " + id2.String() + "@10.0.0.2:7100,")
+	assert.NoError(t, err)
+	assert.Equal(t, []*TrustedPeer{
+		{ID: id1},
+		{ID: id2, Address: "10.0.0.2:7100"},
+	}, l)
+	assert.Equal(t, id2.String()+"@10.0.0.2:7100", l[1].String())
+
+	for _, s := range []string{
+		"cx0000000000000000000000000000000000000001",
+		id1.String() + "@10.0.0.2",
+		id1.String() + "@:7100",
+		"@10.0.0.2:7100",
+	} {
+		_, err := ParseTrustedPeers(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func Test_p2p_dialTrustedPeers(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer ln.Close()
+	na := NetAddress(ln.Addr().String())
+
+	dialed := make(chan string, 1)
+	d := newDialer("test", func(conn net.Conn, addr string, d *Dialer) {
+		conn.Close()
+		dialed <- addr
+	})
+	p2p := newPeerToPeer("test", generatePeer(), d,
+		metric.NewNetworkMetric(metric.DefaultMetricContext()), log.GlobalLogger())
+	defer p2p.sendTicker.Stop()
+
+	// the trusted peer is never seen, so the book doesn't have the address
+	unknown := generatePeerID()
+	trusted := generatePeerID()
+	p2p.setTrustedPeers([]*TrustedPeer{{ID: unknown}, {ID: trusted, Address: na}})
+	_, ok := p2p.book.addressOf(trusted)
+	assert.False(t, ok)
+
+	p2p.dialStaticPeers()
+	select {
+	case addr := <-dialed:
+		assert.Equal(t, string(na), addr)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "trusted peer is not dialed")
+	}
+	select {
+	case addr := <-dialed:
+		assert.Fail(t, "unexpected dial", addr)
+	default:
+	}
+}
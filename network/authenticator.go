@@ -22,7 +22,9 @@ var (
 	p2pProtoAuthSignatureResponse = module.ProtocolInfo(0x0400)
 
 	DefaultSecureEllipticCurve = elliptic.P256()
-	DefaultSecureSuites        = []SecureSuite{
+	// SecureSuiteTls13 and SecureSuiteNoise are not used unless they are
+	// configured by SetSecureSuites.
+	DefaultSecureSuites = []SecureSuite{
 		SecureSuiteNone,
 		SecureSuiteTls,
 		SecureSuiteEcdhe,
@@ -105,6 +107,10 @@ func (a *Authenticator) Signature(content []byte) []byte {
 	return sb
 }
 
+func (a *Authenticator) PublicKey() []byte {
+	return a.wallet.PublicKey()
+}
+
 func (a *Authenticator) VerifySignature(publicKey []byte, signature []byte, content []byte) (module.PeerID, error) {
 	pubKey, err := crypto.ParsePublicKey(publicKey)
 	if err != nil {
@@ -308,6 +314,9 @@ func (a *Authenticator) handleSecureRequest(pkt *Packet, p *Peer) {
 	case *tls.Conn:
 		m.SecureSuite = SecureSuiteTls
 		m.SecureError = SecureErrorEstablished
+	case *NoiseConn:
+		m.SecureSuite = SecureSuiteNoise
+		m.SecureError = SecureErrorEstablished
 	default:
 		p.secureKey = newSecureKey(DefaultSecureEllipticCurve, DefaultSecureKeyLogWriter)
 		m.SecureParam = p.secureKey.marshalPublicKey()
@@ -353,6 +362,23 @@ func (a *Authenticator) handleSecureRequest(pkt *Packet, p *Peer) {
 			tlsConn := tls.Server(p.conn, config)
 			p.ResetConn(tlsConn)
 		}
+	case SecureSuiteTls13:
+		if config, err := p.secureKey.tls13Config(a); err != nil {
+			a.logger.Infoln("handleSecureRequest", p.ConnString(), "failed tls13Config", err)
+			p.CloseByError(err)
+			return
+		} else {
+			tlsConn := tls.Server(p.conn, config)
+			p.ResetConn(tlsConn)
+		}
+	case SecureSuiteNoise:
+		if noiseConn, err := NewNoiseConn(p.conn, m.SecureAeadSuite, p.secureKey, a, false); err != nil {
+			a.logger.Infoln("handleSecureRequest", p.ConnString(), "failed NewNoiseConn", err)
+			p.CloseByError(err)
+			return
+		} else {
+			p.ResetConn(noiseConn)
+		}
 	default:
 		//SecureSuiteNone:
 		//Nothing to do
@@ -402,6 +428,8 @@ func (a *Authenticator) handleSecureResponse(pkt *Packet, p *Peer) {
 		secured = true
 	case *tls.Conn:
 		secured = true
+	case *NoiseConn:
+		secured = true
 	}
 	if secured {
 		err := fmt.Errorf("handleSecureResponse already established secure connection %T", p.conn)
@@ -439,6 +467,33 @@ func (a *Authenticator) handleSecureResponse(pkt *Packet, p *Peer) {
 			return
 		}
 		p.ResetConn(tlsConn)
+	case SecureSuiteTls13:
+		config, err := p.secureKey.tls13Config(a)
+		if err != nil {
+			a.logger.Infoln("handleSecureResponse", p.ConnString(), "failed tls13Config", err)
+			p.CloseByError(err)
+			return
+		}
+		tlsConn := tls.Client(p.conn, config)
+		if err := tlsConn.Handshake(); err != nil {
+			a.logger.Infoln("handleSecureResponse", p.ConnString(), "failed tls13 handshake", err)
+			p.CloseByError(err)
+			return
+		}
+		p.ResetConn(tlsConn)
+	case SecureSuiteNoise:
+		noiseConn, err := NewNoiseConn(p.conn, rsas, p.secureKey, a, true)
+		if err != nil {
+			a.logger.Infoln("handleSecureResponse", p.ConnString(), "failed NewNoiseConn", err)
+			p.CloseByError(err)
+			return
+		}
+		if err := noiseConn.Handshake(); err != nil {
+			a.logger.Infoln("handleSecureResponse", p.ConnString(), "failed noise handshake", err)
+			p.CloseByError(err)
+			return
+		}
+		p.ResetConn(noiseConn)
 	}
 
 	m := &SignatureRequest{
@@ -478,6 +533,8 @@ func (a *Authenticator) handleSignatureRequest(pkt *Packet, p *Peer) {
 		m = &SignatureResponse{Error: err.Error()}
 	} else if id.Equal(a.self) {
 		m = &SignatureResponse{Error: "selfAddress"}
+	} else if !p.secureKey.matchPeerID(id) {
+		m = &SignatureResponse{Error: "mismatchPeerID"}
 	}
 	p.setID(id)
 	a.sendMessage(p2pProtoAuth, p2pProtoAuthSignatureResponse, m, p)
@@ -521,6 +578,12 @@ func (a *Authenticator) handleSignatureResponse(pkt *Packet, p *Peer) {
 		p.CloseByError(err)
 		return
 	}
+	if !p.secureKey.matchPeerID(id) {
+		err := fmt.Errorf("handleSignatureResponse error[mismatchPeerID]")
+		a.logger.Infoln("handleSignatureResponse", p.ConnString(), "Error", err)
+		p.CloseByError(err)
+		return
+	}
 	p.setID(id)
 	if !p.ID().Equal(pkt.src) {
 		a.logger.Infoln("handleSignatureResponse", "id doesnt match pkt:", pkt.src, ",expected:", p.ID())
@@ -22,6 +22,8 @@ const (
 	DuplicatedPeerError
 	InvalidMessageSequenceError
 	InvalidSignatureError
+	BannedPeerError
+	NotBannedPeerError
 )
 
 var (
@@ -41,6 +43,8 @@ var (
 	ErrDuplicatedPeer            = errors.NewBase(DuplicatedPeerError, "DuplicatedPeer")
 	ErrInvalidMessageSequence    = errors.NewBase(InvalidMessageSequenceError, "InvalidMessageSequence")
 	ErrInvalidSignature          = errors.NewBase(InvalidSignatureError, "InvalidSignatureError")
+	ErrBannedPeer                = errors.NewBase(BannedPeerError, "BannedPeer")
+	ErrNotBannedPeer             = errors.NewBase(NotBannedPeerError, "NotBannedPeer")
 	ErrIllegalArgument           = errors.ErrIllegalArgument
 )
 
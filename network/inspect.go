@@ -107,6 +107,10 @@ func protocolHandlerToMap(ph *protocolHandler) map[string]interface{} {
 		m["receiveQueue"] = ph.receiveQueue.Available()
 		m["eventQueue"] = ph.eventQueue.Available()
 		m["sendQueue"] = ph.m.p2p.sendQueue.Available(int(ph.protocol.ID()))
+		priority, bandwidth := ph.m.p2p.sendQueue.Policy(int(ph.protocol.ID()))
+		m["sendPriority"] = priority
+		m["sendBandwidth"] = bandwidth
+		m["bytes"] = ph.m.mtr.ProtocolCounter(ph.protocol.Uint16())
 	}
 	return m
 }
@@ -32,6 +32,9 @@ type manager struct {
 	mtr *metric.NetworkMetric
 
 	streamReactors []*streamReactor
+
+	policyMtx    sync.RWMutex
+	sendPolicies map[byte]SendPolicy
 }
 
 func NewManager(c module.Chain, nt module.NetworkTransport, trustSeeds string, roles ...module.Role) module.NetworkManager {
@@ -70,6 +73,7 @@ func NewManager(c module.Chain, nt module.NetworkTransport, trustSeeds string, r
 
 	m.p2p.setConnectionLimit(p2pConnTypeChildren, c.ChildrenLimit())
 	m.p2p.setConnectionLimit(p2pConnTypeNephew, c.NephewsLimit())
+	m.p2p.reputations.setBanDuration(c.PeerBanDuration())
 
 	m.logger.Debugln("NewManager", channel)
 	return m
@@ -245,11 +249,22 @@ func (m *manager) unicast(pi module.ProtocolInfo, spi module.ProtocolInfo, bytes
 	pkt.dest = p2pDestPeer
 	pkt.ttl = 1
 	pkt.destPeer = id
-	pkt.priority = ph.getPriority()
+	pkt.priority = m.priorityOf(ph)
 	pkt.src = m.PeerID()
 	pkt.forceSend = true
+	// it sends directly to the peer for errors of the peer, and it's charged
+	// to the bandwidth budget of the protocol. ErrQueueOverflow is temporary,
+	// so callers may retry after the budget is refilled.
+	idx := int(pi.ID())
+	if !m.p2p.sendQueue.Available(idx) {
+		return ErrQueueOverflow
+	}
 	p := m.p2p.getPeerByProtocol(id, pkt.protocol, true)
-	return p.sendPacket(pkt)
+	if err := p.sendPacket(pkt); err != nil {
+		return err
+	}
+	m.p2p.sendQueue.Consume(idx, int(pkt.Len()))
+	return nil
 }
 
 func (m *manager) multicast(pi module.ProtocolInfo, spi module.ProtocolInfo, bytes []byte, role module.Role) error {
@@ -266,7 +281,7 @@ func (m *manager) multicast(pi module.ProtocolInfo, spi module.ProtocolInfo, byt
 	pkt := NewPacket(pi, spi, bytes)
 	pkt.dest = m.destByRole[role]
 	pkt.ttl = 0
-	pkt.priority = ph.getPriority()
+	pkt.priority = m.priorityOf(ph)
 	return m.p2p.Send(pkt)
 }
 
@@ -281,7 +296,7 @@ func (m *manager) broadcast(pi module.ProtocolInfo, spi module.ProtocolInfo, byt
 	pkt := NewPacket(pi, spi, bytes)
 	pkt.dest = p2pDestAny
 	pkt.ttl = bt.TTL()
-	pkt.priority = ph.getPriority()
+	pkt.priority = m.priorityOf(ph)
 	pkt.forceSend = bt.ForceSend()
 	return m.p2p.Send(pkt)
 }
@@ -294,7 +309,7 @@ func (m *manager) relay(pkt *Packet) error {
 	if !ok {
 		return ErrNotRegisteredReactor
 	}
-	pkt.priority = ph.getPriority()
+	pkt.priority = m.priorityOf(ph)
 	return m.p2p.Send(pkt)
 }
 
@@ -0,0 +1,230 @@
+package network
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sync"
+
+	"github.com/flynn/noise"
+)
+
+// NoiseConn is the connection secured by Noise_XX_25519_{ChaChaPoly|AESGCM}_SHA256.
+// Noise defines AESGCM with 256 bits key only, so it's used for both of
+// SecureAeadSuiteAes128Gcm and SecureAeadSuiteAes256Gcm.
+// The prologue is secureKey.extra, and both of handshake payloads have
+// the wallet signature for the static key of the sender.
+type NoiseConn struct {
+	net.Conn
+	cs        noise.CipherSuite
+	k         *secureKey
+	s         secureSigner
+	initiator bool
+
+	hsMtx  sync.Mutex
+	hsDone bool
+	hsErr  error
+
+	in   *noise.CipherState
+	out  *noise.CipherState
+	rbuf []byte
+}
+
+const (
+	noiseHeaderSize     = 2
+	noiseMaxMessageSize = math.MaxUint16
+	noiseTagSize        = 16
+)
+
+func newNoiseCipherSuite(sa SecureAeadSuite) (noise.CipherSuite, error) {
+	switch sa {
+	case SecureAeadSuiteAes128Gcm, SecureAeadSuiteAes256Gcm:
+		return noise.NewCipherSuite(noise.DH25519, noise.CipherAESGCM, noise.HashSHA256), nil
+	case SecureAeadSuiteChaCha20Poly1305:
+		return noise.NewCipherSuite(noise.DH25519, noise.CipherChaChaPoly, noise.HashSHA256), nil
+	default:
+		return nil, fmt.Errorf("not supported secure aead %v", sa)
+	}
+}
+
+func NewNoiseConn(conn net.Conn, sa SecureAeadSuite, k *secureKey, s secureSigner, initiator bool) (*NoiseConn, error) {
+	cs, err := newNoiseCipherSuite(sa)
+	if err != nil {
+		return nil, err
+	}
+	c := &NoiseConn{Conn: conn, cs: cs, k: k, s: s, initiator: initiator}
+	return c, nil
+}
+
+// Handshake runs the handshake if it's not done yet.
+// Read and Write call it automatically.
+func (c *NoiseConn) Handshake() error {
+	c.hsMtx.Lock()
+	defer c.hsMtx.Unlock()
+
+	if !c.hsDone {
+		c.hsDone = true
+		c.hsErr = c.handshake()
+	}
+	return c.hsErr
+}
+
+// -> e
+// <- e, ee, s, es
+// -> s, se
+func (c *NoiseConn) handshake() error {
+	s, err := c.cs.GenerateKeypair(rand.Reader)
+	if err != nil {
+		return err
+	}
+	hs, err := noise.NewHandshakeState(noise.Config{
+		CipherSuite:   c.cs,
+		Random:        rand.Reader,
+		Pattern:       noise.HandshakeXX,
+		Initiator:     c.initiator,
+		Prologue:      c.k.extra,
+		StaticKeypair: s,
+	})
+	if err != nil {
+		return err
+	}
+	id, err := c.k.identity(c.s, s.Public)
+	if err != nil {
+		return err
+	}
+
+	var cs1, cs2 *noise.CipherState
+	if c.initiator {
+		if err = c.writeHandshake(hs, nil); err != nil {
+			return err
+		}
+		if err = c.readHandshake(hs); err != nil {
+			return err
+		}
+		if cs1, cs2, err = c.writeHandshakeAndSplit(hs, id); err != nil {
+			return err
+		}
+		c.out, c.in = cs1, cs2
+	} else {
+		if err = c.readHandshake(hs); err != nil {
+			return err
+		}
+		if err = c.writeHandshake(hs, id); err != nil {
+			return err
+		}
+		if cs1, cs2, err = c.readHandshakeAndSplit(hs); err != nil {
+			return err
+		}
+		c.in, c.out = cs1, cs2
+	}
+	return nil
+}
+
+func (c *NoiseConn) writeHandshake(hs *noise.HandshakeState, payload []byte) error {
+	_, _, err := c.writeHandshakeAndSplit(hs, payload)
+	return err
+}
+
+func (c *NoiseConn) writeHandshakeAndSplit(hs *noise.HandshakeState, payload []byte) (*noise.CipherState, *noise.CipherState, error) {
+	msg, cs1, cs2, err := hs.WriteMessage(nil, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = writeNoiseMessage(c.Conn, msg); err != nil {
+		return nil, nil, err
+	}
+	return cs1, cs2, nil
+}
+
+func (c *NoiseConn) readHandshake(hs *noise.HandshakeState) error {
+	_, _, err := c.readHandshakeAndSplit(hs)
+	return err
+}
+
+// readHandshakeAndSplit reads the handshake message, and it verifies the
+// identity in the payload if the message has the static key of the peer.
+func (c *NoiseConn) readHandshakeAndSplit(hs *noise.HandshakeState) (*noise.CipherState, *noise.CipherState, error) {
+	msg, err := readNoiseMessage(c.Conn)
+	if err != nil {
+		return nil, nil, err
+	}
+	known := hs.PeerStatic() != nil
+	payload, cs1, cs2, err := hs.ReadMessage(nil, msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !known && hs.PeerStatic() != nil {
+		if err = c.k.verifyIdentity(c.s, payload, hs.PeerStatic()); err != nil {
+			return nil, nil, err
+		}
+	} else if len(payload) > 0 {
+		return nil, nil, fmt.Errorf("noise: unexpected handshake payload size:%d", len(payload))
+	}
+	return cs1, cs2, nil
+}
+
+func (c *NoiseConn) Read(b []byte) (n int, err error) {
+	if err = c.Handshake(); err != nil {
+		return
+	}
+	for len(c.rbuf) == 0 {
+		var msg []byte
+		if msg, err = readNoiseMessage(c.Conn); err != nil {
+			return
+		}
+		if c.rbuf, err = c.in.Decrypt(msg[:0], nil, msg); err != nil {
+			return
+		}
+	}
+	n = copy(b, c.rbuf)
+	c.rbuf = c.rbuf[n:]
+	return
+}
+
+func (c *NoiseConn) Write(b []byte) (n int, err error) {
+	if err = c.Handshake(); err != nil {
+		return
+	}
+	size := noiseMaxMessageSize - noiseTagSize
+	for len(b) > n {
+		cn := len(b) - n
+		if cn > size {
+			cn = size
+		}
+		var msg []byte
+		if msg, err = c.out.Encrypt(nil, nil, b[n:n+cn]); err != nil {
+			return
+		}
+		if err = writeNoiseMessage(c.Conn, msg); err != nil {
+			return
+		}
+		n += cn
+	}
+	return
+}
+
+func readNoiseMessage(r io.Reader) ([]byte, error) {
+	var hb [noiseHeaderSize]byte
+	if _, err := io.ReadFull(r, hb[:]); err != nil {
+		return nil, err
+	}
+	msg := make([]byte, binary.BigEndian.Uint16(hb[:]))
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func writeNoiseMessage(w io.Writer, msg []byte) error {
+	if len(msg) > noiseMaxMessageSize {
+		return fmt.Errorf("noise: too large message size:%d", len(msg))
+	}
+	b := make([]byte, noiseHeaderSize+len(msg))
+	binary.BigEndian.PutUint16(b, uint16(len(msg)))
+	copy(b[noiseHeaderSize:], msg)
+	_, err := w.Write(b)
+	return err
+}
@@ -76,7 +76,7 @@ var (
 
 type PeerToPeer struct {
 	channel          string
-	sendQueue        *WeightQueue
+	sendQueue        *ShapingQueue
 	alternateQueue   Queue
 	sendTicker       *time.Ticker
 	onPacketCbFuncs  map[uint16]packetCbFunc
@@ -117,6 +117,16 @@ type PeerToPeer struct {
 	cLimit    map[PeerConnectionType]int
 	cLimitMtx sync.RWMutex
 
+	//reputation
+	reputations *reputationTable
+
+	//static peers and address book
+	staticPeers  *NetAddressSet
+	trustedPeers *PeerIDSet
+	trustedAddrs map[string]NetAddress
+	trustedMtx   sync.Mutex
+	book         *addressBook
+
 	//log
 	logger log.Logger
 
@@ -143,7 +153,7 @@ func newPeerToPeer(channel string, self *Peer, d *Dialer, mtr *metric.NetworkMet
 	p2pLogger := l.WithFields(log.Fields{LoggerFieldKeySubModule: "p2p"})
 	p2p := &PeerToPeer{
 		channel:          channel,
-		sendQueue:        NewWeightQueue(DefaultSendQueueSize, DefaultSendQueueMaxPriority+1),
+		sendQueue:        NewShapingQueue(DefaultSendQueueSize, DefaultSendQueueMaxPriority+1, DefaultSendPriority),
 		alternateQueue:   NewQueue(DefaultSendQueueSize),
 		sendTicker:       time.NewTicker(DefaultAlternateSendPeriod),
 		onPacketCbFuncs:  make(map[uint16]packetCbFunc),
@@ -176,6 +186,13 @@ func newPeerToPeer(channel string, self *Peer, d *Dialer, mtr *metric.NetworkMet
 		//
 		cLimit: make(map[PeerConnectionType]int),
 		//
+		reputations: newReputationTable(),
+		//
+		staticPeers:  NewNetAddressSet(),
+		trustedPeers: NewPeerIDSet(),
+		trustedAddrs: make(map[string]NetAddress),
+		book:         newAddressBook(p2pLogger),
+		//
 		logger: p2pLogger,
 		//
 		mtr: mtr,
@@ -247,6 +264,7 @@ func (p2p *PeerToPeer) Stop() {
 	wg.Wait()
 
 	p2p.run = false
+	p2p.book.save(time.Now(), true)
 	p2p.logger.Debugln("Stop", "Done")
 }
 
@@ -308,11 +326,17 @@ func (p2p *PeerToPeer) unsetEventCbFunc(k uint16) {
 //callback from PeerDispatcher.onPeer
 func (p2p *PeerToPeer) onPeer(p *Peer) {
 	p2p.logger.Debugln("onPeer", p)
-	if !p2p.allowedPeers.IsEmpty() && !p2p.allowedPeers.Contains(p.ID()) {
+	if !p2p.allowedPeers.IsEmpty() && !p2p.allowedPeers.Contains(p.ID()) &&
+		!p2p.trustedPeers.Contains(p.ID()) {
 		p2p.onEvent(p2pEventNotAllowed, p)
 		p.CloseByError(fmt.Errorf("onPeer not allowed connection"))
 		return
 	}
+	if p2p.reputations.isBanned(p.ID(), time.Now()) {
+		p2p.onEvent(p2pEventNotAllowed, p)
+		p.CloseByError(ErrBannedPeer)
+		return
+	}
 	if p2p.isTrustSeed(p) {
 		p2p.trustSeeds.SetAndRemoveByData(p.DialNetAddress(), string(p.NetAddress()))
 	}
@@ -334,6 +358,9 @@ func (p2p *PeerToPeer) onPeer(p *Peer) {
 		p2p.logger.Infoln("Already exists connected Peer, close old", dp, diff)
 	}
 	p2p.orphanages.AddWithPredicate(p, func(p *Peer) bool { return !p.IsClosed() })
+	if len(p.NetAddress()) > 0 {
+		p2p.book.onConnect(p, time.Now())
+	}
 	if !p.In() {
 		p2p.sendQuery(p)
 	}
@@ -378,6 +405,7 @@ func (p2p *PeerToPeer) onClose(p *Peer) {
 	if p2p.isTrustSeed(p) {
 		p2p.trustSeeds.RemoveData(p.DialNetAddress())
 	}
+	p2p.book.onClose(p, time.Now())
 }
 
 func (p2p *PeerToPeer) onEvent(evt string, p *Peer) {
@@ -440,6 +468,7 @@ func (p2p *PeerToPeer) onPacket(pkt *Packet, p *Peer) {
 	//	return
 	//}
 	if !p.ProtocolInfos().Exists(pkt.protocol) {
+		p2p.report(p.ID(), module.ReportInvalidMessage)
 		p.CloseByError(ErrNotRegisteredProtocol)
 		return
 	}
@@ -460,6 +489,7 @@ func (p2p *PeerToPeer) onPacket(pkt *Packet, p *Peer) {
 			case p2pProtoConnResp:
 				p2p.handleP2PConnectionResponse(pkt, p)
 			default:
+				p2p.report(p.ID(), module.ReportInvalidMessage)
 				p.CloseByError(ErrNotRegisteredProtocol)
 			}
 		default:
@@ -691,7 +721,7 @@ func (p2p *PeerToPeer) handleQuery(pkt *Packet, p *Peer) {
 		m.Roots = p2p.roots.Array()
 		m.Seeds = p2p.seeds.Array()
 	} else {
-		if r.Has(p2pRoleRoot) {
+		if r.Has(p2pRoleRoot) && !p2p.isStaticPeer(p) {
 			p2p.logger.Infoln("handleQuery", "not allowed connection", p)
 			p.Close("handleQuery not allowed connection")
 			return
@@ -767,8 +797,9 @@ func (p2p *PeerToPeer) handleQueryResult(pkt *Packet, p *Peer) {
 		p.setRole(rr)
 		p2p.applyPeerRole(p)
 	}
+	p2p.book.onRole(p)
 	if !rr.Has(p2pRoleSeed) && !rr.Has(p2pRoleRoot) {
-		if !p2p.isTrustSeed(p) {
+		if !p2p.isTrustSeed(p) && !p2p.isStaticPeer(p) {
 			p2p.logger.Infoln("handleQueryResult", "invalid query, not allowed connection", p)
 			p.CloseByError(fmt.Errorf("handleQueryResult invalid query, resolved role %d", rr))
 			return
@@ -784,6 +815,7 @@ func (p2p *PeerToPeer) handleQueryResult(pkt *Packet, p *Peer) {
 			}
 		}
 		p2p.roots.Merge(roots...)
+		p2p.book.add(p2pRoleRoot, roots...)
 	}
 	seeds := make([]NetAddress, 0)
 	for _, na := range qrm.Seeds {
@@ -792,6 +824,7 @@ func (p2p *PeerToPeer) handleQueryResult(pkt *Packet, p *Peer) {
 		}
 	}
 	p2p.seeds.Merge(seeds...)
+	p2p.book.add(p2pRoleSeed, seeds...)
 
 	m := &RttMessage{Last: p.rtt.last, Average: p.rtt.avg}
 	rpkt := newPacket(p2pProtoControl, p2pProtoRttReq, p2p.encodeMsgpack(m), p2p.ID())
@@ -813,6 +846,7 @@ func (p2p *PeerToPeer) handleRttRequest(pkt *Packet, p *Peer) {
 	}
 	p2p.logger.Traceln("handleRttRequest", rm, p)
 	p2p.stopRtt(p)
+	p2p.book.onRtt(p, p.rtt.last)
 
 	df := rm.Last - p.rtt.last
 	if df > DefaultRttAccuracy {
@@ -838,6 +872,7 @@ func (p2p *PeerToPeer) handleRttResponse(pkt *Packet, p *Peer) {
 		return
 	}
 	p2p.logger.Traceln("handleRttResponse", rm, p)
+	p2p.book.onRtt(p, p.rtt.last)
 
 	df := rm.Last - p.rtt.last
 	if df > DefaultRttAccuracy {
@@ -1022,6 +1057,7 @@ Loop:
 				default: //p2pDestPeerGroup < dest < p2pDestPeer
 					//TODO multicast Routing or Flooding
 				}
+				p2p.sendQueue.Consume(int(pkt.protocol.ID()), int(pkt.Len())*c.enqueue)
 
 				if c.alternate < 1 {
 					atomic.StoreInt32(&c.fixed, 1)
@@ -1394,6 +1430,7 @@ func (p2p *PeerToPeer) discoverRoutine() {
 		p2p.logger.Debugln("discoverRoutine", "initialize", "dial to trustSeed", na)
 		p2p.dial(na)
 	}
+	p2p.dialStaticPeers()
 Loop:
 	for {
 		select {
@@ -1409,6 +1446,7 @@ Loop:
 						p2p.logger.Debugln("discoverRoutine", "seedTicker", "dial to p2pRoleSeed", s)
 						if err := p2p.dial(s); err != nil {
 							p2p.seeds.Remove(s)
+							p2p.book.onDialFailure(s)
 						} else {
 							dialed++
 						}
@@ -1429,12 +1467,14 @@ Loop:
 			} else {
 				seeds := p2p.orphanages.GetBy(p2pRoleSeed, true, false)
 				for _, p := range seeds {
-					if !p.HasRole(p2pRoleRoot) {
+					if !p.HasRole(p2pRoleRoot) && !p2p.isStaticPeer(p) {
 						p2p.logger.Debugln("discoverRoutine", "seedTicker", "no need outgoing p2pRoleSeed connection")
 						p.Close("discoverRoutine no need outgoing p2pRoleSeed connection")
 					}
 				}
 			}
+			p2p.dialStaticPeers()
+			p2p.book.save(time.Now(), false)
 		case <-p2p.discoveryTicker.C:
 			r := p2p.Role()
 			if r.Has(p2pRoleRoot) {
@@ -187,6 +187,7 @@ func (ph *protocolHandler) onPacket(pkt *Packet, p *Peer) {
 		case module.NotRegisteredProtocolPolicyClose:
 			fallthrough
 		default:
+			ph.m.p2p.report(p.ID(), module.ReportInvalidMessage)
 			p.CloseByError(ErrNotRegisteredProtocol)
 			ph.logger.Infoln("onPacket", "not registered protocol", ph.name, pkt.protocol, pkt.subProtocol, p.ID())
 		}
@@ -352,3 +353,8 @@ func (ph *protocolHandler) Broadcast(pi module.ProtocolInfo, b []byte, bt module
 func (ph *protocolHandler) GetPeers() []module.PeerID {
 	return ph.m.getPeersByProtocol(ph.protocol)
 }
+
+func (ph *protocolHandler) Report(id module.PeerID, r module.PeerReport) {
+	ph.logger.Traceln("Report", id, r)
+	ph.m.p2p.report(id, r)
+}
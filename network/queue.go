@@ -3,6 +3,7 @@ package network
 import (
 	"context"
 	"sync"
+	"time"
 )
 
 type Queue interface {
@@ -295,3 +296,219 @@ func NewWeightQueue(size int, nq int) *WeightQueue {
 	q.current = make([]int, nq)
 	return q
 }
+
+// sendBudget is the token bucket for the bandwidth in bytes per second.
+// Tokens can be negative after consuming, then it waits until tokens are
+// refilled to positive.
+type sendBudget struct {
+	rate    int
+	tokens  float64
+	updated time.Time
+}
+
+func newSendBudget(rate int, now time.Time) *sendBudget {
+	if rate < 1 {
+		return nil
+	}
+	return &sendBudget{rate: rate, tokens: float64(rate), updated: now}
+}
+
+func (b *sendBudget) refill(now time.Time) {
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * float64(b.rate)
+		if b.tokens > float64(b.rate) {
+			b.tokens = float64(b.rate)
+		}
+		b.updated = now
+	}
+}
+
+func (b *sendBudget) wait(now time.Time) time.Duration {
+	if b == nil {
+		return 0
+	}
+	b.refill(now)
+	if b.tokens > 0 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / float64(b.rate) * float64(time.Second))
+}
+
+func (b *sendBudget) consume(n int, now time.Time) {
+	if b == nil {
+		return
+	}
+	b.refill(now)
+	b.tokens -= float64(n)
+}
+
+// ShapingQueue is the WeightQueue having priorities and bandwidth budgets
+// of queues. It pops from queues having the highest priority (the lowest
+// value) among queues within their budgets, and weights are applied to
+// queues having the same priority.
+type ShapingQueue struct {
+	WeightQueue
+	priorities []int
+	budgets    []*sendBudget
+	timer      *time.Timer
+	closed     bool
+}
+
+func (q *ShapingQueue) eligible(idx int, now time.Time) (bool, time.Duration) {
+	if q.queues[idx].len < 1 {
+		return false, 0
+	}
+	if d := q.budgets[idx].wait(now); d > 0 {
+		return false, d
+	}
+	return true, 0
+}
+
+func (q *ShapingQueue) fetch() (context.Context, bool) {
+	now := time.Now()
+	s := len(q.queues)
+	priority := -1
+	var wait time.Duration
+	for i := 0; i < s; i++ {
+		if ok, d := q.eligible(i, now); ok {
+			if priority < 0 || q.priorities[i] < priority {
+				priority = q.priorities[i]
+			}
+		} else if d > 0 && (wait == 0 || d < wait) {
+			wait = d
+		}
+	}
+	if priority < 0 {
+		if wait > 0 {
+			q.wakeupAfter(wait)
+		}
+		return nil, false
+	}
+	for i := 0; i < s; i++ {
+		idx := (q.idx + i) % s
+		if q.priorities[idx] != priority {
+			continue
+		}
+		if ok, _ := q.eligible(idx, now); !ok {
+			if q.queues[idx].len < 1 {
+				q.current[idx] = 0
+			}
+			continue
+		}
+		ctx, _ := q.queues[idx].pop()
+		q.current[idx] += 1
+		if q.current[idx] >= q.weights[idx] {
+			q.current[idx] = 0
+			idx = (idx + 1) % s
+		}
+		q.idx = idx
+		return ctx, true
+	}
+	return nil, false
+}
+
+func (q *ShapingQueue) wakeupAfter(d time.Duration) {
+	if q.timer == nil {
+		q.timer = time.AfterFunc(d, q.wakeup)
+	} else {
+		q.timer.Reset(d)
+	}
+}
+
+func (q *ShapingQueue) wakeup() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if !q.closed {
+		q.notify()
+	}
+}
+
+// SetPriority sets the priority of the queue. Lower value is higher priority.
+func (q *ShapingQueue) SetPriority(idx int, priority int) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if idx < 0 || idx >= len(q.priorities) || priority < 0 {
+		return ErrIllegalArgument
+	}
+	q.priorities[idx] = priority
+	q.notify()
+	return nil
+}
+
+// SetBandwidth sets the bandwidth budget of the queue in bytes per second.
+// Zero means unlimited.
+func (q *ShapingQueue) SetBandwidth(idx int, bandwidth int) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if idx < 0 || idx >= len(q.budgets) || bandwidth < 0 {
+		return ErrIllegalArgument
+	}
+	q.budgets[idx] = newSendBudget(bandwidth, time.Now())
+	q.notify()
+	return nil
+}
+
+// Policy returns the priority and the bandwidth of the queue.
+func (q *ShapingQueue) Policy(idx int) (int, int) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if idx < 0 || idx >= len(q.priorities) {
+		return 0, 0
+	}
+	if b := q.budgets[idx]; b != nil {
+		return q.priorities[idx], b.rate
+	}
+	return q.priorities[idx], 0
+}
+
+// Available returns whether the bandwidth budget of the queue has tokens.
+func (q *ShapingQueue) Available(idx int) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if idx < 0 || idx >= len(q.budgets) {
+		return false
+	}
+	return q.budgets[idx].wait(time.Now()) <= 0
+}
+
+// Consume takes n bytes from the bandwidth budget of the queue.
+func (q *ShapingQueue) Consume(idx int, n int) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if idx < 0 || idx >= len(q.budgets) {
+		return
+	}
+	q.budgets[idx].consume(n, time.Now())
+}
+
+func (q *ShapingQueue) Close() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.timer != nil {
+		q.timer.Stop()
+	}
+	q.closed = true
+	q.term()
+}
+
+func NewShapingQueue(size int, nq int, priority int) *ShapingQueue {
+	q := new(ShapingQueue)
+	q.init(size, nq)
+	q.fetchFunc = q.fetch
+	q.weights = make([]int, nq)
+	q.priorities = make([]int, nq)
+	for i := 0; i < nq; i++ {
+		q.weights[i] = 1
+		q.priorities[i] = priority
+	}
+	q.current = make([]int, nq)
+	q.budgets = make([]*sendBudget, nq)
+	return q
+}
@@ -5,6 +5,9 @@ import (
 	"log"
 	"sync"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestPriorityQueue_Pop(t *testing.T) {
@@ -107,3 +110,60 @@ func TestWeightQueue_Pop(t *testing.T) {
 	q.Close()
 	exit.Wait()
 }
+
+func TestShapingQueue_Priority(t *testing.T) {
+	q := NewShapingQueue(10, 4, DefaultSendPriority)
+	defer q.Close()
+	assert.NoError(t, q.SetPriority(2, 1))
+	assert.NoError(t, q.SetPriority(3, DefaultSendQueueMaxPriority))
+
+	for idx := 3; idx >= 0; idx-- {
+		for i := 0; i < 2; i++ {
+			q.Push(context.WithValue(context.Background(), "index", idx), idx)
+		}
+	}
+	var indexes []int
+	for ctx := q.Pop(); ctx != nil; ctx = q.Pop() {
+		indexes = append(indexes, ctx.Value("index").(int))
+	}
+	// same priority is served by round-robin
+	assert.Equal(t, []int{2, 2, 0, 1, 0, 1, 3, 3}, indexes)
+}
+
+func TestShapingQueue_Bandwidth(t *testing.T) {
+	q := NewShapingQueue(10, 2, DefaultSendPriority)
+	defer q.Close()
+	assert.NoError(t, q.SetPriority(0, 1))
+	assert.NoError(t, q.SetBandwidth(0, 1000))
+
+	for i := 0; i < 2; i++ {
+		q.Push(context.WithValue(context.Background(), "index", 0), 0)
+		q.Push(context.WithValue(context.Background(), "index", 1), 1)
+	}
+
+	// out of budget, then lower priority is served
+	assert.Equal(t, 0, q.Pop().Value("index"))
+	q.Consume(0, 1100)
+	assert.False(t, q.Available(0))
+	assert.True(t, q.Available(1))
+	assert.Equal(t, 1, q.Pop().Value("index"))
+	assert.Equal(t, 1, q.Pop().Value("index"))
+	assert.Nil(t, q.Pop())
+
+	// notified after refill
+	start := time.Now()
+	var ctx context.Context
+	for ctx == nil {
+		select {
+		case <-q.Wait():
+			ctx = q.Pop()
+		case <-time.After(time.Second):
+			assert.FailNow(t, "no notification after refill")
+		}
+	}
+	assert.Equal(t, 0, ctx.Value("index"))
+	assert.True(t, time.Since(start) >= 90*time.Millisecond)
+	priority, bandwidth := q.Policy(0)
+	assert.Equal(t, 1, priority)
+	assert.Equal(t, 1000, bandwidth)
+}
@@ -0,0 +1,284 @@
+package network
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	DefaultBanThreshold       = -100
+	DefaultReputationMax      = 100
+	DefaultReputationRecovery = 10 * time.Second
+	DefaultBanDuration        = 10 * time.Minute
+	DefaultReputationSweep    = 1 * time.Minute
+)
+
+// PeerReputation is the reputation of the peer for the admin API.
+type PeerReputation struct {
+	ID          string     `json:"id"`
+	Score       int        `json:"score"`
+	Bans        int        `json:"bans"`
+	BannedUntil *time.Time `json:"bannedUntil,omitempty"`
+}
+
+type reputation struct {
+	id      module.PeerID
+	score   int
+	updated time.Time
+	bans    int
+	until   time.Time
+}
+
+// scoreAt returns the score at the time. The score goes back to zero by
+// a point for each DefaultReputationRecovery.
+func (r *reputation) scoreAt(now time.Time) int {
+	n := int(now.Sub(r.updated) / DefaultReputationRecovery)
+	if r.score > 0 {
+		if r.score > n {
+			return r.score - n
+		}
+		return 0
+	} else {
+		if -r.score > n {
+			return r.score + n
+		}
+		return 0
+	}
+}
+
+func (r *reputation) update(score int, now time.Time) {
+	old := r.score
+	r.score = r.scoreAt(now)
+	if r.score == 0 {
+		r.updated = now
+	} else {
+		n := old - r.score
+		if n < 0 {
+			n = -n
+		}
+		r.updated = r.updated.Add(time.Duration(n) * DefaultReputationRecovery)
+	}
+	r.score += score
+	if r.score > DefaultReputationMax {
+		r.score = DefaultReputationMax
+	}
+}
+
+func (r *reputation) bannedAt(now time.Time) bool {
+	return now.Before(r.until)
+}
+
+func (r *reputation) ban(d time.Duration, now time.Time) {
+	r.score = 0
+	r.updated = now
+	r.bans++
+	r.until = now.Add(d)
+}
+
+type reputationTable struct {
+	mtx       sync.Mutex
+	m         map[string]*reputation
+	threshold int
+	duration  time.Duration
+	lastSweep time.Time
+}
+
+func newReputationTable() *reputationTable {
+	return &reputationTable{
+		m:         make(map[string]*reputation),
+		threshold: DefaultBanThreshold,
+		duration:  DefaultBanDuration,
+	}
+}
+
+func (t *reputationTable) setBanDuration(d time.Duration) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if d <= 0 {
+		d = DefaultBanDuration
+	}
+	t.duration = d
+}
+
+// sweep removes reputations which are the same as new ones.
+func (t *reputationTable) sweep(now time.Time) {
+	if now.Sub(t.lastSweep) < DefaultReputationSweep {
+		return
+	}
+	t.lastSweep = now
+	for k, r := range t.m {
+		if r.scoreAt(now) == 0 && !r.bannedAt(now) && r.bans == 0 {
+			delete(t.m, k)
+		}
+	}
+}
+
+func (t *reputationTable) get(id module.PeerID, now time.Time) *reputation {
+	t.sweep(now)
+	r, ok := t.m[id.String()]
+	if !ok {
+		r = &reputation{id: id, updated: now}
+		t.m[id.String()] = r
+	}
+	return r
+}
+
+// report applies the report to the score of the peer. It returns true if
+// the peer is banned by the report.
+func (t *reputationTable) report(id module.PeerID, rp module.PeerReport, now time.Time) bool {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	r := t.get(id, now)
+	if r.bannedAt(now) {
+		return false
+	}
+	// timeouts don't lower the score below the half of the threshold
+	if rp == module.ReportTimeout && r.scoreAt(now) <= t.threshold/2 {
+		return false
+	}
+	r.update(int(rp), now)
+	if r.score <= t.threshold {
+		r.ban(t.duration, now)
+		return true
+	}
+	return false
+}
+
+// ban bans the peer for the duration. If d is not positive, it uses the
+// configured duration.
+func (t *reputationTable) ban(id module.PeerID, d time.Duration, now time.Time) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if d <= 0 {
+		d = t.duration
+	}
+	t.get(id, now).ban(d, now)
+}
+
+// unban clears the ban of the peer. It returns false if the peer is not
+// banned.
+func (t *reputationTable) unban(id module.PeerID, now time.Time) bool {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	r, ok := t.m[id.String()]
+	if !ok || !r.bannedAt(now) {
+		return false
+	}
+	r.until = time.Time{}
+	return true
+}
+
+func (t *reputationTable) isBanned(id module.PeerID, now time.Time) bool {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	r, ok := t.m[id.String()]
+	return ok && r.bannedAt(now)
+}
+
+func (t *reputationTable) list(now time.Time) []*PeerReputation {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.sweep(now)
+	l := make([]*PeerReputation, 0, len(t.m))
+	for _, r := range t.m {
+		pr := &PeerReputation{
+			ID:    r.id.String(),
+			Score: r.scoreAt(now),
+			Bans:  r.bans,
+		}
+		if r.bannedAt(now) {
+			until := r.until
+			pr.BannedUntil = &until
+		}
+		l = append(l, pr)
+	}
+	sort.Slice(l, func(i, j int) bool {
+		if l[i].Score != l[j].Score {
+			return l[i].Score < l[j].Score
+		}
+		return l[i].ID < l[j].ID
+	})
+	return l
+}
+
+func (p2p *PeerToPeer) report(id module.PeerID, rp module.PeerReport) {
+	if id == nil || p2p.ID().Equal(id) || p2p.trustedPeers.Contains(id) {
+		return
+	}
+	if p2p.reputations.report(id, rp, time.Now()) {
+		p2p.logger.Infoln("report", "ban peer", id, rp)
+		p2p.closeBannedPeer(id)
+	}
+}
+
+func (p2p *PeerToPeer) ban(id module.PeerID, d time.Duration) {
+	p2p.reputations.ban(id, d, time.Now())
+	p2p.logger.Infoln("ban", id, d)
+	p2p.closeBannedPeer(id)
+}
+
+func (p2p *PeerToPeer) unban(id module.PeerID) bool {
+	p2p.logger.Infoln("unban", id)
+	return p2p.reputations.unban(id, time.Now())
+}
+
+func (p2p *PeerToPeer) closeBannedPeer(id module.PeerID) {
+	if p := p2p.getPeer(id, false); p != nil {
+		p.CloseByError(ErrBannedPeer)
+	}
+}
+
+func managerOf(nm module.NetworkManager) (*manager, error) {
+	if m, ok := nm.(*manager); ok {
+		return m, nil
+	}
+	return nil, ErrNotAvailable
+}
+
+// PeerReputations returns reputations of peers reported or banned.
+func PeerReputations(nm module.NetworkManager) ([]*PeerReputation, error) {
+	m, err := managerOf(nm)
+	if err != nil {
+		return nil, err
+	}
+	return m.p2p.reputations.list(time.Now()), nil
+}
+
+// BanPeer bans the peer for the duration, and closes the connection with
+// it. If d is not positive, it uses the configured duration.
+func BanPeer(nm module.NetworkManager, id module.PeerID, d time.Duration) error {
+	m, err := managerOf(nm)
+	if err != nil {
+		return err
+	}
+	if m.p2p.ID().Equal(id) {
+		return errors.IllegalArgumentError.New("SelfPeerID")
+	}
+	if m.p2p.trustedPeers.Contains(id) {
+		return errors.IllegalArgumentError.New("TrustedPeerID")
+	}
+	m.p2p.ban(id, d)
+	return nil
+}
+
+// UnbanPeer clears the ban of the peer.
+func UnbanPeer(nm module.NetworkManager, id module.PeerID) error {
+	m, err := managerOf(nm)
+	if err != nil {
+		return err
+	}
+	if !m.p2p.unban(id) {
+		return ErrNotBannedPeer
+	}
+	return nil
+}
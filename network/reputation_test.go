@@ -0,0 +1,97 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/module"
+)
+
+func Test_reputation_Recovery(t *testing.T) {
+	now := time.Now()
+	r := &reputation{updated: now}
+
+	r.update(int(module.ReportInvalidData), now)
+	assert.Equal(t, -50, r.scoreAt(now))
+	assert.Equal(t, -40, r.scoreAt(now.Add(10*DefaultReputationRecovery)))
+	assert.Equal(t, 0, r.scoreAt(now.Add(100*DefaultReputationRecovery)))
+
+	// frequent reports don't stop recovery
+	for i := 1; i <= 10; i++ {
+		r.update(0, now.Add(time.Duration(i)*DefaultReputationRecovery))
+	}
+	assert.Equal(t, -40, r.score)
+
+	r.update(DefaultReputationMax*2, now)
+	assert.Equal(t, DefaultReputationMax, r.score)
+}
+
+func Test_reputation_Ban(t *testing.T) {
+	rt := newReputationTable()
+	rt.setBanDuration(time.Minute)
+	id1 := generatePeerID()
+	id2 := generatePeerID()
+	now := time.Now()
+
+	assert.False(t, rt.report(id1, module.ReportInvalidData, now))
+	assert.False(t, rt.report(id2, module.ReportValidMessage, now))
+	assert.True(t, rt.report(id1, module.ReportInvalidData, now))
+	assert.True(t, rt.isBanned(id1, now))
+	assert.False(t, rt.isBanned(id2, now))
+
+	// reports are ignored while it's banned
+	assert.False(t, rt.report(id1, module.ReportInvalidData, now))
+
+	l := rt.list(now)
+	assert.Len(t, l, 2)
+	assert.Equal(t, id1.String(), l[0].ID)
+	assert.Equal(t, 1, l[0].Bans)
+	assert.NotNil(t, l[0].BannedUntil)
+	assert.Equal(t, id2.String(), l[1].ID)
+	assert.Nil(t, l[1].BannedUntil)
+
+	// expired
+	assert.False(t, rt.isBanned(id1, now.Add(time.Minute)))
+
+	// manual ban and unban
+	rt.ban(id2, 0, now)
+	assert.True(t, rt.isBanned(id2, now.Add(time.Minute-time.Second)))
+	assert.True(t, rt.unban(id2, now))
+	assert.False(t, rt.isBanned(id2, now))
+	assert.False(t, rt.unban(id2, now))
+}
+
+func Test_reputation_Sweep(t *testing.T) {
+	rt := newReputationTable()
+	id1 := generatePeerID()
+	id2 := generatePeerID()
+	now := time.Now()
+
+	rt.report(id1, module.ReportValidMessage, now)
+	rt.ban(id2, time.Second, now)
+	assert.Len(t, rt.list(now), 2)
+
+	// banned peers are kept for the count of bans
+	later := now.Add(DefaultReputationSweep)
+	assert.Len(t, rt.list(later), 1)
+	assert.Equal(t, id2.String(), rt.list(later)[0].ID)
+}
+
+func Test_reputation_Timeout(t *testing.T) {
+	rt := newReputationTable()
+	id := generatePeerID()
+	now := time.Now()
+
+	// timeouts alone don't ban the peer
+	for i := 0; i < -DefaultBanThreshold*2; i++ {
+		assert.False(t, rt.report(id, module.ReportTimeout, now))
+	}
+	assert.False(t, rt.isBanned(id, now))
+	assert.Equal(t, DefaultBanThreshold/2, rt.list(now)[0].Score)
+
+	// but they make the peer banned sooner for invalid data
+	assert.True(t, rt.report(id, module.ReportInvalidData, now))
+	assert.True(t, rt.isBanned(id, now))
+}
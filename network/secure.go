@@ -9,6 +9,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -21,6 +22,7 @@ import (
 	"golang.org/x/crypto/sha3"
 
 	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
 )
 
 type SecureConn struct {
@@ -165,8 +167,25 @@ type secureKey struct {
 	extra        []byte
 	sa           SecureAeadSuite
 	keyLogWriter io.Writer
+	//peerID is the identity of the peer verified in the handshake
+	peerID module.PeerID
 }
 
+// secureSigner signs and verifies identities with the node wallet
+type secureSigner interface {
+	PublicKey() []byte
+	Signature(content []byte) []byte
+	VerifySignature(publicKey []byte, signature []byte, content []byte) (module.PeerID, error)
+}
+
+type secureIdentity struct {
+	PublicKey []byte
+	Signature []byte
+}
+
+// secureIdentityOID is the private certificate extension having secureIdentity
+var secureIdentityOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 51340, 1, 1}
+
 func newSecureKey(curve elliptic.Curve, keyLogWriter io.Writer) *secureKey {
 	k, err := ecdsa.GenerateKey(curve, rand.Reader)
 	if err != nil {
@@ -264,7 +283,7 @@ func (k *secureKey) tlsConfig() (*tls.Config, error) {
 	default:
 		return nil, fmt.Errorf("secureKey: unknown SecureAeadSuite")
 	}
-	cert, err := k.selfCertificate("")
+	cert, err := k.selfCertificate("", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -292,7 +311,38 @@ func (k *secureKey) tlsConfig() (*tls.Config, error) {
 	return config, nil
 }
 
-func (k *secureKey) selfCertificate(commonName string) (tls.Certificate, error) {
+// tls13Config returns the configuration of the TLS 1.3 suite. The certificate
+// has the wallet signature for its public key, so the peer verifies the
+// identity of the node in the handshake. The AEAD is chosen by crypto/tls.
+func (k *secureKey) tls13Config(s secureSigner) (*tls.Config, error) {
+	id, err := k.identity(s, k.marshalPublicKey())
+	if err != nil {
+		return nil, err
+	}
+	cert, err := k.selfCertificate("", []pkix.Extension{{Id: secureIdentityOID, Value: id}})
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		InsecureSkipVerify: true,
+		Certificates:       []tls.Certificate{cert},
+		ClientAuth:         tls.RequireAnyClientCert,
+		MinVersion:         tls.VersionTLS13,
+		MaxVersion:         tls.VersionTLS13,
+		//certificates are ephemeral, so sessions are not resumed
+		SessionTicketsDisabled: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+			if err := k.verifyCertificate(rawCerts, verifiedChains); err != nil {
+				return err
+			}
+			return k.verifyCertificateIdentity(s, rawCerts)
+		},
+		KeyLogWriter: k.keyLogWriter,
+	}
+	return config, nil
+}
+
+func (k *secureKey) selfCertificate(commonName string, exts []pkix.Extension) (tls.Certificate, error) {
 	cur := time.Now()
 	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
 	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
@@ -309,6 +359,7 @@ func (k *secureKey) selfCertificate(commonName string) (tls.Certificate, error)
 		NotAfter:  cur.Add(365 * 24 * time.Hour),
 
 		IsCA:                  true,
+		ExtraExtensions:       exts,
 		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
 		BasicConstraintsValid: true,
 	}
@@ -354,6 +405,54 @@ func (k *secureKey) verifyCertificate(rawCerts [][]byte, verifiedChains [][]*x50
 	return nil
 }
 
+func (k *secureKey) verifyCertificateIdentity(s secureSigner, rawCerts [][]byte) error {
+	if len(rawCerts) == 0 {
+		return fmt.Errorf("secureKey: no certificate")
+	}
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return err
+	}
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(secureIdentityOID) {
+			return k.verifyIdentity(s, ext.Value, elliptic.Marshal(k.Curve, k.pX, k.pY))
+		}
+	}
+	return fmt.Errorf("secureKey: no identity in certificate")
+}
+
+// identity returns the wallet signature for the public key of the handshake
+// bound to the key exchanged by SecureRequest and SecureResponse.
+func (k *secureKey) identity(s secureSigner, publicKey []byte) ([]byte, error) {
+	content := append(append([]byte(nil), publicKey...), k.extra...)
+	return asn1.Marshal(secureIdentity{
+		PublicKey: s.PublicKey(),
+		Signature: s.Signature(content),
+	})
+}
+
+func (k *secureKey) verifyIdentity(s secureSigner, b []byte, publicKey []byte) error {
+	si := &secureIdentity{}
+	if rest, err := asn1.Unmarshal(b, si); err != nil {
+		return err
+	} else if len(rest) > 0 {
+		return fmt.Errorf("secureKey: invalid identity")
+	}
+	content := append(append([]byte(nil), publicKey...), k.extra...)
+	id, err := s.VerifySignature(si.PublicKey, si.Signature, content)
+	if err != nil {
+		return err
+	}
+	k.peerID = id
+	return nil
+}
+
+// matchPeerID returns whether id is the identity verified in the handshake.
+// It returns true if the handshake doesn't verify the identity.
+func (k *secureKey) matchPeerID(id module.PeerID) bool {
+	return k.peerID == nil || k.peerID.Equal(id)
+}
+
 type SecureSuite byte
 
 const (
@@ -361,6 +460,8 @@ const (
 	SecureSuiteNone
 	SecureSuiteTls
 	SecureSuiteEcdhe
+	SecureSuiteTls13
+	SecureSuiteNoise
 )
 
 func (s SecureSuite) String() string {
@@ -371,6 +472,10 @@ func (s SecureSuite) String() string {
 		return "tls"
 	case SecureSuiteEcdhe:
 		return "ecdhe"
+	case SecureSuiteTls13:
+		return "tls13"
+	case SecureSuiteNoise:
+		return "noise"
 	default:
 		return "unknown"
 	}
@@ -384,6 +489,10 @@ func SecureSuiteFromString(s string) SecureSuite {
 		return SecureSuiteTls
 	case "ecdhe":
 		return SecureSuiteEcdhe
+	case "tls13":
+		return SecureSuiteTls13
+	case "noise":
+		return SecureSuiteNoise
 	default:
 		return SecureSuiteUnknown
 	}
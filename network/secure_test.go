@@ -0,0 +1,113 @@
+package network
+
+import (
+	"bytes"
+	"crypto/tls"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/wallet"
+	"github.com/icon-project/goloop/module"
+)
+
+func newSecureKeyPair(t *testing.T, sa SecureAeadSuite) (*secureKey, *secureKey) {
+	k1 := newSecureKey(DefaultSecureEllipticCurve, nil)
+	k2 := newSecureKey(DefaultSecureEllipticCurve, nil)
+	assert.NoError(t, k1.setup(sa, k2.marshalPublicKey(), false, 2))
+	assert.NoError(t, k2.setup(sa, k1.marshalPublicKey(), true, 2))
+	return k1, k2
+}
+
+func newTestSigner() (*Authenticator, module.PeerID) {
+	w := wallet.New()
+	return newAuthenticator(w, log.New()), NewPeerIDFromAddress(w.Address())
+}
+
+func assertSecureTransfer(t *testing.T, client, server net.Conn) {
+	data := bytes.Repeat([]byte("secure"), 20000)
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := client.Write(data)
+		errCh <- err
+	}()
+	b := make([]byte, len(data))
+	_, err := io.ReadFull(server, b)
+	assert.NoError(t, err)
+	assert.NoError(t, <-errCh)
+	assert.Equal(t, data, b)
+}
+
+func Test_secure_Tls13(t *testing.T) {
+	k1, k2 := newSecureKeyPair(t, SecureAeadSuiteChaCha20Poly1305)
+	a1, id1 := newTestSigner()
+	a2, id2 := newTestSigner()
+	cfg1, err := k1.tls13Config(a1)
+	assert.NoError(t, err)
+	cfg2, err := k2.tls13Config(a2)
+	assert.NoError(t, err)
+
+	c1, c2 := net.Pipe()
+	client := tls.Client(c1, cfg1)
+	server := tls.Server(c2, cfg2)
+	defer c1.Close()
+	defer c2.Close()
+
+	assertSecureTransfer(t, client, server)
+	assert.Equal(t, uint16(tls.VersionTLS13), client.ConnectionState().Version)
+	assert.True(t, id2.Equal(k1.peerID))
+	assert.True(t, id1.Equal(k2.peerID))
+	assert.True(t, k1.matchPeerID(id2))
+	assert.False(t, k1.matchPeerID(id1))
+}
+
+func Test_secure_Noise(t *testing.T) {
+	for _, sa := range DefaultSecureAeadSuites {
+		t.Run(sa.String(), func(t *testing.T) {
+			k1, k2 := newSecureKeyPair(t, sa)
+			a1, id1 := newTestSigner()
+			a2, id2 := newTestSigner()
+
+			c1, c2 := net.Pipe()
+			client, err := NewNoiseConn(c1, sa, k1, a1, true)
+			assert.NoError(t, err)
+			server, err := NewNoiseConn(c2, sa, k2, a2, false)
+			assert.NoError(t, err)
+			defer client.Close()
+			defer server.Close()
+
+			assertSecureTransfer(t, client, server)
+			assertSecureTransfer(t, server, client)
+			assert.True(t, id2.Equal(k1.peerID))
+			assert.True(t, id1.Equal(k2.peerID))
+		})
+	}
+}
+
+func Test_secure_NoiseMismatchPrologue(t *testing.T) {
+	sa := SecureAeadSuite(SecureAeadSuiteChaCha20Poly1305)
+	k1, k2 := newSecureKeyPair(t, sa)
+	k3, _ := newSecureKeyPair(t, sa)
+	k2.extra = k3.extra
+	a1, _ := newTestSigner()
+	a2, _ := newTestSigner()
+
+	c1, c2 := net.Pipe()
+	client, err := NewNoiseConn(c1, sa, k1, a1, true)
+	assert.NoError(t, err)
+	server, err := NewNoiseConn(c2, sa, k2, a2, false)
+	assert.NoError(t, err)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Handshake()
+	}()
+	assert.Error(t, client.Handshake())
+	_ = client.Close()
+	assert.Error(t, <-errCh)
+	_ = server.Close()
+	assert.Nil(t, k1.peerID)
+}
@@ -0,0 +1,121 @@
+package network
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	DefaultSendPriority = 4
+)
+
+// SendPolicy is the priority and the bandwidth budget of a protocol for
+// sending packets. Packets of the protocol with higher priority (lower
+// value) are sent first, and Bandwidth is in bytes per second (0: unlimited).
+type SendPolicy struct {
+	Priority  int
+	Bandwidth int
+}
+
+var protocolNames = map[string]module.ProtocolInfo{
+	"p2p":           module.ProtoP2P,
+	"statesync":     module.ProtoStateSync,
+	"transaction":   module.ProtoTransaction,
+	"consensus":     module.ProtoConsensus,
+	"fastsync":      module.ProtoFastSync,
+	"consensussync": module.ProtoConsensusSync,
+}
+
+func parseProtocolID(s string) (byte, error) {
+	if pi, ok := protocolNames[s]; ok {
+		return pi.ID(), nil
+	}
+	id, err := strconv.ParseUint(s, 0, 8)
+	if err != nil || id > DefaultSendQueueMaxPriority {
+		return 0, errors.IllegalArgumentError.Errorf("InvalidProtocol(%s)", s)
+	}
+	return byte(id), nil
+}
+
+// ParseSendPolicies parses comma separated send policies. Each policy is
+// "<protocol>:<priority>[:<bandwidth>]", where protocol is the name
+// (p2p, statesync, transaction, consensus, fastsync, consensussync) or the
+// ID of the protocol, and priority is from 1 (highest) to
+// DefaultSendQueueMaxPriority (lowest).
+func ParseSendPolicies(s string) (map[byte]SendPolicy, error) {
+	policies := make(map[byte]SendPolicy)
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); len(v) == 0 {
+			continue
+		}
+		tokens := strings.Split(v, ":")
+		if len(tokens) < 2 || len(tokens) > 3 {
+			return nil, errors.IllegalArgumentError.Errorf("InvalidSendPolicy(%s)", v)
+		}
+		id, err := parseProtocolID(tokens[0])
+		if err != nil {
+			return nil, err
+		}
+		sp := SendPolicy{}
+		sp.Priority, err = strconv.Atoi(tokens[1])
+		if err != nil || sp.Priority < 1 || sp.Priority > DefaultSendQueueMaxPriority {
+			return nil, errors.IllegalArgumentError.Errorf("InvalidPriority(%s)", v)
+		}
+		if len(tokens) > 2 {
+			sp.Bandwidth, err = strconv.Atoi(tokens[2])
+			if err != nil || sp.Bandwidth < 0 {
+				return nil, errors.IllegalArgumentError.Errorf("InvalidBandwidth(%s)", v)
+			}
+		}
+		if _, ok := policies[id]; ok {
+			return nil, errors.IllegalArgumentError.Errorf("DuplicateProtocol(%s)", v)
+		}
+		policies[id] = sp
+	}
+	return policies, nil
+}
+
+// SetSendPolicies sets send policies of protocols in the format of
+// ParseSendPolicies. The priority of the policy is used instead of the
+// priority of the reactor. Protocols without the policy have
+// DefaultSendPriority in the send queue and unlimited bandwidth.
+func SetSendPolicies(nm module.NetworkManager, s string) error {
+	m, err := managerOf(nm)
+	if err != nil {
+		return err
+	}
+	policies, err := ParseSendPolicies(s)
+	if err != nil {
+		return err
+	}
+	m.policyMtx.Lock()
+	defer m.policyMtx.Unlock()
+
+	m.sendPolicies = policies
+	for id := 0; id <= DefaultSendQueueMaxPriority; id++ {
+		sp, ok := policies[byte(id)]
+		if !ok {
+			sp = SendPolicy{Priority: DefaultSendPriority}
+		}
+		if err = m.p2p.sendQueue.SetPriority(id, sp.Priority); err != nil {
+			return err
+		}
+		if err = m.p2p.sendQueue.SetBandwidth(id, sp.Bandwidth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *manager) priorityOf(ph *protocolHandler) uint8 {
+	m.policyMtx.RLock()
+	defer m.policyMtx.RUnlock()
+
+	if sp, ok := m.sendPolicies[ph.protocol.ID()]; ok {
+		return uint8(sp.Priority)
+	}
+	return ph.getPriority()
+}
@@ -0,0 +1,62 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/module"
+)
+
+func TestParseSendPolicies(t *testing.T) {
+	policies, err := ParseSendPolicies("consensus:1, transaction:2,fastsync:3:1048576,0x05:1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[byte]SendPolicy{
+		module.ProtoConsensus.ID():     {Priority: 1},
+		module.ProtoTransaction.ID():   {Priority: 2},
+		module.ProtoFastSync.ID():      {Priority: 3, Bandwidth: 1048576},
+		module.ProtoConsensusSync.ID(): {Priority: 1},
+	}, policies)
+
+	policies, err = ParseSendPolicies("")
+	assert.NoError(t, err)
+	assert.Len(t, policies, 0)
+
+	for _, s := range []string{
+		"consensus",
+		"consensus:0",
+		"consensus:8",
+		"consensus:1:-1",
+		"consensus:1:2:3",
+		"unknown:1",
+		"0x10:1",
+		"consensus:1,consensus:2",
+	} {
+		_, err = ParseSendPolicies(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestSetSendPolicies_Unicast(t *testing.T) {
+	arr, _ := generateNetwork("TestUnicast", 8280, 1, t)
+	r := arr[0]
+	defer func() {
+		assert.NoError(t, r.nt.Close())
+		r.nm.Term()
+	}()
+	assert.NoError(t, SetSendPolicies(r.nm, "0x01:1:1000"))
+
+	id := NewPeerIDFromAddress(walletFromGeneratedPrivateKey().Address())
+	m := r.encode(&testNetworkRequest{Message: "Unicast"})
+
+	// errors of the peer are returned
+	err := r.ph.Unicast(ProtoTestNetworkRequest, m, id)
+	assert.True(t, ErrNotAvailable.Equals(err))
+	assert.False(t, err.(module.NetworkError).Temporary())
+
+	// out of budget, then it's temporary
+	r.p2p.sendQueue.Consume(int(ProtoTestNetwork.ID()), 1100)
+	err = r.ph.Unicast(ProtoTestNetworkRequest, m, id)
+	assert.True(t, ErrQueueOverflow.Equals(err))
+	assert.True(t, err.(module.NetworkError).Temporary())
+}
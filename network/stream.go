@@ -163,6 +163,10 @@ func (r *streamReactor) GetPeers() []module.PeerID {
 	return r.ph.GetPeers()
 }
 
+func (r *streamReactor) Report(id module.PeerID, rp module.PeerReport) {
+	r.ph.Report(id, rp)
+}
+
 func newStream(r *streamReactor, id module.PeerID) *stream {
 	return &stream{
 		r:  r,
@@ -2,6 +2,7 @@ package network
 
 import (
 	"encoding/hex"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -146,3 +147,34 @@ func Test_transport(t *testing.T) {
 	assert.NoError(t, nt2.Close(), "Transport2.Close fail")
 	time.Sleep(1 * time.Second)
 }
+
+func Test_transport_SecureSuites(t *testing.T) {
+	for i, ss := range []string{"tls13", "noise"} {
+		port := 8082 + i*2
+		t.Run(ss, func(t *testing.T) {
+			var wg sync.WaitGroup
+
+			nt1 := NewTransport(fmt.Sprintf(":%d", port), walletFromGeneratedPrivateKey(), log.New())
+			nt2 := NewTransport(fmt.Sprintf(":%d", port+1), walletFromGeneratedPrivateKey(), log.New())
+			assert.NoError(t, nt1.SetSecureSuites(testChannel, ss))
+			assert.NoError(t, nt2.SetSecureSuites(testChannel, "none,"+ss))
+
+			wg.Add(1)
+			tph1 := newTestPeerHandler("TestPeerHandler1", t, &wg, nt1.(*transport).logger)
+			tph2 := newTestPeerHandler("TestPeerHandler2", t, &wg, nt2.(*transport).logger)
+			nt1.(*transport).pd.registerPeerHandler(tph1, true)
+			nt2.(*transport).pd.registerPeerHandler(tph2, true)
+			nt1.(*transport).cn.addProtocol(testChannel, p2pProtoControl)
+			nt2.(*transport).cn.addProtocol(testChannel, p2pProtoControl)
+
+			assert.NoError(t, nt1.Listen(), "Transport1.Start fail")
+			assert.NoError(t, nt2.Listen(), "Transport2.Start fail")
+			assert.NoError(t, nt2.Dial(nt1.GetListenAddress(), testChannel), "Transport.Dial fail")
+
+			wg.Wait()
+
+			assert.NoError(t, nt1.Close(), "Transport1.Close fail")
+			assert.NoError(t, nt2.Close(), "Transport2.Close fail")
+		})
+	}
+}
@@ -111,9 +111,13 @@ type RuntimeConfig struct {
 	RPCDefaultChannel string `json:"rpcDefaultChannel"`
 	RPCIncludeDebug   bool   `json:"rpcIncludeDebug"`
 	RPCRosetta        bool   `json:"rpcRosetta"`
+	RPCEth            bool   `json:"rpcEth"`
+	RPCGraphQL        bool   `json:"rpcGraphQL"`
 	RPCBatchLimit     int    `json:"rpcBatchLimit"`
 	WSMaxSession      int    `json:"wsMaxSession"`
 
+	RPCRateLimit *server.RateLimitConfig `json:"rpcRateLimit,omitempty"`
+
 	FilePath string `json:"-"` // absolute path
 }
 
@@ -376,6 +376,13 @@ func (n *Node) JoinChain(
 		return nil, errors.Wrap(err, "fail to get NID for genesis")
 	}
 
+	if _, err := network.ParseTrustedPeers(p.TrustedPeers); err != nil {
+		return nil, err
+	}
+	if _, err := network.ParseSendPolicies(p.SendPolicies); err != nil {
+		return nil, err
+	}
+
 	channel := chain.GetChannel(p.Channel, nid)
 
 	if err := n._canAdd(cid, nid, channel, false); err != nil {
@@ -398,12 +405,17 @@ func (n *Node) JoinChain(
 		SecureSuites:     p.SecureSuites,
 		SecureAeads:      p.SecureAeads,
 		SeedAddr:         p.SeedAddr,
+		StaticPeers:      p.StaticPeers,
+		TrustedPeers:     p.TrustedPeers,
+		SendPolicies:     p.SendPolicies,
 		Role:             p.Role,
 		GenesisStorage:   genesisStorage,
 		ConcurrencyLevel: p.ConcurrencyLevel,
 		NormalTxPoolSize: p.NormalTxPoolSize,
 		PatchTxPoolSize:  p.PatchTxPoolSize,
 		MaxBlockTxBytes:  p.MaxBlockTxBytes,
+		MaxSenderTxs:     p.MaxSenderTxs,
+		MaxSenderTxBytes: p.MaxSenderTxBytes,
 		NodeCache:        p.NodeCache,
 		DefWaitTimeout:   p.DefWaitTimeout,
 		MaxWaitTimeout:   p.MaxWaitTimeout,
@@ -413,7 +425,11 @@ func (n *Node) JoinChain(
 		NIDForP2P:        n.cfg.NIDForP2P,
 		ChildrenLimit:    p.ChildrenLimit,
 		NephewsLimit:     p.NephewsLimit,
+		PeerBanDuration:  p.PeerBanDuration,
 		ValidateTxOnSend: p.ValidateTxOnSend,
+		AddressIndex:     p.AddressIndex,
+		Archive:          p.Archive,
+		ArchiveWindow:    p.ArchiveWindow,
 	}
 
 	if err := cfg.Save(); err != nil {
@@ -649,6 +665,21 @@ func (n *Node) ConfigureChain(cid int, key string, value string) error {
 		case "seedAddress":
 			c.cfg.SeedAddr = value
 			c.NetworkManager().SetTrustSeeds(c.cfg.SeedAddr)
+		case "staticPeers":
+			if err := network.SetStaticPeers(c.NetworkManager(), value); err != nil {
+				return err
+			}
+			c.cfg.StaticPeers = value
+		case "trustedPeers":
+			if err := network.SetTrustedPeers(c.NetworkManager(), value); err != nil {
+				return err
+			}
+			c.cfg.TrustedPeers = value
+		case "sendPolicies":
+			if err := network.SetSendPolicies(c.NetworkManager(), value); err != nil {
+				return err
+			}
+			c.cfg.SendPolicies = value
 		case "role":
 			if uintVal, err := strconv.ParseUint(value, 0, 32); err != nil {
 				return errors.Wrapf(err, "invalid value type")
@@ -694,6 +725,18 @@ func (n *Node) ConfigureChain(cid int, key string, value string) error {
 			} else {
 				c.cfg.MaxBlockTxBytes = intVal
 			}
+		case "maxSenderTxs":
+			if intVal, err := strconv.Atoi(value); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else {
+				c.cfg.MaxSenderTxs = intVal
+			}
+		case "maxSenderTxBytes":
+			if intVal, err := strconv.Atoi(value); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else {
+				c.cfg.MaxSenderTxBytes = intVal
+			}
 		case "nodeCache":
 			if !chain.IsNodeCacheOption(value) {
 				return errors.Errorf("InvalidNodeCacheOption(%s)", value)
@@ -737,6 +780,18 @@ func (n *Node) ConfigureChain(cid int, key string, value string) error {
 			c.cfg.SecureAeads = value
 		case "seedAddress":
 			c.cfg.SeedAddr = value
+		case "staticPeers":
+			c.cfg.StaticPeers = value
+		case "trustedPeers":
+			if _, err := network.ParseTrustedPeers(value); err != nil {
+				return err
+			}
+			c.cfg.TrustedPeers = value
+		case "sendPolicies":
+			if _, err := network.ParseSendPolicies(value); err != nil {
+				return err
+			}
+			c.cfg.SendPolicies = value
 		case "role":
 			if uintVal, err := strconv.ParseUint(value, 0, 32); err != nil {
 				return errors.Wrapf(err, "invalid value type")
@@ -761,12 +816,40 @@ func (n *Node) ConfigureChain(cid int, key string, value string) error {
 			} else {
 				c.cfg.NephewsLimit = &intVal
 			}
+		case "peerBanDuration":
+			if intVal, err := strconv.ParseInt(value, 0, 64); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else if intVal < 0 {
+				return errors.IllegalArgumentError.Errorf("InvalidPeerBanDuration(%d)", intVal)
+			} else {
+				c.cfg.PeerBanDuration = intVal
+			}
 		case "validateTxOnSend":
 			if bc, err := strconv.ParseBool(value); err != nil {
 				return errors.Wrapf(err, "InvalidValueType(exp=bool,val=%s)", value)
 			} else {
 				c.cfg.ValidateTxOnSend = bc
 			}
+		case "addressIndex":
+			if bc, err := strconv.ParseBool(value); err != nil {
+				return errors.Wrapf(err, "InvalidValueType(exp=bool,val=%s)", value)
+			} else {
+				c.cfg.AddressIndex = bc
+			}
+		case "archive":
+			if bc, err := strconv.ParseBool(value); err != nil {
+				return errors.Wrapf(err, "InvalidValueType(exp=bool,val=%s)", value)
+			} else {
+				c.cfg.Archive = bc
+			}
+		case "archiveWindow":
+			if intVal, err := strconv.ParseInt(value, 0, 64); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else if intVal < 0 {
+				return errors.IllegalArgumentError.Errorf("InvalidArchiveWindow(%d)", intVal)
+			} else {
+				c.cfg.ArchiveWindow = intVal
+			}
 		default:
 			return errors.Errorf("not found key %s", key)
 		}
@@ -879,6 +962,20 @@ func (n *Node) Configure(key string, value string) error {
 			n.rcfg.RPCRosetta = boolVal
 		}
 		n.srv.SetRosetta(n.rcfg.RPCRosetta)
+	case "rpcEth":
+		if boolVal, err := strconv.ParseBool(value); err != nil {
+			return errors.Wrapf(err, "invalid value type")
+		} else {
+			n.rcfg.RPCEth = boolVal
+		}
+		n.srv.SetEth(n.rcfg.RPCEth)
+	case "rpcGraphQL":
+		if boolVal, err := strconv.ParseBool(value); err != nil {
+			return errors.Wrapf(err, "invalid value type")
+		} else {
+			n.rcfg.RPCGraphQL = boolVal
+		}
+		n.srv.SetGraphQL(n.rcfg.RPCGraphQL)
 	case "rpcBatchLimit":
 		if intVal, err := strconv.Atoi(value); err != nil {
 			return errors.Wrapf(err, "invalid value type")
@@ -893,6 +990,15 @@ func (n *Node) Configure(key string, value string) error {
 			n.rcfg.WSMaxSession = intVal
 		}
 		n.srv.SetWSMaxSession(n.rcfg.WSMaxSession)
+	case "rpcRateLimit":
+		var rl *server.RateLimitConfig
+		if value != "" {
+			if err := json.Unmarshal([]byte(value), &rl); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			}
+		}
+		n.rcfg.RPCRateLimit = rl
+		n.srv.SetRateLimit(n.rcfg.RPCRateLimit)
 	default:
 		return errors.Errorf("not found key")
 	}
@@ -929,9 +1035,12 @@ func NewNode(
 		JSONRPCDump:           cfg.RPCDump,
 		JSONRPCIncludeDebug:   rcfg.RPCIncludeDebug,
 		JSONRPCRosetta:        rcfg.RPCRosetta,
+		JSONRPCEth:            rcfg.RPCEth,
+		GraphQL:               rcfg.RPCGraphQL,
 		JSONRPCDefaultChannel: rcfg.RPCDefaultChannel,
 		JSONRPCBatchLimit:     rcfg.RPCBatchLimit,
 		WSMaxSession:          rcfg.WSMaxSession,
+		RateLimit:             rcfg.RPCRateLimit,
 	}
 	srv := server.NewManager(config, w, l)
 
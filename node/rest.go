@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"strings"
 	"syscall"
 	"text/template"
 	"time"
@@ -20,10 +21,12 @@ import (
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/network"
 	"github.com/icon-project/goloop/server"
 	"github.com/icon-project/goloop/server/metric"
+	"github.com/icon-project/goloop/server/v3"
 	"github.com/icon-project/goloop/service"
 )
 
@@ -37,6 +40,8 @@ const (
 	ParamID     = "id"
 	UrlUserRes  = "/:" + ParamID
 	TaskID      = "task"
+	ParamTxHash = "txHash"
+	ParamPeerID = "peerID"
 
 	UrlDB    = "/db"
 	ParamBK  = "bucket"
@@ -79,6 +84,7 @@ type ChainInspectView struct {
 	*ChainView
 	GenesisTx json.RawMessage `json:"genesisTx"`
 	Config    *ChainConfig    `json:"config"`
+	Archive   *ArchiveView    `json:"archive,omitempty"`
 	// TODO [TBD] define structure each module for inspect
 	Module map[string]interface{} `json:"module"`
 }
@@ -87,11 +93,16 @@ type ChainConfig struct {
 	DBType           string `json:"dbType"`
 	Platform         string `json:"platform"`
 	SeedAddr         string `json:"seedAddress"`
+	StaticPeers      string `json:"staticPeers,omitempty"`
+	TrustedPeers     string `json:"trustedPeers,omitempty"`
+	SendPolicies     string `json:"sendPolicies,omitempty"`
 	Role             uint   `json:"role"`
 	ConcurrencyLevel int    `json:"concurrencyLevel,omitempty"`
 	NormalTxPoolSize int    `json:"normalTxPool,omitempty"`
 	PatchTxPoolSize  int    `json:"patchTxPool,omitempty"`
 	MaxBlockTxBytes  int    `json:"maxBlockTxBytes,omitempty"`
+	MaxSenderTxs     int    `json:"maxSenderTxs,omitempty"`
+	MaxSenderTxBytes int    `json:"maxSenderTxBytes,omitempty"`
 	NodeCache        string `json:"nodeCache,omitempty"`
 	Channel          string `json:"channel"`
 	SecureSuites     string `json:"secureSuites"`
@@ -102,7 +113,21 @@ type ChainConfig struct {
 	AutoStart        bool   `json:"autoStart"`
 	ChildrenLimit    *int   `json:"childrenLimit,omitempty"`
 	NephewsLimit     *int   `json:"nephewsLimit,omitempty"`
+	PeerBanDuration  int64  `json:"peerBanDuration,omitempty"`
 	ValidateTxOnSend bool   `json:"validateTxOnSend,omitempty"`
+	AddressIndex     bool   `json:"addressIndex,omitempty"`
+	Archive          bool   `json:"archive,omitempty"`
+	ArchiveWindow    int64  `json:"archiveWindow,omitempty"`
+}
+
+// ArchiveView shows states kept in archive mode. States of the blocks
+// from From to the last block are guaranteed to be kept, and DiskUsage is
+// the size of the database in bytes.
+type ArchiveView struct {
+	Window    int64 `json:"window"`
+	Base      int64 `json:"base"`
+	From      int64 `json:"from"`
+	DiskUsage int64 `json:"diskUsage"`
 }
 
 type ChainResetParam struct {
@@ -120,6 +145,10 @@ type ChainPruneParam struct {
 	Height int64  `json:"height"`
 }
 
+type ChainBanParam struct {
+	Duration int64 `json:"duration,omitempty"`
+}
+
 type ChainBackupParam struct {
 	Manual bool `json:"manual,omitempty"`
 }
@@ -161,19 +190,41 @@ func NewChainInspectView(c *Chain) *ChainInspectView {
 		GenesisTx: c.Genesis(),
 		Config:    NewChainConfig(c.cfg),
 	}
+	if c.cfg.Archive {
+		v.Archive = NewArchiveView(c, v.Height)
+	}
 	return v
 }
 
+func NewArchiveView(c *Chain, height int64) *ArchiveView {
+	base := c.GenesisStorage().Height()
+	usage, err := c.cfg.DiskUsage()
+	if err != nil {
+		log.Warnf("Fail to get disk usage cid=%#x err=%+v", c.CID(), err)
+	}
+	return &ArchiveView{
+		Window:    c.cfg.ArchiveWindow,
+		Base:      base,
+		From:      c.cfg.ArchiveFrom(base, height),
+		DiskUsage: usage,
+	}
+}
+
 func NewChainConfig(cfg *chain.Config) *ChainConfig {
 	v := &ChainConfig{
 		DBType:           cfg.DBType,
 		Platform:         cfg.Platform,
 		SeedAddr:         cfg.SeedAddr,
+		StaticPeers:      cfg.StaticPeers,
+		TrustedPeers:     cfg.TrustedPeers,
+		SendPolicies:     cfg.SendPolicies,
 		Role:             cfg.Role,
 		ConcurrencyLevel: cfg.ConcurrencyLevel,
 		NormalTxPoolSize: cfg.NormalTxPoolSize,
 		PatchTxPoolSize:  cfg.PatchTxPoolSize,
 		MaxBlockTxBytes:  cfg.MaxBlockTxBytes,
+		MaxSenderTxs:     cfg.MaxSenderTxs,
+		MaxSenderTxBytes: cfg.MaxSenderTxBytes,
 		NodeCache:        cfg.NodeCache,
 		Channel:          cfg.Channel,
 		SecureSuites:     cfg.SecureSuites,
@@ -184,7 +235,11 @@ func NewChainConfig(cfg *chain.Config) *ChainConfig {
 		AutoStart:        cfg.AutoStart,
 		ChildrenLimit:    cfg.ChildrenLimit,
 		NephewsLimit:     cfg.NephewsLimit,
+		PeerBanDuration:  cfg.PeerBanDuration,
 		ValidateTxOnSend: cfg.ValidateTxOnSend,
+		AddressIndex:     cfg.AddressIndex,
+		Archive:          cfg.Archive,
+		ArchiveWindow:    cfg.ArchiveWindow,
 	}
 	return v
 }
@@ -244,6 +299,13 @@ func (r *Rest) RegisterChainHandlers(g *echo.Group) {
 	g.GET(UrlChainRes+"/configure", r.GetChainConfig, r.ChainInjector)
 	g.POST(UrlChainRes+"/configure", r.ConfigureChain, r.ChainInjector)
 	g.POST(UrlChainRes+"/:"+TaskID, r.RunChainTask, r.ChainInjector)
+	g.GET(UrlChainRes+"/txpool", r.GetChainTxPoolStatus, r.ChainInjector)
+	g.GET(UrlChainRes+"/txpool/content", r.GetChainTxPoolContent, r.ChainInjector)
+	g.GET(UrlChainRes+"/txpool/:"+ParamTxHash, r.GetChainTxPoolTransaction, r.ChainInjector)
+	g.GET(UrlChainRes+"/peer", r.GetChainPeers, r.ChainInjector)
+	g.GET(UrlChainRes+"/addressbook", r.GetChainAddressBook, r.ChainInjector)
+	g.POST(UrlChainRes+"/peer/:"+ParamPeerID+"/ban", r.BanChainPeer, r.ChainInjector)
+	g.POST(UrlChainRes+"/peer/:"+ParamPeerID+"/unban", r.UnbanChainPeer, r.ChainInjector)
 }
 
 func (r *Rest) ChainInjector(next echo.HandlerFunc) echo.HandlerFunc {
@@ -468,6 +530,136 @@ func (r *Rest) RunChainTask(ctx echo.Context) error {
 	}
 }
 
+func (r *Rest) GetChainTxPoolStatus(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	sm := c.ServiceManager()
+	if sm == nil {
+		return ctx.String(http.StatusServiceUnavailable, "Stopped")
+	}
+	return ctx.JSON(http.StatusOK, v3.TransactionPoolStatusToJSON(sm))
+}
+
+func (r *Rest) GetChainTxPoolContent(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	sm := c.ServiceManager()
+	if sm == nil {
+		return ctx.String(http.StatusServiceUnavailable, "Stopped")
+	}
+	var from module.Address
+	if param := ctx.QueryParam("from"); param != "" {
+		addr, err := common.NewAddressFromString(param)
+		if err != nil {
+			return ctx.String(http.StatusBadRequest, "InvalidAddress(from:"+param+")")
+		}
+		from = addr
+	}
+	result, err := v3.TransactionPoolContentToJSON(sm, from)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (r *Rest) GetChainTxPoolTransaction(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	sm := c.ServiceManager()
+	if sm == nil {
+		return ctx.String(http.StatusServiceUnavailable, "Stopped")
+	}
+	param := ctx.Param(ParamTxHash)
+	id, err := hex.DecodeString(strings.TrimPrefix(param, "0x"))
+	if err != nil {
+		return ctx.String(http.StatusBadRequest, "InvalidHash(txHash:"+param+")")
+	}
+	ptx := sm.GetPendingTransaction(id)
+	if ptx == nil {
+		return ctx.String(http.StatusNotFound, "NotFound(txHash:"+param+")")
+	}
+	result, err := v3.PendingTransactionToJSON(ptx, time.Now(), true)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (r *Rest) GetChainPeers(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	nm := c.NetworkManager()
+	if nm == nil {
+		return ctx.String(http.StatusServiceUnavailable, "Stopped")
+	}
+	l, err := network.PeerReputations(nm)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, l)
+}
+
+func (r *Rest) GetChainAddressBook(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	nm := c.NetworkManager()
+	if nm == nil {
+		return ctx.String(http.StatusServiceUnavailable, "Stopped")
+	}
+	v, err := network.GetStaticPeers(nm)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, v)
+}
+
+func peerIDParam(ctx echo.Context) (module.PeerID, error) {
+	param := ctx.Param(ParamPeerID)
+	addr, err := common.NewAddressFromString(param)
+	if err != nil || addr.IsContract() {
+		return nil, ctx.String(http.StatusBadRequest, "InvalidPeerID(peerID:"+param+")")
+	}
+	return network.NewPeerIDFromAddress(addr), nil
+}
+
+func (r *Rest) BanChainPeer(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	nm := c.NetworkManager()
+	if nm == nil {
+		return ctx.String(http.StatusServiceUnavailable, "Stopped")
+	}
+	id, err := peerIDParam(ctx)
+	if id == nil {
+		return err
+	}
+	param := &ChainBanParam{}
+	if err := ctx.Bind(param); err != nil || param.Duration < 0 {
+		return echo.ErrBadRequest
+	}
+	d := time.Duration(param.Duration) * time.Second
+	if err := network.BanPeer(nm, id, d); err != nil {
+		if errors.IllegalArgumentError.Equals(err) {
+			return ctx.String(http.StatusBadRequest, err.Error())
+		}
+		return err
+	}
+	return ctx.String(http.StatusOK, "OK")
+}
+
+func (r *Rest) UnbanChainPeer(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	nm := c.NetworkManager()
+	if nm == nil {
+		return ctx.String(http.StatusServiceUnavailable, "Stopped")
+	}
+	id, err := peerIDParam(ctx)
+	if id == nil {
+		return err
+	}
+	if err := network.UnbanPeer(nm, id); err != nil {
+		if network.NotBannedPeerError.Equals(err) {
+			return ctx.String(http.StatusNotFound, "NotBanned(peerID:"+id.String()+")")
+		}
+		return err
+	}
+	return ctx.String(http.StatusOK, "OK")
+}
+
 func (r *Rest) RegisterSystemHandlers(g *echo.Group) {
 	g.GET("", r.GetSystem)
 	g.GET("/configure", r.GetSystemConfig)
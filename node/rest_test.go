@@ -0,0 +1,91 @@
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/chain"
+	"github.com/icon-project/goloop/module"
+)
+
+type testGenesisStorage struct {
+	module.GenesisStorage
+	height int64
+}
+
+func (gs *testGenesisStorage) Height() int64 {
+	return gs.height
+}
+
+type testChain struct {
+	module.Chain
+	gs *testGenesisStorage
+}
+
+func (c *testChain) GenesisStorage() module.GenesisStorage {
+	return c.gs
+}
+
+func TestNewArchiveView(t *testing.T) {
+	baseDir, err := ioutil.TempDir("", "goloop-archiveview")
+	assert.NoError(t, err)
+	defer os.RemoveAll(baseDir)
+	dbDir := path.Join(baseDir, chain.DefaultDBDir, "sub")
+	assert.NoError(t, os.MkdirAll(dbDir, 0700))
+	assert.NoError(t, ioutil.WriteFile(path.Join(dbDir, "a"), make([]byte, 100), 0600))
+	assert.NoError(t, ioutil.WriteFile(path.Join(dbDir, "b"), make([]byte, 20), 0600))
+
+	tests := []struct {
+		name   string
+		window int64
+		base   int64
+		height int64
+		from   int64
+	}{
+		{"WindowAll", 0, 0, 100, 0},
+		{"InChain", 10, 0, 100, 91},
+		{"InChainWithBase", 10, 30, 100, 91},
+		{"LargerThanChain", 200, 0, 100, 0},
+		{"LargerThanChainWithBase", 80, 30, 100, 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Chain{
+				Chain: &testChain{gs: &testGenesisStorage{height: tt.base}},
+				cfg: &chain.Config{
+					Archive:       true,
+					ArchiveWindow: tt.window,
+					BaseDir:       baseDir,
+				},
+			}
+			assert.Equal(t, &ArchiveView{
+				Window:    tt.window,
+				Base:      tt.base,
+				From:      tt.from,
+				DiskUsage: 120,
+			}, NewArchiveView(c, tt.height))
+		})
+	}
+}
+
+func TestNewArchiveView_NoDatabase(t *testing.T) {
+	baseDir, err := ioutil.TempDir("", "goloop-archiveview")
+	assert.NoError(t, err)
+	defer os.RemoveAll(baseDir)
+
+	c := &Chain{
+		Chain: &testChain{gs: &testGenesisStorage{}},
+		cfg: &chain.Config{
+			Archive:       true,
+			ArchiveWindow: 10,
+			BaseDir:       baseDir,
+		},
+	}
+	v := NewArchiveView(c, 5)
+	assert.EqualValues(t, 0, v.From)
+	assert.EqualValues(t, 0, v.DiskUsage)
+}
@@ -50,11 +50,13 @@ class Message(object):
     CLOSE = 11
     SETFEEPCT = 15
     CONTAINS = 16
+    STEPS = 17
 
 
 class InvokeFlag(object):
     READ_ONLY = 1
     TRACE = 2
+    STEP_PROFILE = 4
 
 
 class Log(object):
@@ -253,6 +255,8 @@ class ServiceManagerProxy:
         self.__codec = None
         self.__readonly_stack = []
         self.__readonly = False
+        self.__step_profile_stack = []
+        self.__step_profile = False
         self.__set_handlers: List[Tuple[SetHandler, int]] = []
 
     def connect(self, addr):
@@ -380,6 +384,8 @@ class ServiceManagerProxy:
         try:
             self.__readonly_stack.append(self.__readonly)
             self.__readonly = is_query
+            self.__step_profile_stack.append(self.__step_profile)
+            self.__step_profile = (option & InvokeFlag.STEP_PROFILE) != 0
             status, step_used, result = self.__invoke(
                 code, is_query, _from, _to, value, limit, method, params, info)
 
@@ -398,6 +404,7 @@ class ServiceManagerProxy:
             ])
         finally:
             self.__readonly = self.__readonly_stack.pop(-1)
+            self.__step_profile = self.__step_profile_stack.pop(-1)
 
     def __handle_get_api(self, data):
         try:
@@ -503,6 +510,10 @@ class ServiceManagerProxy:
             raise Exception('InvalidParameter')
         self.__client.send(Message.SETFEEPCT, pct)
 
+    def send_steps(self, step_type: str, size: int, steps: int):
+        if self.__step_profile:
+            self.__client.send(Message.STEPS, [step_type, size, self.encode(steps)])
+
     def contains(self, prefix: bytes, value: bytes, limit: int) -> Tuple[bool, int, int]:
         msg, ret = self.send_and_receive(Message.CONTAINS, [prefix, value, limit])
         if msg != Message.CONTAINS:
@@ -143,7 +143,8 @@ class PyExecEngine(object):
         context.msg = Message(sender=_from, value=value)
         context.owner = info.get(Info.CONTRACT_OWNER)
         context.step_counter = IconScoreStepCounter(info.get(Info.STEP_COSTS), limit,
-                                                    self.handle_set_values)
+                                                    self.handle_set_values,
+                                                    self.__proxy.send_steps)
         context.revision = info.get(Info.REVISION)
         if Logger.isDebugEnabled():
             Logger.debug(f'[Transaction] {context.tx}', TAG)
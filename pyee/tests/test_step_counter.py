@@ -60,6 +60,25 @@ class TestStepCounter(unittest.TestCase):
         for key, value in step_costs.items():
             self.assertEqual(value, self.step_costs_v1[key.value])
 
+    def test_report_steps_v1(self):
+        reports = []
+        step_counter = IconScoreStepCounter(self.step_costs_v1, self.step_limit,
+                                            self._dummy_refund_handler,
+                                            lambda *args: reports.append(args))
+        step_counter.apply_step(StepType.GET, 4)
+        step_counter.apply_step(StepType.SET, 8)
+        step_counter.refund_step(4)
+        step_counter.apply_step(StepType.LOG, 10)
+        step_counter.consume_step(StepType.API_CALL, 10000)
+        self.assertEqual([
+            ('get', 4, 2000 + 80 * 4),
+            ('set', 8, 5000 + 320 * 8),
+            ('set', -1, (5000 + 3000) // 2 - 5000 - 240 * 4),
+            ('eventLog', 10, 5000 + 200 * 10),
+            ('apiCall', -1, 10000),
+        ], reports)
+        self.assertEqual(sum(r[2] for r in reports), step_counter.step_used)
+
 
 if __name__ == '__main__':
     unittest.main()
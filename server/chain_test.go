@@ -0,0 +1,285 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	"github.com/icon-project/goloop/server/metric"
+	"github.com/icon-project/goloop/server/v3"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+const testEventSignature = "Transfer(Address,Address,int)"
+
+// testLog is the event log of testEventSignature.
+type testLog struct {
+	score string
+	from  string
+	to    string
+	value string
+}
+
+func (l testLog) addLogTo(t *testing.T, r txresult.Receipt) {
+	indexed := [][]byte{[]byte(testEventSignature)}
+	for _, v := range []string{l.from, l.to} {
+		bs, err := txresult.EventDataStringToBytesByType("Address", v)
+		assert.NoError(t, err)
+		indexed = append(indexed, bs)
+	}
+	data, err := txresult.EventDataStringToBytesByType("int", l.value)
+	assert.NoError(t, err)
+	r.AddLog(common.MustNewAddressFromString(l.score), indexed, [][]byte{data})
+}
+
+func newTestReceipt(t *testing.T, logs ...testLog) txresult.Receipt {
+	r := txresult.NewReceipt(db.NewMapDB(), module.LatestRevision,
+		common.MustNewAddressFromString("cx0000000000000000000000000000000000000001"))
+	for _, l := range logs {
+		l.addLogTo(t, r)
+	}
+	r.SetResult(module.StatusSuccess, new(big.Int), new(big.Int), nil)
+	return r
+}
+
+type testTransaction struct {
+	module.Transaction
+	id []byte
+}
+
+func (tx *testTransaction) ID() []byte {
+	return tx.id
+}
+
+type testTransactionList struct {
+	module.TransactionList
+	txs []module.Transaction
+}
+
+func (l *testTransactionList) Get(i int) (module.Transaction, error) {
+	if i < 0 || i >= len(l.txs) {
+		return nil, errors.NotFoundError.Errorf("NoTransaction(idx=%d)", i)
+	}
+	return l.txs[i], nil
+}
+
+type testBlock struct {
+	module.Block
+	height int64
+	id     []byte
+	lb     *txresult.LogsBloom
+	result []byte
+	txs    *testTransactionList
+}
+
+func (b *testBlock) Height() int64 {
+	return b.height
+}
+
+func (b *testBlock) ID() []byte {
+	return b.id
+}
+
+func (b *testBlock) LogsBloom() module.LogsBloom {
+	return b.lb
+}
+
+func (b *testBlock) Result() []byte {
+	return b.result
+}
+
+func (b *testBlock) NormalTransactions() module.TransactionList {
+	return b.txs
+}
+
+type testBlockManager struct {
+	module.BlockManager
+	mtx     sync.Mutex
+	blocks  []module.Block
+	waiters map[int64][]chan module.Block
+}
+
+func (bm *testBlockManager) GetBlockByHeight(height int64) (module.Block, error) {
+	bm.mtx.Lock()
+	defer bm.mtx.Unlock()
+
+	if height < 0 || height >= int64(len(bm.blocks)) {
+		return nil, errors.NotFoundError.Errorf("NoBlock(height=%d)", height)
+	}
+	return bm.blocks[height], nil
+}
+
+func (bm *testBlockManager) GetLastBlock() (module.Block, error) {
+	bm.mtx.Lock()
+	defer bm.mtx.Unlock()
+
+	return bm.blocks[len(bm.blocks)-1], nil
+}
+
+func (bm *testBlockManager) WaitForBlock(height int64) (<-chan module.Block, error) {
+	bm.mtx.Lock()
+	defer bm.mtx.Unlock()
+
+	ch := make(chan module.Block, 1)
+	if height < int64(len(bm.blocks)) {
+		ch <- bm.blocks[height]
+		return ch, nil
+	}
+	if bm.waiters == nil {
+		bm.waiters = make(map[int64][]chan module.Block)
+	}
+	bm.waiters[height] = append(bm.waiters[height], ch)
+	return ch, nil
+}
+
+func (bm *testBlockManager) addBlock(blk module.Block) {
+	bm.mtx.Lock()
+	defer bm.mtx.Unlock()
+
+	bm.blocks = append(bm.blocks, blk)
+	height := blk.Height()
+	for _, ch := range bm.waiters[height] {
+		ch <- blk
+	}
+	delete(bm.waiters, height)
+}
+
+type testServiceManager struct {
+	module.ServiceManager
+	mtx      sync.Mutex
+	receipts map[string]module.ReceiptList
+
+	// tracing the block at traceHangAt never ends until it's cancelled,
+	// and tracing the block at traceFailAt fails.
+	traceHangAt int64
+	traceFailAt int64
+	cancelled   chan int64
+}
+
+func (sm *testServiceManager) ReceiptListFromResult(result []byte, g module.TransactionGroup) (module.ReceiptList, error) {
+	sm.mtx.Lock()
+	defer sm.mtx.Unlock()
+
+	if rl, ok := sm.receipts[string(result)]; ok {
+		return rl, nil
+	}
+	return txresult.NewReceiptListFromSlice(db.NewMapDB(), nil), nil
+}
+
+type testGenesisStorage struct {
+	module.GenesisStorage
+	height int64
+}
+
+func (gs *testGenesisStorage) Height() int64 {
+	return gs.height
+}
+
+// testChain is the chain whose block at height h has the receipts of
+// the transactions in the block at height h-1.
+type testChain struct {
+	module.Chain
+	bm *testBlockManager
+	sm *testServiceManager
+	gs *testGenesisStorage
+}
+
+func newTestChain() *testChain {
+	return &testChain{
+		bm: &testBlockManager{},
+		sm: &testServiceManager{
+			receipts:    make(map[string]module.ReceiptList),
+			traceHangAt: -1,
+			traceFailAt: -1,
+			cancelled:   make(chan int64, 1),
+		},
+		gs: &testGenesisStorage{},
+	}
+}
+
+func (c *testChain) BlockManager() module.BlockManager {
+	return c.bm
+}
+
+func (c *testChain) ServiceManager() module.ServiceManager {
+	return c.sm
+}
+
+func (c *testChain) GenesisStorage() module.GenesisStorage {
+	return c.gs
+}
+
+func (c *testChain) MetricContext() context.Context {
+	return metric.DefaultMetricContext()
+}
+
+// addBlock adds the block with the transactions of the receipts, and
+// the receipts are in the result of the next block.
+func (c *testChain) addBlock(receipts ...txresult.Receipt) {
+	height := int64(len(c.bm.blocks))
+	blk := &testBlock{
+		height: height,
+		id:     []byte(fmt.Sprintf("block%d", height)),
+		lb:     txresult.NewLogsBloom(nil),
+		result: []byte(fmt.Sprintf("result%d", height)),
+		txs:    &testTransactionList{},
+	}
+	c.sm.mtx.Lock()
+	if rl, ok := c.sm.receipts[string(blk.result)]; ok {
+		for it := rl.Iterator(); it.Has(); it.Next() {
+			r, _ := it.Get()
+			blk.lb.Merge(r.LogsBloom())
+		}
+	}
+	c.sm.mtx.Unlock()
+	for i := range receipts {
+		blk.txs.txs = append(blk.txs.txs, &testTransaction{
+			id: []byte(fmt.Sprintf("tx%d.%d", height, i)),
+		})
+	}
+	if len(receipts) > 0 {
+		c.sm.mtx.Lock()
+		c.sm.receipts[fmt.Sprintf("result%d", height+1)] =
+			txresult.NewReceiptListFromSlice(db.NewMapDB(), receipts)
+		c.sm.mtx.Unlock()
+	}
+	c.bm.addBlock(blk)
+}
+
+// invokeMethod calls the method with the params, then it returns the
+// result or the error of the response.
+func invokeMethod(t *testing.T, chain module.Chain, method string, params string) (json.RawMessage, *jsonrpc.Error) {
+	reqJson := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"%s","params":%s}`, method, params)
+	e := echo.New()
+	e.Validator = jsonrpc.NewValidator()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(reqJson))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("includeDebug", false)
+	c.Set("raw", json.RawMessage(reqJson))
+	c.Set("chain", chain)
+	mr := v3.MethodRepository(metric.NewJsonrpcMetric(
+		metric.DefaultJsonrpcDurationsExpire, metric.DefaultJsonrpcDurationsSize, true))
+	assert.NoError(t, mr.Handle(c))
+
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *jsonrpc.Error  `json:"error"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp.Result, resp.Error
+}
@@ -0,0 +1,600 @@
+package eth
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	"github.com/icon-project/goloop/server/metric"
+	"github.com/icon-project/goloop/server/v3"
+)
+
+// ClientVersion is returned by web3_clientVersion.
+var ClientVersion = "goloop/unknown"
+
+// emptyUncleHash is the hash of the empty list of uncles.
+const emptyUncleHash = "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347"
+
+var zeroHash = HashOf(make([]byte, 32))
+
+func MethodRepository(mtr *metric.JsonrpcMetric) *jsonrpc.MethodRepository {
+	mr := jsonrpc.NewMethodRepository(mtr)
+
+	mr.RegisterMethod("web3_clientVersion", clientVersion)
+	mr.RegisterMethod("web3_sha3", web3Sha3)
+	mr.RegisterMethod("net_version", netVersion)
+	mr.RegisterMethod("net_listening", netListening)
+
+	mr.RegisterMethod("eth_chainId", chainId)
+	mr.RegisterMethod("eth_blockNumber", blockNumber)
+	mr.RegisterMethod("eth_getBlockByNumber", getBlockByNumber)
+	mr.RegisterMethod("eth_getBalance", getBalance)
+	mr.RegisterMethod("eth_getTransactionReceipt", getTransactionReceipt)
+	mr.RegisterMethod("eth_getLogs", getLogs)
+	return mr
+}
+
+// convertParams converts positional parameters into args. Parameters
+// after required ones are optional.
+func convertParams(params *jsonrpc.Params, required int, args ...interface{}) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(params.RawMessage(), &raws); err != nil {
+		return errors.IllegalArgumentError.Wrap(err, "params must be array")
+	}
+	if len(raws) < required || len(raws) > len(args) {
+		return errors.IllegalArgumentError.Errorf(
+			"InvalidNumberOfParams(params=%d,required=%d)", len(raws), required)
+	}
+	for i, raw := range raws {
+		if err := json.Unmarshal(raw, args[i]); err != nil {
+			return errors.IllegalArgumentError.Wrapf(err, "InvalidParam(index=%d)", i)
+		}
+	}
+	return nil
+}
+
+func chainOf(ctx *jsonrpc.Context, debug bool) (module.Chain, error) {
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+	if chain.BlockManager() == nil || chain.ServiceManager() == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+	return chain, nil
+}
+
+// heightRange returns the earliest height and the latest height. Results of
+// transactions in a block are in the next block, so the latest block is the
+// previous block of the last block.
+func heightRange(chain module.Chain, debug bool) (int64, int64, error) {
+	last, err := chain.BlockManager().GetLastBlock()
+	if err != nil {
+		return 0, 0, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	earliest := chain.GenesisStorage().Height()
+	latest := last.Height() - 1
+	if latest < earliest {
+		return 0, 0, jsonrpc.ErrorCodeExecuting.New("NoFinalizedBlock")
+	}
+	return earliest, latest, nil
+}
+
+// blockAndResultOf returns the block and the next block having the result
+// of the transactions in the block.
+func blockAndResultOf(bm module.BlockManager, height int64) (module.Block, module.Block, error) {
+	blk, err := bm.GetBlockByHeight(height)
+	if err != nil {
+		return nil, nil, err
+	}
+	rblk, err := bm.GetBlockByHeight(height + 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	return blk, rblk, nil
+}
+
+// bloomOf returns logs bloom. Logs bloom of ICON is not compatible, so it
+// returns the bloom matching everything if there are any event logs.
+func bloomOf(lb module.LogsBloom) string {
+	if len(lb.Bytes()) == 0 {
+		return "0x" + strings.Repeat("00", 256)
+	}
+	return "0x" + strings.Repeat("ff", 256)
+}
+
+func clientVersion(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+	if err := convertParams(params, 0); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	return ClientVersion, nil
+}
+
+func web3Sha3(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+	var data common.HexBytes
+	if err := convertParams(params, 1, &data); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	return HashOf(keccak256(data)), nil
+}
+
+func netVersion(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+	if err := convertParams(params, 0); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+	return strconv.Itoa(chain.NID()), nil
+}
+
+func netListening(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+	if err := convertParams(params, 0); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+	return chain.NetworkManager() != nil, nil
+}
+
+func chainId(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+	if err := convertParams(params, 0); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+	return intconv.FormatInt(int64(chain.NID())), nil
+}
+
+func blockNumber(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+	if err := convertParams(params, 0); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	chain, err := chainOf(ctx, debug)
+	if err != nil {
+		return nil, err
+	}
+	_, latest, err := heightRange(chain, debug)
+	if err != nil {
+		return nil, err
+	}
+	return intconv.FormatInt(latest), nil
+}
+
+// txFields has fields of the transaction used for Ethereum transaction.
+type txFields struct {
+	To        *common.Address `json:"to"`
+	Value     *common.HexInt  `json:"value"`
+	StepLimit *common.HexInt  `json:"stepLimit"`
+	Nonce     json.RawMessage `json:"nonce"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func fieldsOf(tx module.Transaction) (*txFields, error) {
+	jso, err := tx.ToJSON(module.JSONVersion3)
+	if err != nil {
+		return nil, err
+	}
+	js, err := json.Marshal(jso)
+	if err != nil {
+		return nil, err
+	}
+	fields := new(txFields)
+	if err := json.Unmarshal(js, fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func transactionToJSON(tx module.Transaction, blk module.Block, index int, rct module.Receipt) (map[string]interface{}, error) {
+	fields, err := fieldsOf(tx)
+	if err != nil {
+		return nil, err
+	}
+	jso := map[string]interface{}{
+		"hash":             HashOf(tx.ID()),
+		"nonce":            "0x0",
+		"blockHash":        HashOf(blk.ID()),
+		"blockNumber":      intconv.FormatInt(blk.Height()),
+		"transactionIndex": intconv.FormatInt(int64(index)),
+		"from":             AddressOf(tx.From()),
+		"to":               nil,
+		"value":            "0x0",
+		"gas":              "0x0",
+		"gasPrice":         "0x0",
+		"input":            "0x",
+		"type":             "0x0",
+	}
+	if fields.To != nil {
+		jso["to"] = AddressOf(fields.To)
+	}
+	if fields.Value != nil {
+		jso["value"] = fields.Value.String()
+	}
+	if fields.StepLimit != nil {
+		jso["gas"] = fields.StepLimit.String()
+	}
+	var nonce common.HexInt
+	if len(fields.Nonce) > 0 && nonce.UnmarshalJSON(fields.Nonce) == nil && nonce.Sign() >= 0 {
+		jso["nonce"] = nonce.String()
+	}
+	if len(fields.Data) > 0 {
+		jso["input"] = HashOf(fields.Data)
+	}
+	if rct != nil {
+		jso["gasPrice"] = intconv.FormatBigInt(rct.StepPrice())
+	}
+	return jso, nil
+}
+
+func getBlockByNumber(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var number BlockNumber
+	var full bool
+	if err := convertParams(params, 1, &number, &full); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	chain, err := chainOf(ctx, debug)
+	if err != nil {
+		return nil, err
+	}
+	earliest, latest, err := heightRange(chain, debug)
+	if err != nil {
+		return nil, err
+	}
+	height := number.Resolve(earliest, latest)
+	if height < earliest || height > latest {
+		return nil, nil
+	}
+
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	blk, rblk, err := blockAndResultOf(bm, height)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	rl, err := sm.ReceiptListFromResult(rblk.Result(), module.TransactionGroupNormal)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+
+	gasUsed := new(big.Int)
+	txs := make([]interface{}, 0)
+	for it := blk.NormalTransactions().Iterator(); it.Has(); it.Next() {
+		tx, index, err := it.Get()
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		rct, err := rl.Get(index)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		gasUsed.Set(rct.CumulativeStepUsed())
+		if full {
+			txJSON, err := transactionToJSON(tx, blk, index, rct)
+			if err != nil {
+				return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+			}
+			txs = append(txs, txJSON)
+		} else {
+			txs = append(txs, HashOf(tx.ID()))
+		}
+	}
+
+	txRoot := zeroHash
+	if h := blk.NormalTransactions().Hash(); len(h) > 0 {
+		txRoot = HashOf(h)
+	}
+	parentHash := zeroHash
+	if len(blk.PrevID()) > 0 {
+		parentHash = HashOf(blk.PrevID())
+	}
+	return map[string]interface{}{
+		"number":           intconv.FormatInt(blk.Height()),
+		"hash":             HashOf(blk.ID()),
+		"parentHash":       parentHash,
+		"nonce":            "0x0000000000000000",
+		"sha3Uncles":       emptyUncleHash,
+		"logsBloom":        bloomOf(rblk.LogsBloom()),
+		"transactionsRoot": txRoot,
+		"stateRoot":        zeroHash,
+		"receiptsRoot":     zeroHash,
+		"miner":            AddressOf(blk.Proposer()),
+		"difficulty":       "0x0",
+		"totalDifficulty":  "0x0",
+		"extraData":        "0x",
+		"size":             "0x0",
+		"gasLimit":         "0x0",
+		"gasUsed":          intconv.FormatBigInt(gasUsed),
+		"timestamp":        intconv.FormatInt(blk.Timestamp() / 1000000),
+		"transactions":     txs,
+		"uncles":           []interface{}{},
+	}, nil
+}
+
+func getBalance(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var addr Address
+	var number BlockNumber
+	if err := convertParams(params, 1, &addr, &number); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	chain, err := chainOf(ctx, debug)
+	if err != nil {
+		return nil, err
+	}
+	earliest, latest, err := heightRange(chain, debug)
+	if err != nil {
+		return nil, err
+	}
+	height := number.Resolve(earliest, latest)
+	if height > latest {
+		return nil, jsonrpc.ErrorCodeNotFound.Errorf(
+			"NoResult(height=%d,latest=%d)", height, latest)
+	}
+	if err := v3.CheckBaseHeight(chain, height); err != nil {
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	}
+
+	_, rblk, err := blockAndResultOf(chain.BlockManager(), height)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	balance, err := chain.ServiceManager().GetBalance(rblk.Result(), &addr.Address)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	return intconv.FormatBigInt(balance), nil
+}
+
+// logsOfReceipt returns JSON of event logs of the receipt matching with
+// the filter. Index of the first log in the block is logIndex.
+func logsOfReceipt(
+	rct module.Receipt, filter *FilterParam, info LogInfo,
+) ([]interface{}, int, error) {
+	var logs []interface{}
+	for it := rct.EventLogIterator(); it.Has(); it.Next() {
+		el, err := it.Get()
+		if err != nil {
+			return nil, 0, err
+		}
+		topics := topicsOf(el.Indexed())
+		if filter == nil || (filter.matchAddress(el.Address()) && filter.matchTopics(topics)) {
+			li := info
+			logs = append(logs, logToJSON(el, topics, &li))
+		}
+		info.LogIndex++
+	}
+	return logs, info.LogIndex, nil
+}
+
+func countOfLogs(rct module.Receipt) int {
+	count := 0
+	for it := rct.EventLogIterator(); it.Has(); it.Next() {
+		count++
+	}
+	return count
+}
+
+func getTransactionReceipt(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var hash common.HexBytes
+	if err := convertParams(params, 1, &hash); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	chain, err := chainOf(ctx, debug)
+	if err != nil {
+		return nil, err
+	}
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+
+	// unknown or pending transaction doesn't have receipt
+	txInfo, err := bm.GetTransactionInfo(hash)
+	if errors.NotFoundError.Equals(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	if txInfo.Group() != module.TransactionGroupNormal {
+		return nil, nil
+	}
+	blk := txInfo.Block()
+	if blk.Height() < chain.GenesisStorage().Height() {
+		return nil, nil
+	}
+	rblk, err := bm.GetBlockByHeight(blk.Height() + 1)
+	if errors.NotFoundError.Equals(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	tx, err := txInfo.Transaction()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	rl, err := sm.ReceiptListFromResult(rblk.Result(), module.TransactionGroupNormal)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+
+	// index of logs is in the block
+	logIndex := 0
+	for i := 0; i < txInfo.Index(); i++ {
+		rct, err := rl.Get(i)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		logIndex += countOfLogs(rct)
+	}
+	rct, err := rl.Get(txInfo.Index())
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	logs, _, err := logsOfReceipt(rct, nil, LogInfo{
+		BlockHash:   blk.ID(),
+		BlockHeight: blk.Height(),
+		TxHash:      tx.ID(),
+		TxIndex:     txInfo.Index(),
+		LogIndex:    logIndex,
+	})
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	if logs == nil {
+		logs = []interface{}{}
+	}
+
+	status := "0x0"
+	if rct.Status() == module.StatusSuccess {
+		status = "0x1"
+	}
+	result := map[string]interface{}{
+		"transactionHash":   HashOf(tx.ID()),
+		"transactionIndex":  intconv.FormatInt(int64(txInfo.Index())),
+		"blockHash":         HashOf(blk.ID()),
+		"blockNumber":       intconv.FormatInt(blk.Height()),
+		"from":              AddressOf(tx.From()),
+		"to":                nil,
+		"cumulativeGasUsed": intconv.FormatBigInt(rct.CumulativeStepUsed()),
+		"gasUsed":           intconv.FormatBigInt(rct.StepUsed()),
+		"effectiveGasPrice": intconv.FormatBigInt(rct.StepPrice()),
+		"contractAddress":   nil,
+		"logs":              logs,
+		"logsBloom":         bloomOf(rct.LogsBloom()),
+		"status":            status,
+		"type":              "0x0",
+	}
+	if to := rct.To(); to != nil {
+		result["to"] = AddressOf(to)
+	}
+	if score := rct.SCOREAddress(); score != nil {
+		result["contractAddress"] = AddressOf(score)
+	}
+	return result, nil
+}
+
+// getLogs returns event logs of normal transactions matching the filter.
+// Range of blocks is limited by v3.LogsMaxRange, and it fails if the number
+// of logs exceeds v3.LogsMaxLimit.
+func getLogs(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var param FilterParam
+	if err := convertParams(params, 1, &param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	if err := param.compile(); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	chain, err := chainOf(ctx, debug)
+	if err != nil {
+		return nil, err
+	}
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	earliest, latest, err := heightRange(chain, debug)
+	if err != nil {
+		return nil, err
+	}
+
+	var from, to int64
+	if param.BlockHash != nil {
+		if param.FromBlock != nil || param.ToBlock != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.New(
+				"blockHash can't be used with fromBlock or toBlock")
+		}
+		id, err := hex.DecodeString(strings.TrimPrefix(*param.BlockHash, "0x"))
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		}
+		blk, err := bm.GetBlock(id)
+		if errors.NotFoundError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+		} else if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		from, to = blk.Height(), blk.Height()
+	} else {
+		from = param.FromBlock.Resolve(earliest, latest)
+		to = param.ToBlock.Resolve(earliest, latest)
+	}
+	if err := v3.CheckBaseHeight(chain, from); err != nil {
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	}
+	if to > latest {
+		to = latest
+	}
+	if to-from+1 > v3.LogsMaxRange {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Errorf(
+			"TooManyBlocks(fromBlock=%d,toBlock=%d,limit=%d)", from, to, v3.LogsMaxRange)
+	}
+
+	logs := make([]interface{}, 0)
+	for h := from; h <= to; h++ {
+		blk, rblk, err := blockAndResultOf(bm, h)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		if len(rblk.LogsBloom().Bytes()) == 0 {
+			continue
+		}
+		rl, err := sm.ReceiptListFromResult(rblk.Result(), module.TransactionGroupNormal)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		logIndex := 0
+		for it := blk.NormalTransactions().Iterator(); it.Has(); it.Next() {
+			tx, index, err := it.Get()
+			if err != nil {
+				return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+			}
+			rct, err := rl.Get(index)
+			if err != nil {
+				return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+			}
+			var txLogs []interface{}
+			txLogs, logIndex, err = logsOfReceipt(rct, &param, LogInfo{
+				BlockHash:   blk.ID(),
+				BlockHeight: h,
+				TxHash:      tx.ID(),
+				TxIndex:     index,
+				LogIndex:    logIndex,
+			})
+			if err != nil {
+				return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+			}
+			logs = append(logs, txLogs...)
+			if len(logs) > v3.LogsMaxLimit {
+				return nil, jsonrpc.ErrorCodeInvalidRequest.Errorf(
+					"TooManyLogs(fromBlock=%d,toBlock=%d,limit=%d)", from, to, v3.LogsMaxLimit)
+			}
+		}
+	}
+	return logs, nil
+}
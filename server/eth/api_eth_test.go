@@ -0,0 +1,206 @@
+package eth
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	"github.com/icon-project/goloop/server/metric"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+type testBlock struct {
+	module.Block
+	height int64
+}
+
+func (b *testBlock) Height() int64 {
+	return b.height
+}
+
+func (b *testBlock) ID() []byte {
+	return []byte(fmt.Sprintf("block%d", b.height))
+}
+
+func (b *testBlock) Result() []byte {
+	return nil
+}
+
+func (b *testBlock) LogsBloom() module.LogsBloom {
+	return txresult.NewLogsBloom(nil)
+}
+
+type testBlockManager struct {
+	module.BlockManager
+	last int64
+}
+
+func (bm *testBlockManager) GetBlockByHeight(height int64) (module.Block, error) {
+	if height < 0 || height > bm.last {
+		return nil, errors.NotFoundError.Errorf("NoBlock(height=%d)", height)
+	}
+	return &testBlock{height: height}, nil
+}
+
+func (bm *testBlockManager) GetBlock(id []byte) (module.Block, error) {
+	var height int64
+	if _, err := fmt.Sscanf(string(id), "block%d", &height); err != nil {
+		return nil, errors.NotFoundError.Errorf("NoBlock(id=%x)", id)
+	}
+	return bm.GetBlockByHeight(height)
+}
+
+func (bm *testBlockManager) GetLastBlock() (module.Block, error) {
+	return &testBlock{height: bm.last}, nil
+}
+
+type testServiceManager struct {
+	module.ServiceManager
+}
+
+func (sm *testServiceManager) GetBalance(result []byte, addr module.Address) (*big.Int, error) {
+	return big.NewInt(100), nil
+}
+
+type testGenesisStorage struct {
+	module.GenesisStorage
+	height int64
+}
+
+func (gs *testGenesisStorage) Height() int64 {
+	return gs.height
+}
+
+// testChain has blocks up to the last height, and states of blocks from
+// the base height.
+type testChain struct {
+	module.Chain
+	bm *testBlockManager
+	sm *testServiceManager
+	gs *testGenesisStorage
+}
+
+func newTestChain(base, last int64) *testChain {
+	return &testChain{
+		bm: &testBlockManager{last: last},
+		sm: &testServiceManager{},
+		gs: &testGenesisStorage{height: base},
+	}
+}
+
+func (c *testChain) BlockManager() module.BlockManager {
+	return c.bm
+}
+
+func (c *testChain) ServiceManager() module.ServiceManager {
+	return c.sm
+}
+
+func (c *testChain) GenesisStorage() module.GenesisStorage {
+	return c.gs
+}
+
+func (c *testChain) MetricContext() context.Context {
+	return metric.DefaultMetricContext()
+}
+
+// invokeMethod calls the method with the params, then it returns the
+// result or the error of the response.
+func invokeMethod(t *testing.T, chain module.Chain, method string, params string) (json.RawMessage, *jsonrpc.Error) {
+	reqJson := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"%s","params":%s}`, method, params)
+	e := echo.New()
+	e.Validator = jsonrpc.NewValidator()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(reqJson))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("includeDebug", false)
+	c.Set("raw", json.RawMessage(reqJson))
+	c.Set("chain", chain)
+	mr := MethodRepository(metric.NewJsonrpcMetric(
+		metric.DefaultJsonrpcDurationsExpire, metric.DefaultJsonrpcDurationsSize, true))
+	assert.NoError(t, mr.Handle(c))
+
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *jsonrpc.Error  `json:"error"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp.Result, resp.Error
+}
+
+func TestGetBalance_BaseHeight(t *testing.T) {
+	c := newTestChain(3, 10)
+	addr := `"0x0000000000000000000000000000000000000001"`
+
+	tests := []struct {
+		name   string
+		number string
+		code   jsonrpc.ErrorCode
+	}{
+		{"Latest", `"latest"`, 0},
+		{"Earliest", `"earliest"`, 0},
+		{"Base", `"0x3"`, 0},
+		{"Pruned", `"0x2"`, jsonrpc.ErrorCodeNotFound},
+		{"NotFinalized", `"0xa"`, jsonrpc.ErrorCodeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, jerr := invokeMethod(t, c, "eth_getBalance",
+				fmt.Sprintf(`[%s,%s]`, addr, tt.number))
+			if tt.code != 0 {
+				if assert.NotNil(t, jerr) {
+					assert.Equal(t, tt.code, jerr.Code, jerr.Message)
+				}
+				return
+			}
+			if assert.Nil(t, jerr) {
+				assert.Equal(t, `"0x64"`, string(res))
+			}
+		})
+	}
+}
+
+func TestGetLogs_BaseHeight(t *testing.T) {
+	c := newTestChain(3, 10)
+	hashOf := func(height int64) string {
+		return "0x" + hex.EncodeToString([]byte(fmt.Sprintf("block%d", height)))
+	}
+
+	tests := []struct {
+		name   string
+		filter string
+		code   jsonrpc.ErrorCode
+	}{
+		{"Range", `{"fromBlock":"0x3","toBlock":"0x5"}`, 0},
+		{"Earliest", `{"fromBlock":"earliest"}`, 0},
+		{"Block", fmt.Sprintf(`{"blockHash":"%s"}`, hashOf(3)), 0},
+		{"PrunedRange", `{"fromBlock":"0x2","toBlock":"0x5"}`, jsonrpc.ErrorCodeNotFound},
+		{"PrunedBlock", fmt.Sprintf(`{"blockHash":"%s"}`, hashOf(2)), jsonrpc.ErrorCodeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, jerr := invokeMethod(t, c, "eth_getLogs", fmt.Sprintf(`[%s]`, tt.filter))
+			if tt.code != 0 {
+				if assert.NotNil(t, jerr) {
+					assert.Equal(t, tt.code, jerr.Code, jerr.Message)
+				}
+				return
+			}
+			if assert.Nil(t, jerr) {
+				assert.Equal(t, `[]`, string(res))
+			}
+		})
+	}
+}
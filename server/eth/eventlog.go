@@ -0,0 +1,166 @@
+package eth
+
+import (
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+)
+
+const wordSize = 32
+
+var wordModulus = new(big.Int).Lsh(big.NewInt(1), wordSize*8)
+
+const (
+	typeInt     = "int"
+	typeBool    = "bool"
+	typeAddress = "Address"
+	typeStr     = "str"
+	typeBytes   = "bytes"
+)
+
+func keccak256(data ...[]byte) []byte {
+	s := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		s.Write(d)
+	}
+	return s.Sum(nil)
+}
+
+// parseSignature returns types of parameters of the event signature like
+// "Transfer(Address,Address,int,bytes)".
+func parseSignature(sig string) ([]string, bool) {
+	open := strings.IndexByte(sig, '(')
+	if open <= 0 || !strings.HasSuffix(sig, ")") {
+		return nil, false
+	}
+	params := sig[open+1 : len(sig)-1]
+	if params == "" {
+		return []string{}, true
+	}
+	return strings.Split(params, ","), true
+}
+
+func isStaticType(t string) bool {
+	return t == typeInt || t == typeBool || t == typeAddress
+}
+
+// wordOf returns 32 bytes word for the value of the static type. Integers
+// are sign extended and truncated to lower 256 bits, and addresses are
+// mapped to their IDs.
+func wordOf(t string, v []byte) []byte {
+	word := make([]byte, wordSize)
+	if v == nil {
+		return word
+	}
+	switch t {
+	case typeAddress:
+		if addr, err := common.NewAddress(v); err == nil {
+			copy(word[wordSize-common.AddressIDBytes:], addr.ID())
+			return word
+		}
+	case typeInt, typeBool:
+		i := intconv.BigIntSetBytes(new(big.Int), v)
+		bs := i.Mod(i, wordModulus).Bytes()
+		copy(word[wordSize-len(bs):], bs)
+		return word
+	}
+	if len(v) > wordSize {
+		v = v[len(v)-wordSize:]
+	}
+	copy(word[wordSize-len(v):], v)
+	return word
+}
+
+// topicsOf returns topics of the event log. The first topic is Keccak-256
+// hash of the signature. Indexed values of static types are converted to
+// words, and others are converted to Keccak-256 hash of them.
+func topicsOf(indexed [][]byte) [][]byte {
+	if len(indexed) == 0 {
+		return [][]byte{}
+	}
+	types, ok := parseSignature(string(indexed[0]))
+	topics := make([][]byte, len(indexed))
+	topics[0] = keccak256(indexed[0])
+	for i, v := range indexed[1:] {
+		t := typeBytes
+		if ok && i < len(types) {
+			t = types[i]
+		}
+		if isStaticType(t) {
+			topics[i+1] = wordOf(t, v)
+		} else if v == nil {
+			topics[i+1] = make([]byte, wordSize)
+		} else {
+			topics[i+1] = keccak256(v)
+		}
+	}
+	return topics
+}
+
+func padRight(v []byte) []byte {
+	n := (len(v) + wordSize - 1) / wordSize * wordSize
+	bs := make([]byte, n)
+	copy(bs, v)
+	return bs
+}
+
+// dataOf returns ABI encoded data of the event log. Types of values
+// are the rest of parameters of the signature after indexed ones. Values
+// of unknown types are encoded as bytes.
+func dataOf(indexed [][]byte, data [][]byte) []byte {
+	var types []string
+	if len(indexed) > 0 {
+		if ts, ok := parseSignature(string(indexed[0])); ok && len(ts) >= len(indexed)-1 {
+			types = ts[len(indexed)-1:]
+		}
+	}
+	head := make([]byte, 0, len(data)*wordSize)
+	var tail []byte
+	for i, v := range data {
+		t := typeBytes
+		if i < len(types) {
+			t = types[i]
+		}
+		if isStaticType(t) {
+			head = append(head, wordOf(t, v)...)
+			continue
+		}
+		offset := len(data)*wordSize + len(tail)
+		head = append(head, wordOf(typeInt, intconv.Int64ToBytes(int64(offset)))...)
+		tail = append(tail, wordOf(typeInt, intconv.Int64ToBytes(int64(len(v))))...)
+		tail = append(tail, padRight(v)...)
+	}
+	return append(head, tail...)
+}
+
+// LogInfo is the position of the event log.
+type LogInfo struct {
+	BlockHash   []byte
+	BlockHeight int64
+	TxHash      []byte
+	TxIndex     int
+	LogIndex    int
+}
+
+func logToJSON(el module.EventLog, topics [][]byte, info *LogInfo) map[string]interface{} {
+	topicsJSON := make([]string, len(topics))
+	for i, t := range topics {
+		topicsJSON[i] = HashOf(t)
+	}
+	return map[string]interface{}{
+		"address":          AddressOf(el.Address()),
+		"topics":           topicsJSON,
+		"data":             HashOf(dataOf(el.Indexed(), el.Data())),
+		"blockNumber":      intconv.FormatInt(info.BlockHeight),
+		"blockHash":        HashOf(info.BlockHash),
+		"transactionHash":  HashOf(info.TxHash),
+		"transactionIndex": intconv.FormatInt(int64(info.TxIndex)),
+		"logIndex":         intconv.FormatInt(int64(info.LogIndex)),
+		"removed":          false,
+	}
+}
@@ -0,0 +1,101 @@
+package eth
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/intconv"
+)
+
+func wordOfHex(s string) []byte {
+	bs, _ := hex.DecodeString(strings.Repeat("0", 64-len(s)) + s)
+	return bs
+}
+
+func TestKeccak256(t *testing.T) {
+	assert.Equal(t,
+		"c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		hex.EncodeToString(keccak256([]byte{})))
+}
+
+func TestTopicsOf(t *testing.T) {
+	from := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+	to := common.MustNewAddressFromString("cx0000000000000000000000000000000000000002")
+	sig := "Transfer(Address,Address,int,bytes)"
+
+	topics := topicsOf([][]byte{
+		[]byte(sig),
+		from.Bytes(),
+		to.Bytes(),
+		intconv.BigIntToBytes(big.NewInt(-1)),
+		[]byte("data"),
+	})
+	assert.Equal(t, 5, len(topics))
+	assert.Equal(t, keccak256([]byte(sig)), topics[0])
+	assert.Equal(t, wordOfHex("01"), topics[1])
+	assert.Equal(t, wordOfHex("02"), topics[2])
+	assert.Equal(t, wordOfHex(strings.Repeat("f", 64)), topics[3])
+	assert.Equal(t, keccak256([]byte("data")), topics[4])
+
+	// unknown signature and null value
+	topics = topicsOf([][]byte{[]byte("Unknown"), nil, []byte{1}})
+	assert.Equal(t, make([]byte, wordSize), topics[1])
+	assert.Equal(t, keccak256([]byte{1}), topics[2])
+}
+
+func TestDataOf(t *testing.T) {
+	sig := []byte("Message(Address,str,int,bytes)")
+	addr := common.MustNewAddressFromString("hx0000000000000000000000000000000000000003")
+	data := dataOf(
+		[][]byte{sig, addr.Bytes()},
+		[][]byte{[]byte("hello"), intconv.Int64ToBytes(0x100), nil},
+	)
+	expected := strings.Join([]string{
+		// head
+		hex.EncodeToString(wordOfHex("60")),
+		hex.EncodeToString(wordOfHex("100")),
+		hex.EncodeToString(wordOfHex("a0")),
+		// tail of str
+		hex.EncodeToString(wordOfHex("5")),
+		"68656c6c6f" + strings.Repeat("00", 27),
+		// tail of bytes
+		hex.EncodeToString(wordOfHex("0")),
+	}, "")
+	assert.Equal(t, expected, hex.EncodeToString(data))
+
+	// values of unknown types are encoded as bytes
+	data = dataOf([][]byte{[]byte("Unknown")}, [][]byte{{1, 2}})
+	expected = hex.EncodeToString(wordOfHex("20")) +
+		hex.EncodeToString(wordOfHex("2")) +
+		"0102" + strings.Repeat("00", 30)
+	assert.Equal(t, expected, hex.EncodeToString(data))
+}
+
+func TestFilterParam_Match(t *testing.T) {
+	sig := []byte("Transfer(Address,Address,int)")
+	score := common.MustNewAddressFromString("cx0000000000000000000000000000000000000004")
+	topics := [][]byte{keccak256(sig), wordOfHex("01"), wordOfHex("02")}
+
+	var p FilterParam
+	assert.NoError(t, convertJSON(`{
+		"address": "0x0000000000000000000000000000000000000004",
+		"topics": [
+			"0x`+hex.EncodeToString(keccak256(sig))+`",
+			null,
+			["0x`+hex.EncodeToString(wordOfHex("03"))+`", "0x`+hex.EncodeToString(wordOfHex("02"))+`"]
+		]
+	}`, &p))
+	assert.NoError(t, p.compile())
+	assert.True(t, p.matchAddress(score))
+	assert.False(t, p.matchAddress(common.MustNewAddressFromString("hx0000000000000000000000000000000000000004")))
+	assert.True(t, p.matchTopics(topics))
+	assert.False(t, p.matchTopics(topics[:2]))
+
+	topics[2] = wordOfHex("05")
+	assert.False(t, p.matchTopics(topics))
+}
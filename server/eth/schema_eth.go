@@ -0,0 +1,210 @@
+package eth
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	TagLatest    = "latest"
+	TagEarliest  = "earliest"
+	TagPending   = "pending"
+	TagSafe      = "safe"
+	TagFinalized = "finalized"
+)
+
+// BlockNumber is the block parameter. It's either a quantity or a tag.
+type BlockNumber struct {
+	Tag    string
+	Height int64
+}
+
+func (n *BlockNumber) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	switch s {
+	case TagLatest, TagEarliest, TagPending, TagSafe, TagFinalized:
+		n.Tag = s
+		return nil
+	}
+	if !strings.HasPrefix(s, "0x") {
+		return errors.IllegalArgumentError.Errorf("InvalidBlockNumber(%s)", s)
+	}
+	height, err := intconv.ParseInt(s, 64)
+	if err != nil || height < 0 {
+		return errors.IllegalArgumentError.Errorf("InvalidBlockNumber(%s)", s)
+	}
+	n.Tag, n.Height = "", height
+	return nil
+}
+
+// Resolve returns the height of the block. Tags except earliest are
+// resolved to latest, the last block having results of its transactions.
+func (n *BlockNumber) Resolve(earliest, latest int64) int64 {
+	if n == nil {
+		return latest
+	}
+	switch n.Tag {
+	case "":
+		return n.Height
+	case TagEarliest:
+		return earliest
+	default:
+		return latest
+	}
+}
+
+// Address is the address parameter. 20 bytes address is mapped to the
+// account (hx) address. Addresses in ICON format are also accepted.
+type Address struct {
+	common.Address
+}
+
+func (a *Address) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	addr, err := ParseAddress(s, false)
+	if err != nil {
+		return err
+	}
+	a.Address = *addr
+	return nil
+}
+
+// ParseAddress parses the address. 20 bytes address with 0x prefix is
+// mapped to the contract (cx) address if contract is true. Otherwise, it's
+// mapped to the account (hx) address.
+func ParseAddress(s string, contract bool) (*common.Address, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		id, err := hex.DecodeString(s[2:])
+		if err != nil || len(id) != common.AddressIDBytes {
+			return nil, errors.IllegalArgumentError.Errorf("InvalidAddress(%s)", s)
+		}
+		return common.NewAddressWithTypeAndID(contract, id), nil
+	}
+	addr, err := common.NewAddressFromString(s)
+	if err != nil {
+		return nil, errors.IllegalArgumentError.Wrapf(err, "InvalidAddress(%s)", s)
+	}
+	return addr, nil
+}
+
+// AddressOf returns 20 bytes address for the address. Type of the address
+// is dropped.
+func AddressOf(addr module.Address) string {
+	if addr == nil {
+		return "0x" + strings.Repeat("00", common.AddressIDBytes)
+	}
+	return "0x" + hex.EncodeToString(addr.ID())
+}
+
+// HashOf returns the hash with 0x prefix.
+func HashOf(h []byte) string {
+	return "0x" + hex.EncodeToString(h)
+}
+
+type hexList [][]byte
+
+// UnmarshalJSON accepts null, a string or an array of strings.
+func (l *hexList) UnmarshalJSON(b []byte) error {
+	var values []string
+	if err := json.Unmarshal(b, &values); err != nil {
+		var s *string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		if s != nil {
+			values = []string{*s}
+		}
+	}
+	*l = nil
+	for _, v := range values {
+		bs, err := hex.DecodeString(strings.TrimPrefix(v, "0x"))
+		if err != nil {
+			return errors.IllegalArgumentError.Errorf("InvalidHex(%s)", v)
+		}
+		*l = append(*l, bs)
+	}
+	return nil
+}
+
+type FilterParam struct {
+	FromBlock *BlockNumber    `json:"fromBlock,omitempty"`
+	ToBlock   *BlockNumber    `json:"toBlock,omitempty"`
+	BlockHash *string         `json:"blockHash,omitempty"`
+	Address   json.RawMessage `json:"address,omitempty"`
+	Topics    []hexList       `json:"topics,omitempty"`
+
+	addresses []module.Address
+}
+
+// compile parses addresses of the filter. Addresses are mapped to
+// contract addresses since only contracts emit events.
+func (p *FilterParam) compile() error {
+	p.addresses = nil
+	if len(p.Address) == 0 || string(p.Address) == "null" {
+		return nil
+	}
+	var values []string
+	if err := json.Unmarshal(p.Address, &values); err != nil {
+		var s string
+		if err := json.Unmarshal(p.Address, &s); err != nil {
+			return errors.IllegalArgumentError.Wrap(err, "InvalidAddress")
+		}
+		values = []string{s}
+	}
+	for _, v := range values {
+		addr, err := ParseAddress(v, true)
+		if err != nil {
+			return err
+		}
+		p.addresses = append(p.addresses, addr)
+	}
+	return nil
+}
+
+func (p *FilterParam) matchAddress(addr module.Address) bool {
+	if len(p.addresses) == 0 {
+		return true
+	}
+	for _, a := range p.addresses {
+		if a.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTopics returns whether topics match the filter. Empty list of
+// the position matches any topic.
+func (p *FilterParam) matchTopics(topics [][]byte) bool {
+	if len(p.Topics) > len(topics) {
+		return false
+	}
+	for i, candidates := range p.Topics {
+		if len(candidates) == 0 {
+			continue
+		}
+		matched := false
+		for _, c := range candidates {
+			if string(c) == string(topics[i]) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
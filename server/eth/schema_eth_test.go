@@ -0,0 +1,55 @@
+package eth
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+)
+
+func convertJSON(js string, v interface{}) error {
+	return json.Unmarshal([]byte(js), v)
+}
+
+func TestBlockNumber(t *testing.T) {
+	var n BlockNumber
+	assert.NoError(t, convertJSON(`"0x10"`, &n))
+	assert.Equal(t, int64(0x10), n.Resolve(1, 100))
+
+	assert.NoError(t, convertJSON(`"latest"`, &n))
+	assert.Equal(t, int64(100), n.Resolve(1, 100))
+	assert.NoError(t, convertJSON(`"pending"`, &n))
+	assert.Equal(t, int64(100), n.Resolve(1, 100))
+	assert.NoError(t, convertJSON(`"earliest"`, &n))
+	assert.Equal(t, int64(1), n.Resolve(1, 100))
+
+	var nilNumber *BlockNumber
+	assert.Equal(t, int64(100), nilNumber.Resolve(1, 100))
+
+	assert.Error(t, convertJSON(`"16"`, &n))
+	assert.Error(t, convertJSON(`"-0x1"`, &n))
+	assert.Error(t, convertJSON(`"unknown"`, &n))
+	assert.Error(t, convertJSON(`16`, &n))
+}
+
+func TestAddress(t *testing.T) {
+	id := "0000000000000000000000000000000000000001"
+
+	var a Address
+	assert.NoError(t, convertJSON(`"0x`+id+`"`, &a))
+	assert.Equal(t, "hx"+id, a.String())
+
+	assert.NoError(t, convertJSON(`"cx`+id+`"`, &a))
+	assert.Equal(t, "cx"+id, a.String())
+	assert.Equal(t, "0x"+id, AddressOf(&a.Address))
+
+	assert.Error(t, convertJSON(`"0x00"`, &a))
+
+	addr, err := ParseAddress("0x"+id, true)
+	assert.NoError(t, err)
+	assert.True(t, addr.Equal(common.MustNewAddressFromString("cx"+id)))
+
+	assert.Equal(t, "0x0000000000000000000000000000000000000000", AddressOf(nil))
+}
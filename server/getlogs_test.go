@@ -0,0 +1,156 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	"github.com/icon-project/goloop/server/v3"
+)
+
+const (
+	testScore1 = "cx0000000000000000000000000000000000000011"
+	testScore2 = "cx0000000000000000000000000000000000000012"
+	testUser1  = "hx0000000000000000000000000000000000000021"
+	testUser2  = "hx0000000000000000000000000000000000000022"
+)
+
+// newLogsTestChain returns the chain with logs in the block 1, 2 and 3,
+// and the last block is 5.
+func newLogsTestChain(t *testing.T) *testChain {
+	c := newTestChain()
+	c.addBlock()
+	c.addBlock(newTestReceipt(t,
+		testLog{testScore1, testUser1, testUser2, "0x1"},
+		testLog{testScore1, testUser2, testUser1, "0x2"},
+	))
+	c.addBlock(
+		newTestReceipt(t),
+		newTestReceipt(t, testLog{testScore2, testUser1, testUser2, "0x3"}),
+	)
+	c.addBlock(newTestReceipt(t,
+		testLog{testScore1, testUser1, testUser2, "0x4"},
+		testLog{testScore2, testUser2, testUser1, "0x5"},
+	))
+	c.addBlock()
+	c.addBlock()
+	return c
+}
+
+type logInfoJSON struct {
+	BlockHeight common.HexInt64 `json:"blockHeight"`
+	TxHash      common.HexBytes `json:"txHash"`
+	TxIndex     common.HexInt32 `json:"txIndex"`
+	EventIndex  common.HexInt32 `json:"eventIndex"`
+	EventLog    json.RawMessage `json:"eventLog"`
+}
+
+type logsResultJSON struct {
+	LastHeight common.HexInt64 `json:"lastHeight"`
+	Logs       []*logInfoJSON  `json:"logs"`
+}
+
+func callGetLogs(t *testing.T, c *testChain, params string) (*logsResultJSON, *jsonrpc.Error) {
+	res, jerr := invokeMethod(t, c, "icx_getLogs", params)
+	if jerr != nil {
+		return nil, jerr
+	}
+	var result logsResultJSON
+	assert.NoError(t, json.Unmarshal(res, &result))
+	return &result, nil
+}
+
+func valuesOfLogs(logs []*logInfoJSON) []string {
+	var values []string
+	for _, l := range logs {
+		values = append(values, fmt.Sprintf("%d:%d:%d",
+			l.BlockHeight.Value, l.TxIndex.Value, l.EventIndex.Value))
+	}
+	return values
+}
+
+func TestGetLogs_Range(t *testing.T) {
+	c := newLogsTestChain(t)
+	c.gs.height = 1
+
+	tests := []struct {
+		name   string
+		params string
+		code   jsonrpc.ErrorCode
+	}{
+		{"NoResultYet", `{"fromHeight":"0x1","toHeight":"0x5","event":"` + testEventSignature + `"}`, jsonrpc.ErrorCodeNotFound},
+		{"PrunedBlock", `{"fromHeight":"0x0","event":"` + testEventSignature + `"}`, jsonrpc.ErrorCodeNotFound},
+		{"InvalidRange", `{"fromHeight":"0x3","toHeight":"0x2","event":"` + testEventSignature + `"}`, jsonrpc.ErrorCodeInvalidParams},
+		{"BadSignature", `{"fromHeight":"0x1","event":"Transfer"}`, jsonrpc.ErrorCodeInvalidParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, jerr := callGetLogs(t, c, tt.params)
+			if assert.NotNil(t, jerr) {
+				assert.Equal(t, tt.code, jerr.Code)
+			}
+		})
+	}
+
+	result, jerr := callGetLogs(t, c, `{"fromHeight":"0x1","event":"`+testEventSignature+`"}`)
+	assert.Nil(t, jerr)
+	assert.EqualValues(t, 4, result.LastHeight.Value)
+	assert.Equal(t, []string{"1:0:0", "1:0:1", "2:1:0", "3:0:0", "3:0:1"}, valuesOfLogs(result.Logs))
+}
+
+func TestGetLogs_MaxRange(t *testing.T) {
+	c := newTestChain()
+	for i := 0; i < v3.LogsMaxRange+10; i++ {
+		c.addBlock()
+	}
+
+	result, jerr := callGetLogs(t, c, `{"fromHeight":"0x0","event":"`+testEventSignature+`"}`)
+	assert.Nil(t, jerr)
+	assert.EqualValues(t, v3.LogsMaxRange-1, result.LastHeight.Value)
+	assert.Empty(t, result.Logs)
+
+	params := fmt.Sprintf(`{"fromHeight":"%#x","event":"%s"}`, result.LastHeight.Value+1, testEventSignature)
+	result, jerr = callGetLogs(t, c, params)
+	assert.Nil(t, jerr)
+	assert.EqualValues(t, v3.LogsMaxRange+8, result.LastHeight.Value)
+}
+
+func TestGetLogs_Limit(t *testing.T) {
+	c := newLogsTestChain(t)
+
+	var pages [][]string
+	from := int64(1)
+	for from <= 4 {
+		params := fmt.Sprintf(`{"fromHeight":"%#x","limit":"0x2","event":"%s"}`, from, testEventSignature)
+		result, jerr := callGetLogs(t, c, params)
+		if !assert.Nil(t, jerr) {
+			return
+		}
+		pages = append(pages, valuesOfLogs(result.Logs))
+		from = result.LastHeight.Value + 1
+	}
+	assert.Equal(t, [][]string{
+		{"1:0:0", "1:0:1"},
+		{"2:1:0"},
+		{"3:0:0", "3:0:1"},
+	}, pages)
+}
+
+func TestGetLogs_TooManyLogs(t *testing.T) {
+	c := newLogsTestChain(t)
+
+	_, jerr := callGetLogs(t, c, `{"fromHeight":"0x1","limit":"0x1","event":"`+testEventSignature+`"}`)
+	if assert.NotNil(t, jerr) {
+		assert.Equal(t, jsonrpc.ErrorCodeInvalidRequest, jerr.Code)
+		assert.Contains(t, jerr.Message, "TooManyLogs")
+	}
+
+	result, jerr := callGetLogs(t, c, `{"fromHeight":"0x2","limit":"0x1","event":"`+testEventSignature+`"}`)
+	assert.Nil(t, jerr)
+	assert.EqualValues(t, 2, result.LastHeight.Value)
+	assert.Equal(t, []string{"2:1:0"}, valuesOfLogs(result.Logs))
+}
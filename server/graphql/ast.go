@@ -0,0 +1,114 @@
+package graphql
+
+// Document is the executable document. Only query operations are served,
+// and type system definitions are not allowed.
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+type Operation struct {
+	Type       string
+	Name       string
+	Variables  []*VariableDefinition
+	Directives []*Directive
+	Selections []Selection
+	Loc        Location
+}
+
+type VariableDefinition struct {
+	Name    string
+	Type    string
+	NonNull bool
+	Default *Value
+	Loc     Location
+}
+
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+	Loc       Location
+}
+
+type Argument struct {
+	Name  string
+	Value *Value
+	Loc   Location
+}
+
+// Selection is one of *Field, *FragmentSpread and *InlineFragment.
+type Selection interface {
+	location() Location
+	directives() []*Directive
+}
+
+type Field struct {
+	Alias      string
+	Name       string
+	Arguments  []*Argument
+	Directives []*Directive
+	Selections []Selection
+	Loc        Location
+}
+
+func (f *Field) location() Location       { return f.Loc }
+func (f *Field) directives() []*Directive { return f.Directives }
+
+// ResponseKey returns the key of the field in the response.
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+	Loc        Location
+}
+
+func (f *FragmentSpread) location() Location       { return f.Loc }
+func (f *FragmentSpread) directives() []*Directive { return f.Directives }
+
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	Selections    []Selection
+	Loc           Location
+}
+
+func (f *InlineFragment) location() Location       { return f.Loc }
+func (f *InlineFragment) directives() []*Directive { return f.Directives }
+
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	Directives    []*Directive
+	Selections    []Selection
+	Loc           Location
+}
+
+type ValueKind int
+
+const (
+	ValueVariable ValueKind = iota
+	ValueInt
+	ValueFloat
+	ValueString
+	ValueBoolean
+	ValueNull
+	ValueEnum
+	ValueList
+	ValueObject
+)
+
+// Value is the literal value or the variable in the document. Raw has the
+// name of the variable, or the literal of the scalar value.
+type Value struct {
+	Kind   ValueKind
+	Raw    string
+	List   []*Value
+	Fields []*Argument
+	Loc    Location
+}
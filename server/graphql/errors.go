@@ -0,0 +1,42 @@
+package graphql
+
+import (
+	"fmt"
+)
+
+// Error is the error in the response. Locations and the path of the
+// field are included if they are known.
+type Error struct {
+	Message   string        `json:"message"`
+	Locations []Location    `json:"locations,omitempty"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if len(e.Locations) > 0 {
+		return fmt.Sprintf("%s (line=%d,column=%d)",
+			e.Message, e.Locations[0].Line, e.Locations[0].Column)
+	}
+	return e.Message
+}
+
+func newError(loc Location, format string, args ...interface{}) *Error {
+	return &Error{
+		Message:   fmt.Sprintf(format, args...),
+		Locations: []Location{loc},
+	}
+}
+
+// fieldError returns the error of the field at the path.
+func fieldError(err error, loc Location, path []interface{}) *Error {
+	if e, ok := err.(*Error); ok && len(e.Locations) > 0 {
+		return e
+	}
+	p := make([]interface{}, len(path))
+	copy(p, path)
+	return &Error{
+		Message:   err.Error(),
+		Locations: []Location{loc},
+		Path:      p,
+	}
+}
@@ -0,0 +1,521 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+const typenameField = "__typename"
+
+// object is the result of the selection set. Fields are kept in the order
+// of the selections.
+type object struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newObject() *object {
+	return &object{values: make(map[string]interface{})}
+}
+
+func (o *object) set(key string, value interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+func (o *object) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBufferString("{")
+	for i, k := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		value, err := json.Marshal(o.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+type fieldGroup struct {
+	key    string
+	fields []*Field
+}
+
+type execution struct {
+	schema  *schema
+	doc     *Document
+	op      *Operation
+	vars    map[string]interface{}
+	rc      *resolveContext
+	cost    int
+	maxCost int
+	errors  []*Error
+}
+
+// result is the result of the operation.
+type result struct {
+	Data   *object
+	Errors []*Error
+	Cost   int
+}
+
+// selectOperation returns the operation to execute. Name is required if
+// the document has more than one operation.
+func selectOperation(doc *Document, name string) (*Operation, error) {
+	if name == "" {
+		if len(doc.Operations) != 1 {
+			return nil, &Error{Message: "Operation name is required"}
+		}
+		return doc.Operations[0], nil
+	}
+	for _, op := range doc.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, &Error{Message: "Unknown operation " + strings.TrimSpace(name)}
+}
+
+// coerceVariables applies default values and checks non-null variables.
+func coerceVariables(op *Operation, values map[string]interface{}) (map[string]interface{}, error) {
+	vars := make(map[string]interface{}, len(op.Variables))
+	for _, def := range op.Variables {
+		v, ok := values[def.Name]
+		if !ok && def.Default != nil {
+			dv, err := valueOf(def.Default, nil)
+			if err != nil {
+				return nil, err
+			}
+			v, ok = dv, true
+		}
+		if v == nil && def.NonNull {
+			return nil, newError(def.Loc, "Variable $%s of non-null type %s! is not provided", def.Name, def.Type)
+		}
+		if ok {
+			vars[def.Name] = v
+		}
+	}
+	return vars, nil
+}
+
+// execute executes the operation of the document. Request errors like
+// errors of validation are returned as error. Errors of fields are in
+// the result with the partial data.
+func execute(s *schema, rc *resolveContext, doc *Document, opName string, values map[string]interface{}, maxCost int) (*result, error) {
+	op, err := selectOperation(doc, opName)
+	if err != nil {
+		return nil, err
+	}
+	if op.Type != "query" {
+		return nil, newError(op.Loc, "Unsupported operation %s", op.Type)
+	}
+	if err := validate(s, doc, op); err != nil {
+		return nil, err
+	}
+	vars, err := coerceVariables(op, values)
+	if err != nil {
+		return nil, err
+	}
+	ex := &execution{
+		schema:  s,
+		doc:     doc,
+		op:      op,
+		vars:    vars,
+		rc:      rc,
+		maxCost: maxCost,
+	}
+	if maxCost > 0 {
+		if _, err := ex.estimate(s.query, op.Selections); err != nil {
+			return nil, err
+		}
+	}
+	data, err := ex.executeSelections(s.query, nil, op.Selections, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &result{Data: data, Errors: ex.errors, Cost: ex.cost}, nil
+}
+
+func (ex *execution) charge(cost int, loc Location) error {
+	ex.cost += cost
+	if ex.maxCost > 0 && ex.cost > ex.maxCost {
+		return newError(loc, "Query cost exceeds the limit %d", ex.maxCost)
+	}
+	return nil
+}
+
+// estimate returns the maximum cost of the selections assuming lists have
+// as many items as their arguments allow. It returns an error as soon as
+// the cost exceeds the limit, so no resolver is called for the query.
+func (ex *execution) estimate(t *objectType, sels []Selection) (int, error) {
+	groups, err := ex.collectFields(t, sels, nil, make(map[string]bool))
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, g := range groups {
+		f := g.fields[0]
+		if f.Name == typenameField {
+			continue
+		}
+		fd := t.fields[f.Name]
+		cost := 0
+		if ot := ex.schema.objectOf(fd); ot != nil {
+			var sub []Selection
+			for _, field := range g.fields {
+				sub = append(sub, field.Selections...)
+			}
+			if cost, err = ex.estimate(ot, sub); err != nil {
+				return 0, err
+			}
+		}
+		if fd.list {
+			size := DefaultListSize
+			if args, err := argumentsOf(f.Arguments, ex.vars); err == nil && fd.size != nil {
+				size = fd.size(args)
+			}
+			cost += fd.itemCost
+			if cost > 0 && size > ex.maxCost/cost {
+				return 0, newError(f.Loc, "Estimated query cost exceeds the limit %d", ex.maxCost)
+			}
+			cost *= size
+		}
+		total += fd.cost + cost
+		if total > ex.maxCost {
+			return 0, newError(f.Loc, "Estimated query cost exceeds the limit %d", ex.maxCost)
+		}
+	}
+	return total, nil
+}
+
+// included evaluates @skip and @include directives.
+func (ex *execution) included(dirs []*Directive) (bool, error) {
+	for _, dir := range dirs {
+		args, err := argumentsOf(dir.Arguments, ex.vars)
+		if err != nil {
+			return false, err
+		}
+		cond, ok, err := args.Boolean("if")
+		if err != nil {
+			return false, newError(dir.Loc, "%s", err.Error())
+		} else if !ok {
+			return false, newError(dir.Loc, "Argument \"if\" of @%s is required", dir.Name)
+		}
+		if (dir.Name == "skip" && cond) || (dir.Name == "include" && !cond) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (ex *execution) collectFields(t *objectType, sels []Selection, groups []*fieldGroup, visited map[string]bool) ([]*fieldGroup, error) {
+	for _, sel := range sels {
+		if ok, err := ex.included(sel.directives()); err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+		var err error
+		switch s := sel.(type) {
+		case *Field:
+			key := s.ResponseKey()
+			found := false
+			for _, g := range groups {
+				if g.key == key {
+					g.fields = append(g.fields, s)
+					found = true
+					break
+				}
+			}
+			if !found {
+				groups = append(groups, &fieldGroup{key: key, fields: []*Field{s}})
+			}
+		case *FragmentSpread:
+			if visited[s.Name] {
+				continue
+			}
+			visited[s.Name] = true
+			f := ex.doc.Fragments[s.Name]
+			if f.TypeCondition != t.name {
+				continue
+			}
+			groups, err = ex.collectFields(t, f.Selections, groups, visited)
+		case *InlineFragment:
+			if s.TypeCondition != "" && s.TypeCondition != t.name {
+				continue
+			}
+			groups, err = ex.collectFields(t, s.Selections, groups, visited)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return groups, nil
+}
+
+func (ex *execution) executeSelections(t *objectType, src interface{}, sels []Selection, path []interface{}) (*object, error) {
+	groups, err := ex.collectFields(t, sels, nil, make(map[string]bool))
+	if err != nil {
+		return nil, err
+	}
+	result := newObject()
+	for _, g := range groups {
+		value, err := ex.executeField(t, src, g, append(path, g.key))
+		if err != nil {
+			return nil, err
+		}
+		result.set(g.key, value)
+	}
+	return result, nil
+}
+
+// executeField returns the value of the field. Errors of the resolver are
+// recorded and the value becomes null. Returned error aborts the execution.
+func (ex *execution) executeField(t *objectType, src interface{}, g *fieldGroup, path []interface{}) (interface{}, error) {
+	f := g.fields[0]
+	if f.Name == typenameField {
+		return t.name, nil
+	}
+	fd := t.fields[f.Name]
+	if err := ex.charge(fd.cost, f.Loc); err != nil {
+		return nil, err
+	}
+	args, err := argumentsOf(f.Arguments, ex.vars)
+	if err != nil {
+		ex.errors = append(ex.errors, fieldError(err, f.Loc, path))
+		return nil, nil
+	}
+	value, err := fd.resolve(ex.rc, src, args)
+	if err != nil {
+		ex.errors = append(ex.errors, fieldError(err, f.Loc, path))
+		return nil, nil
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	var sels []Selection
+	for _, field := range g.fields {
+		sels = append(sels, field.Selections...)
+	}
+	ot := ex.schema.objectOf(fd)
+	if !fd.list {
+		if ot == nil {
+			return value, nil
+		}
+		return ex.executeSelections(ot, value, sels, path)
+	}
+
+	items := value.([]interface{})
+	if err := ex.charge(fd.itemCost*len(items), f.Loc); err != nil {
+		return nil, err
+	}
+	if ot == nil {
+		return items, nil
+	}
+	list := make([]interface{}, len(items))
+	for i, item := range items {
+		if item == nil {
+			continue
+		}
+		obj, err := ex.executeSelections(ot, item, sels, append(path, i))
+		if err != nil {
+			return nil, err
+		}
+		list[i] = obj
+	}
+	return list, nil
+}
+
+// validate checks selections of the operation against the schema, and
+// usage of fragments, variables and directives.
+func validate(s *schema, doc *Document, op *Operation) error {
+	v := &validator{
+		schema:    s,
+		doc:       doc,
+		vars:      make(map[string]bool),
+		fragments: make(map[string]fragmentState),
+	}
+	for _, def := range op.Variables {
+		if v.vars[def.Name] {
+			return newError(def.Loc, "Duplicate variable $%s", def.Name)
+		}
+		v.vars[def.Name] = true
+	}
+	if err := v.directives(op.Directives); err != nil {
+		return err
+	}
+	return v.selections(s.query, op.Selections)
+}
+
+type fragmentState int
+
+const (
+	fragmentValidating fragmentState = iota + 1
+	fragmentValid
+)
+
+type validator struct {
+	schema    *schema
+	doc       *Document
+	vars      map[string]bool
+	fragments map[string]fragmentState
+}
+
+func (v *validator) value(value *Value) error {
+	switch value.Kind {
+	case ValueVariable:
+		if !v.vars[value.Raw] {
+			return newError(value.Loc, "Variable $%s is not defined", value.Raw)
+		}
+	case ValueList:
+		for _, item := range value.List {
+			if err := v.value(item); err != nil {
+				return err
+			}
+		}
+	case ValueObject:
+		for _, f := range value.Fields {
+			if err := v.value(f.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (v *validator) directives(dirs []*Directive) error {
+	for _, dir := range dirs {
+		if dir.Name != "skip" && dir.Name != "include" {
+			return newError(dir.Loc, "Unknown directive @%s", dir.Name)
+		}
+		for _, arg := range dir.Arguments {
+			if arg.Name != "if" {
+				return newError(arg.Loc, "Unknown argument %q of @%s", arg.Name, dir.Name)
+			}
+			if err := v.value(arg.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// selections validates selections of the type.
+func (v *validator) selections(t *objectType, sels []Selection) error {
+	for _, sel := range sels {
+		if err := v.directives(sel.directives()); err != nil {
+			return err
+		}
+		switch s := sel.(type) {
+		case *Field:
+			if err := v.field(t, s); err != nil {
+				return err
+			}
+		case *FragmentSpread:
+			if err := v.fragment(s); err != nil {
+				return err
+			}
+		case *InlineFragment:
+			ft := t
+			if s.TypeCondition != "" {
+				var ok bool
+				if ft, ok = v.schema.types[s.TypeCondition]; !ok {
+					return newError(s.Loc, "Unknown type %q", s.TypeCondition)
+				}
+			}
+			if err := v.selections(ft, s.Selections); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fragment validates the fragment of the spread against its type condition.
+// Each fragment is validated once, and a spread of the fragment being
+// validated is reported as a cycle.
+func (v *validator) fragment(s *FragmentSpread) error {
+	f, ok := v.doc.Fragments[s.Name]
+	if !ok {
+		return newError(s.Loc, "Unknown fragment %q", s.Name)
+	}
+	switch v.fragments[s.Name] {
+	case fragmentValid:
+		return nil
+	case fragmentValidating:
+		return newError(s.Loc, "Cycle of fragment %q", s.Name)
+	}
+	v.fragments[s.Name] = fragmentValidating
+	ft, ok := v.schema.types[f.TypeCondition]
+	if !ok {
+		return newError(f.Loc, "Unknown type %q", f.TypeCondition)
+	}
+	if err := v.directives(f.Directives); err != nil {
+		return err
+	}
+	if err := v.selections(ft, f.Selections); err != nil {
+		return err
+	}
+	v.fragments[s.Name] = fragmentValid
+	return nil
+}
+
+func (v *validator) field(t *objectType, f *Field) error {
+	if f.Name == typenameField {
+		if len(f.Arguments) > 0 || len(f.Selections) > 0 {
+			return newError(f.Loc, "Invalid selection of %s", typenameField)
+		}
+		return nil
+	}
+	fd, ok := t.fields[f.Name]
+	if !ok {
+		return newError(f.Loc, "Cannot query field %q on type %q", f.Name, t.name)
+	}
+	for _, arg := range f.Arguments {
+		if _, ok := fd.args[arg.Name]; !ok {
+			return newError(arg.Loc, "Unknown argument %q on field %s.%s", arg.Name, t.name, f.Name)
+		}
+		if err := v.value(arg.Value); err != nil {
+			return err
+		}
+	}
+	for name, typ := range fd.args {
+		if !strings.HasSuffix(typ, "!") {
+			continue
+		}
+		found := false
+		for _, arg := range f.Arguments {
+			if arg.Name == name && arg.Value.Kind != ValueNull {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return newError(f.Loc, "Argument %q of type %s is required on field %s.%s", name, typ, t.name, f.Name)
+		}
+	}
+	ot := v.schema.objectOf(fd)
+	if ot == nil {
+		if len(f.Selections) > 0 {
+			return newError(f.Loc, "Field %q of type %s must not have a selection", f.Name, fd.typ)
+		}
+		return nil
+	}
+	if len(f.Selections) == 0 {
+		return newError(f.Loc, "Field %q of type %s must have a selection", f.Name, fd.typ)
+	}
+	return v.selections(ot, f.Selections)
+}
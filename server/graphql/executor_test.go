@@ -0,0 +1,212 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testItem struct {
+	ID   int64
+	Name string
+}
+
+func testSchema() *schema {
+	items := []interface{}{
+		&testItem{ID: 1, Name: "one"},
+		&testItem{ID: 2, Name: "two"},
+		&testItem{ID: 3, Name: "three"},
+	}
+	return newSchema("Query",
+		&objectType{
+			name: "Query",
+			fields: map[string]*fieldDef{
+				"item": {
+					typ:  "Item",
+					args: map[string]string{"id": TypeLong + "!"},
+					cost: 10,
+					resolve: func(rc *resolveContext, src interface{}, args arguments) (interface{}, error) {
+						id, _, err := args.Long("id")
+						if err != nil {
+							return nil, err
+						}
+						for _, item := range items {
+							if item.(*testItem).ID == id {
+								return item, nil
+							}
+						}
+						return nil, nil
+					},
+				},
+				"items": {
+					typ:      "Item",
+					list:     true,
+					args:     pageArgs,
+					cost:     1,
+					itemCost: 10,
+					size:     pageSize,
+					resolve: func(rc *resolveContext, src interface{}, args arguments) (interface{}, error) {
+						start, end, err := paginate(args, len(items))
+						if err != nil {
+							return nil, err
+						}
+						return items[start:end], nil
+					},
+				},
+				"fail": property(TypeString, func(src interface{}) (interface{}, error) {
+					return nil, errors.New("failure")
+				}),
+			},
+		},
+		&objectType{
+			name: "Item",
+			fields: map[string]*fieldDef{
+				"id": property(TypeLong, func(src interface{}) (interface{}, error) {
+					return src.(*testItem).ID, nil
+				}),
+				"name": property(TypeString, func(src interface{}) (interface{}, error) {
+					return src.(*testItem).Name, nil
+				}),
+			},
+		},
+	)
+}
+
+func run(t *testing.T, query string, vars map[string]interface{}, maxCost int) (string, *result, error) {
+	doc, err := Parse(query)
+	if !assert.NoError(t, err) {
+		return "", nil, err
+	}
+	res, err := execute(testSchema(), nil, doc, "", vars, maxCost)
+	if err != nil {
+		return "", nil, err
+	}
+	bs, err := json.Marshal(res.Data)
+	assert.NoError(t, err)
+	return string(bs), res, nil
+}
+
+func TestExecute_Selections(t *testing.T) {
+	js, res, err := run(t, `
+		query ($id: Long!, $skip: Boolean = true) {
+			a: item(id: $id) { name id __typename }
+			b: item(id: 4) { name }
+			items(first: 2, skip: 1) {
+				...F
+				name @skip(if: $skip)
+				... on Item { id }
+			}
+		}
+		fragment F on Item { id name }
+	`, map[string]interface{}{"id": json.Number("1")}, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, res.Errors)
+	assert.Equal(t,
+		`{"a":{"name":"one","id":1,"__typename":"Item"},"b":null,`+
+			`"items":[{"id":2,"name":"two"},{"id":3,"name":"three"}]}`,
+		js)
+	// a(10+2) + b(10) + items(1+10*2) + id and name of items(2*2)
+	assert.Equal(t, 12+10+21+4, res.Cost)
+}
+
+func TestExecute_FieldError(t *testing.T) {
+	js, res, err := run(t, `{ item(id: 1) { id } fail }`, nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"item":{"id":1},"fail":null}`, js)
+	if assert.Len(t, res.Errors, 1) {
+		assert.Equal(t, "failure", res.Errors[0].Message)
+		assert.Equal(t, []interface{}{"fail"}, res.Errors[0].Path)
+		assert.Equal(t, []Location{{Line: 1, Column: 22}}, res.Errors[0].Locations)
+	}
+
+	_, res, err = run(t, `{ item(id: "xyz") { id } }`, nil, 0)
+	assert.NoError(t, err)
+	assert.Len(t, res.Errors, 1)
+}
+
+func TestExecute_Cost(t *testing.T) {
+	_, _, err := run(t, `{ items { id name } }`, nil, 30)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "cost")
+	}
+	_, res, err := run(t, `{ items(first: 1) { id name } }`, nil, 30)
+	assert.NoError(t, err)
+	assert.Equal(t, 13, res.Cost)
+}
+
+func TestExecute_EstimatedCost(t *testing.T) {
+	cases := []struct {
+		query string
+		vars  map[string]interface{}
+		cost  int
+	}{
+		// item(10+1) + items(1+12*3), though only 3 items exist
+		{`{ item(id: 1) { id } items(first: 3) { id name } }`, nil, -1},
+		{`{ items(first: 2) { id name } }`, nil, 25},
+		{`{ items(skip: 2) { id } }`, nil, -1},
+		{`query ($n: Int) { items(first: $n) { id } }`, map[string]interface{}{"n": json.Number("2")}, 23},
+		{`query ($n: Int) { items(first: $n) { id } }`, map[string]interface{}{"n": json.Number("3")}, -1},
+		{`query ($n: Int) { items(first: $n) { id } }`, map[string]interface{}{"n": json.Number("4611686018427387904")}, -1},
+		{`{ items(first: 3) @skip(if: true) { id } }`, nil, 0},
+	}
+	for _, c := range cases {
+		_, res, err := run(t, c.query, c.vars, 30)
+		if c.cost < 0 {
+			if assert.Error(t, err, c.query) {
+				assert.Contains(t, err.Error(), "Estimated query cost", c.query)
+			}
+			continue
+		}
+		if assert.NoError(t, err, c.query) {
+			assert.Equal(t, c.cost, res.Cost, c.query)
+		}
+	}
+}
+
+func TestExecute_Validation(t *testing.T) {
+	cases := []struct {
+		query string
+		msg   string
+	}{
+		{`{ unknown }`, "Cannot query field"},
+		{`{ item { id } }`, "is required"},
+		{`{ item(id: 1, x: 2) { id } }`, "Unknown argument"},
+		{`{ item(id: 1) }`, "must have a selection"},
+		{`{ item(id: 1) { id { a } } }`, "must not have a selection"},
+		{`{ item(id: $x) { id } }`, "is not defined"},
+		{`{ item(id: 1) { ...G } }`, "Unknown fragment"},
+		{`{ items { ...F } } fragment F on Item { ...G } fragment G on Item { ...F }`, "Cycle"},
+		{`{ items { ... on Unknown { id } } }`, "Unknown type"},
+		{`{ items @defer { id } }`, "Unknown directive"},
+		{`mutation { items { id } }`, "Unsupported operation"},
+		{`query A { items { id } } query B { items { id } }`, "Operation name is required"},
+		{`query ($id: Long!) { item(id: $id) { id } }`, "is not provided"},
+	}
+	for _, c := range cases {
+		_, _, err := run(t, c.query, nil, 0)
+		if assert.Error(t, err, c.query) {
+			assert.Contains(t, err.Error(), c.msg, c.query)
+		}
+	}
+}
+
+func TestExecute_FragmentChain(t *testing.T) {
+	// each fragment spreads the next one twice, so validation walking
+	// every path would take 2^n steps.
+	const n = 60
+	var sb strings.Builder
+	sb.WriteString(`{ items(first: 1) { ...F0 } }`)
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, " fragment F%d on Item { id ...F%d ...F%d }", i, i+1, i+1)
+	}
+	fmt.Fprintf(&sb, " fragment F%d on Item { name }", n)
+
+	js, res, err := run(t, sb.String(), nil, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, res.Errors)
+	assert.Equal(t, `{"items":[{"id":1,"name":"one"}]}`, js)
+}
@@ -0,0 +1,134 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	DefaultMaxCost = 10000
+	MaxQueryLength = 64 * 1024
+
+	// MaxRequestLength is the limit of the body of POST requests having
+	// the query and the variables.
+	MaxRequestLength = 2 * MaxQueryLength
+)
+
+// Request is the request of the query. Variables may be given as an
+// object or a JSON string of the object for GET requests.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+type Handler struct {
+	schema  *schema
+	maxCost int
+}
+
+// NewHandler returns the handler serving queries on the chain injected in
+// the context with key "chain". Queries costing more than maxCost are
+// aborted. Non-positive maxCost means DefaultMaxCost.
+func NewHandler(maxCost int) *Handler {
+	if maxCost <= 0 {
+		maxCost = DefaultMaxCost
+	}
+	return &Handler{schema: chainSchema, maxCost: maxCost}
+}
+
+func (h *Handler) requestOf(c echo.Context) (*Request, error) {
+	req := new(Request)
+	if c.Request().Method == http.MethodGet {
+		req.Query = c.QueryParam("query")
+		req.OperationName = c.QueryParam("operationName")
+		if vars := c.QueryParam("variables"); vars != "" {
+			if err := decodeJSON([]byte(vars), &req.Variables); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		var buf bytes.Buffer
+		body := io.LimitReader(c.Request().Body, MaxRequestLength+1)
+		if _, err := buf.ReadFrom(body); err != nil {
+			return nil, err
+		}
+		if buf.Len() > MaxRequestLength {
+			return nil, &Error{Message: "Request is too long"}
+		}
+		if err := decodeJSON(buf.Bytes(), req); err != nil {
+			return nil, err
+		}
+	}
+	if req.Query == "" {
+		return nil, &Error{Message: "Query is required"}
+	}
+	if len(req.Query) > MaxQueryLength {
+		return nil, &Error{Message: "Query is too long"}
+	}
+	return req, nil
+}
+
+// decodeJSON decodes numbers as json.Number to keep integers.
+func decodeJSON(bs []byte, v interface{}) error {
+	d := json.NewDecoder(bytes.NewReader(bs))
+	d.UseNumber()
+	if err := d.Decode(v); err != nil {
+		return &Error{Message: "Invalid request: " + err.Error()}
+	}
+	return nil
+}
+
+func requestError(c echo.Context, status int, err error) error {
+	e, ok := err.(*Error)
+	if !ok {
+		e = &Error{Message: err.Error()}
+	}
+	return c.JSON(status, map[string]interface{}{
+		"errors": []*Error{e},
+	})
+}
+
+// Handle serves the query with GET or POST. Errors of the request are
+// returned with 400 Bad Request without data, and errors of fields are
+// returned with the partial data.
+func (h *Handler) Handle(c echo.Context) error {
+	chain, ok := c.Get("chain").(module.Chain)
+	if !ok || chain == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+	req, err := h.requestOf(c)
+	if err != nil {
+		return requestError(c, http.StatusBadRequest, err)
+	}
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return requestError(c, http.StatusBadRequest, err)
+	}
+	rc, err := newResolveContext(chain)
+	if err != nil {
+		return requestError(c, http.StatusServiceUnavailable, err)
+	}
+	res, err := execute(h.schema, rc, doc, req.OperationName, req.Variables, h.maxCost)
+	if err != nil {
+		return requestError(c, http.StatusBadRequest, err)
+	}
+	resp := map[string]interface{}{
+		"data": res.Data,
+		"extensions": map[string]interface{}{
+			"height":  rc.height,
+			"cost":    res.Cost,
+			"maxCost": h.maxCost,
+		},
+	}
+	if len(res.Errors) > 0 {
+		resp["errors"] = res.Errors
+	}
+	return c.JSON(http.StatusOK, resp)
+}
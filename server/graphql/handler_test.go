@@ -0,0 +1,202 @@
+package graphql
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+type testBlock struct {
+	module.Block
+	height int64
+}
+
+func (b *testBlock) Height() int64 {
+	return b.height
+}
+
+func (b *testBlock) ID() []byte {
+	return []byte{byte(b.height)}
+}
+
+func (b *testBlock) PrevID() []byte {
+	if b.height == 0 {
+		return nil
+	}
+	return []byte{byte(b.height - 1)}
+}
+
+func (b *testBlock) Result() []byte {
+	return []byte{byte(b.height)}
+}
+
+type testBlockManager struct {
+	module.BlockManager
+	last int64
+}
+
+func (bm *testBlockManager) GetLastBlock() (module.Block, error) {
+	return &testBlock{height: bm.last}, nil
+}
+
+func (bm *testBlockManager) GetBlockByHeight(height int64) (module.Block, error) {
+	if height > bm.last {
+		return nil, errors.NotFoundError.Errorf("NoBlock(height=%d)", height)
+	}
+	return &testBlock{height: height}, nil
+}
+
+type testServiceManager struct {
+	module.ServiceManager
+}
+
+func (sm *testServiceManager) GetBalance(result []byte, addr module.Address) (*big.Int, error) {
+	return big.NewInt(int64(result[0]) * 100), nil
+}
+
+type testGenesisStorage struct {
+	module.GenesisStorage
+}
+
+func (gs *testGenesisStorage) Height() int64 {
+	return 1
+}
+
+type testChain struct {
+	module.Chain
+	bm module.BlockManager
+	sm module.ServiceManager
+}
+
+func (c *testChain) BlockManager() module.BlockManager {
+	return c.bm
+}
+
+func (c *testChain) ServiceManager() module.ServiceManager {
+	return c.sm
+}
+
+func (c *testChain) GenesisStorage() module.GenesisStorage {
+	return &testGenesisStorage{}
+}
+
+func serve(chain module.Chain, method string, req *Request) (int, map[string]interface{}) {
+	e := echo.New()
+	var r *http.Request
+	if method == http.MethodGet {
+		q := url.Values{}
+		q.Set("query", req.Query)
+		if req.Variables != nil {
+			vars, _ := json.Marshal(req.Variables)
+			q.Set("variables", string(vars))
+		}
+		r = httptest.NewRequest(method, "/?"+q.Encode(), nil)
+	} else {
+		body, _ := json.Marshal(req)
+		r = httptest.NewRequest(method, "/", strings.NewReader(string(body)))
+	}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(r, rec)
+	c.Set("chain", chain)
+	_ = NewHandler(0).Handle(c)
+
+	var res map[string]interface{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &res)
+	return rec.Code, res
+}
+
+func TestHandler_Query(t *testing.T) {
+	chain := &testChain{
+		bm: &testBlockManager{last: 10},
+		sm: &testServiceManager{},
+	}
+	addr := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		code, res := serve(chain, method, &Request{
+			Query: `query ($addr: Address!) {
+				height
+				last: block { height hash }
+				block(height: "0x5") { height parentHash }
+				next: block(height: 11) { height }
+				blocks(from: 8, to: 20) { height }
+				account(address: $addr, height: 3) { address balance isContract score { owner } }
+			}`,
+			Variables: map[string]interface{}{"addr": addr.String()},
+		})
+		assert.Equal(t, http.StatusOK, code)
+		assert.Nil(t, res["errors"])
+		assert.Equal(t, map[string]interface{}{
+			"height": 10.0,
+			"last":   map[string]interface{}{"height": 10.0, "hash": "0x0a"},
+			"block":  map[string]interface{}{"height": 5.0, "parentHash": "0x04"},
+			"next":   nil,
+			"blocks": []interface{}{
+				map[string]interface{}{"height": 8.0},
+				map[string]interface{}{"height": 9.0},
+				map[string]interface{}{"height": 10.0},
+			},
+			"account": map[string]interface{}{
+				"address":    addr.String(),
+				"balance":    "0x12c",
+				"isContract": false,
+				"score":      nil,
+			},
+		}, res["data"])
+		assert.Equal(t, 10.0, res["extensions"].(map[string]interface{})["height"])
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	chain := &testChain{
+		bm: &testBlockManager{last: 10},
+		sm: &testServiceManager{},
+	}
+
+	// request errors
+	code, res := serve(chain, http.MethodPost, &Request{Query: `{ block { unknown } }`})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.NotContains(t, res, "data")
+	assert.Len(t, res["errors"], 1)
+
+	code, _ = serve(chain, http.MethodPost, &Request{})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// too long requests are rejected before decoding them
+	code, res = serve(chain, http.MethodPost, &Request{
+		Query:     `{ height }`,
+		Variables: map[string]interface{}{"v": strings.Repeat("a", MaxRequestLength)},
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "Request is too long", res["errors"].([]interface{})[0].(map[string]interface{})["message"])
+	code, _ = serve(chain, http.MethodPost, &Request{
+		Query:     `{ height }`,
+		Variables: map[string]interface{}{"v": strings.Repeat("a", MaxQueryLength)},
+	})
+	assert.Equal(t, http.StatusOK, code)
+
+	// field errors
+	defer func(n int64) { MaxBlocks = n }(MaxBlocks)
+	MaxBlocks = 5
+	code, res = serve(chain, http.MethodPost, &Request{
+		Query: `{ pruned: block(height: 0) { height } blocks(from: 1) { height } }`,
+	})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]interface{}{"pruned": nil, "blocks": nil}, res["data"])
+	assert.Len(t, res["errors"], 2)
+
+	// stopped chain
+	code, _ = serve(&testChain{}, http.MethodPost, &Request{Query: `{ height }`})
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
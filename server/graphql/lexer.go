@@ -0,0 +1,276 @@
+package graphql
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokenEOF:
+		return "EOF"
+	case tokenPunct:
+		return "Punctuator"
+	case tokenName:
+		return "Name"
+	case tokenInt:
+		return "Int"
+	case tokenFloat:
+		return "Float"
+	case tokenString:
+		return "String"
+	default:
+		return "Unknown"
+	}
+}
+
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type token struct {
+	kind  tokenKind
+	value string
+	loc   Location
+}
+
+// lexer splits the source into tokens. Commas, white spaces and comments
+// are ignored as the specification says.
+type lexer struct {
+	src  string
+	pos  int
+	line int
+	col  int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: src, line: 1, col: 1}
+}
+
+func (l *lexer) advance(n int) {
+	for i := 0; i < n && l.pos < len(l.src); i++ {
+		if l.src[l.pos] == '\n' {
+			l.line++
+			l.col = 1
+		} else {
+			l.col++
+		}
+		l.pos++
+	}
+}
+
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; c {
+		case ' ', '\t', '\n', '\r', ',':
+			l.advance(1)
+		case '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		default:
+			if strings.HasPrefix(l.src[l.pos:], "\ufeff") {
+				l.pos += len("\ufeff")
+				continue
+			}
+			return
+		}
+	}
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func (l *lexer) next() (*token, error) {
+	l.skipIgnored()
+	loc := Location{Line: l.line, Column: l.col}
+	if l.pos >= len(l.src) {
+		return &token{kind: tokenEOF, loc: loc}, nil
+	}
+	c := l.src[l.pos]
+	switch {
+	case strings.IndexByte("!$&():=@[]{}|", c) >= 0:
+		l.advance(1)
+		return &token{kind: tokenPunct, value: string(c), loc: loc}, nil
+	case c == '.':
+		if strings.HasPrefix(l.src[l.pos:], "...") {
+			l.advance(3)
+			return &token{kind: tokenPunct, value: "...", loc: loc}, nil
+		}
+	case isNameStart(c):
+		start := l.pos
+		for l.pos < len(l.src) && (isNameStart(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance(1)
+		}
+		return &token{kind: tokenName, value: l.src[start:l.pos], loc: loc}, nil
+	case c == '-' || isDigit(c):
+		return l.readNumber(loc)
+	case c == '"':
+		return l.readString(loc)
+	}
+	r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+	return nil, newError(loc, "Unexpected character %q", r)
+}
+
+func (l *lexer) readNumber(loc Location) (*token, error) {
+	start := l.pos
+	kind := tokenInt
+	if l.src[l.pos] == '-' {
+		l.advance(1)
+	}
+	digits := func() int {
+		n := 0
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.advance(1)
+			n++
+		}
+		return n
+	}
+	if digits() == 0 {
+		return nil, newError(loc, "Invalid number")
+	}
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		l.advance(1)
+		kind = tokenFloat
+		if digits() == 0 {
+			return nil, newError(loc, "Invalid number")
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		l.advance(1)
+		kind = tokenFloat
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.advance(1)
+		}
+		if digits() == 0 {
+			return nil, newError(loc, "Invalid number")
+		}
+	}
+	if l.pos < len(l.src) && (isNameStart(l.src[l.pos]) || l.src[l.pos] == '.') {
+		return nil, newError(loc, "Invalid number")
+	}
+	return &token{kind: kind, value: l.src[start:l.pos], loc: loc}, nil
+}
+
+func (l *lexer) readString(loc Location) (*token, error) {
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		return l.readBlockString(loc)
+	}
+	l.advance(1)
+	var sb strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '"':
+			l.advance(1)
+			return &token{kind: tokenString, value: sb.String(), loc: loc}, nil
+		case c == '\n' || c == '\r':
+			return nil, newError(loc, "Unterminated string")
+		case c == '\\':
+			if l.pos+1 >= len(l.src) {
+				return nil, newError(loc, "Unterminated string")
+			}
+			esc := l.src[l.pos+1]
+			switch esc {
+			case '"', '\\', '/':
+				sb.WriteByte(esc)
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'u':
+				if l.pos+6 > len(l.src) {
+					return nil, newError(loc, "Invalid escape sequence")
+				}
+				v, err := strconv.ParseUint(l.src[l.pos+2:l.pos+6], 16, 16)
+				if err != nil {
+					return nil, newError(loc, "Invalid escape sequence")
+				}
+				sb.WriteRune(rune(v))
+				l.advance(4)
+			default:
+				return nil, newError(loc, "Invalid escape sequence")
+			}
+			l.advance(2)
+		default:
+			sb.WriteByte(c)
+			l.advance(1)
+		}
+	}
+	return nil, newError(loc, "Unterminated string")
+}
+
+// readBlockString reads the block string. Common indentation of lines
+// and leading and trailing blank lines are removed.
+func (l *lexer) readBlockString(loc Location) (*token, error) {
+	l.advance(3)
+	var sb strings.Builder
+	for l.pos < len(l.src) {
+		if strings.HasPrefix(l.src[l.pos:], `"""`) {
+			l.advance(3)
+			return &token{kind: tokenString, value: blockStringValue(sb.String()), loc: loc}, nil
+		}
+		if strings.HasPrefix(l.src[l.pos:], `\"""`) {
+			sb.WriteString(`"""`)
+			l.advance(4)
+			continue
+		}
+		sb.WriteByte(l.src[l.pos])
+		l.advance(1)
+	}
+	return nil, newError(loc, "Unterminated string")
+}
+
+func blockStringValue(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+		if n := len(line) - len(trimmed); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= indent {
+				lines[i] = lines[i][indent:]
+			} else {
+				lines[i] = strings.TrimLeft(lines[i], " \t")
+			}
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
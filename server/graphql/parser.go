@@ -0,0 +1,512 @@
+package graphql
+
+import (
+	"strings"
+)
+
+const (
+	// MaxDepth is the maximum depth of nested selections and values.
+	MaxDepth = 32
+	// MaxFragments is the maximum number of fragment definitions.
+	MaxFragments = 100
+	// MaxSpreads is the maximum number of fragment spreads in a document.
+	MaxSpreads = 500
+)
+
+type parser struct {
+	lex     *lexer
+	tok     *token
+	depth   int
+	spreads int
+}
+
+// Parse parses the executable document.
+func Parse(src string) (*Document, error) {
+	p := &parser{lex: newLexer(src)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	doc := &Document{Fragments: make(map[string]*Fragment)}
+	for p.tok.kind != tokenEOF {
+		if p.peek(tokenPunct, "{") {
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+			continue
+		}
+		if p.tok.kind != tokenName {
+			return nil, p.unexpected()
+		}
+		switch p.tok.value {
+		case "query", "mutation", "subscription":
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case "fragment":
+			f, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.Fragments[f.Name]; ok {
+				return nil, newError(f.Loc, "Duplicate fragment %q", f.Name)
+			}
+			if len(doc.Fragments) >= MaxFragments {
+				return nil, newError(f.Loc, "Too many fragments (max=%d)", MaxFragments)
+			}
+			doc.Fragments[f.Name] = f
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.Operations) == 0 {
+		return nil, newError(p.tok.loc, "No operation")
+	}
+	return doc, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) peek(kind tokenKind, value string) bool {
+	return p.tok.kind == kind && p.tok.value == value
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return newError(p.tok.loc, "Unexpected <EOF>")
+	}
+	return newError(p.tok.loc, "Unexpected %s %q", p.tok.kind, p.tok.value)
+}
+
+func (p *parser) expect(kind tokenKind, value string) error {
+	if !p.peek(kind, value) {
+		return p.unexpected()
+	}
+	return p.advance()
+}
+
+// skip advances if the current token is the punctuator.
+func (p *parser) skip(value string) (bool, error) {
+	if !p.peek(tokenPunct, value) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+	name := p.tok.value
+	return name, p.advance()
+}
+
+func (p *parser) enter() error {
+	p.depth++
+	if p.depth > MaxDepth {
+		return newError(p.tok.loc, "Too deep (max=%d)", MaxDepth)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) parseOperation() (*Operation, error) {
+	op := &Operation{Type: "query", Loc: p.tok.loc}
+	if p.tok.kind == tokenName {
+		op.Type = p.tok.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokenName {
+			op.Name = p.tok.value
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+		if p.peek(tokenPunct, "(") {
+			vars, err := p.parseVariableDefinitions()
+			if err != nil {
+				return nil, err
+			}
+			op.Variables = vars
+		}
+		dirs, err := p.parseDirectives(true)
+		if err != nil {
+			return nil, err
+		}
+		op.Directives = dirs
+	}
+	sels, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.Selections = sels
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinitions() ([]*VariableDefinition, error) {
+	if err := p.expect(tokenPunct, "("); err != nil {
+		return nil, err
+	}
+	var defs []*VariableDefinition
+	for {
+		if ok, err := p.skip(")"); err != nil || ok {
+			return defs, err
+		}
+		def := &VariableDefinition{Loc: p.tok.loc}
+		if err := p.expect(tokenPunct, "$"); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		def.Name = name
+		if err := p.expect(tokenPunct, ":"); err != nil {
+			return nil, err
+		}
+		if def.Type, def.NonNull, err = p.parseType(); err != nil {
+			return nil, err
+		}
+		if ok, err := p.skip("="); err != nil {
+			return nil, err
+		} else if ok {
+			if def.Default, err = p.parseValue(true); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := p.parseDirectives(true); err != nil {
+			return nil, err
+		}
+		defs = append(defs, def)
+	}
+}
+
+// parseType returns the type in the document like "[Long!]" and whether
+// it's non-null type.
+func (p *parser) parseType() (string, bool, error) {
+	var sb strings.Builder
+	if ok, err := p.skip("["); err != nil {
+		return "", false, err
+	} else if ok {
+		if err := p.enter(); err != nil {
+			return "", false, err
+		}
+		t, nonNull, err := p.parseType()
+		p.leave()
+		if err != nil {
+			return "", false, err
+		}
+		if err := p.expect(tokenPunct, "]"); err != nil {
+			return "", false, err
+		}
+		sb.WriteString("[" + t)
+		if nonNull {
+			sb.WriteString("!")
+		}
+		sb.WriteString("]")
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return "", false, err
+		}
+		sb.WriteString(name)
+	}
+	nonNull, err := p.skip("!")
+	if err != nil {
+		return "", false, err
+	}
+	return sb.String(), nonNull, nil
+}
+
+func (p *parser) parseDirectives(constant bool) ([]*Directive, error) {
+	var dirs []*Directive
+	for p.peek(tokenPunct, "@") {
+		dir := &Directive{Loc: p.tok.loc}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		dir.Name = name
+		if dir.Arguments, err = p.parseArguments(constant); err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs, nil
+}
+
+func (p *parser) parseArguments(constant bool) ([]*Argument, error) {
+	if ok, err := p.skip("("); err != nil || !ok {
+		return nil, err
+	}
+	var args []*Argument
+	for {
+		if ok, err := p.skip(")"); err != nil || ok {
+			return args, err
+		}
+		arg := &Argument{Loc: p.tok.loc}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		arg.Name = name
+		if err := p.expect(tokenPunct, ":"); err != nil {
+			return nil, err
+		}
+		if arg.Value, err = p.parseValue(constant); err != nil {
+			return nil, err
+		}
+		for _, a := range args {
+			if a.Name == arg.Name {
+				return nil, newError(arg.Loc, "Duplicate argument %q", arg.Name)
+			}
+		}
+		args = append(args, arg)
+	}
+}
+
+func (p *parser) parseSelectionSet() ([]Selection, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	if err := p.expect(tokenPunct, "{"); err != nil {
+		return nil, err
+	}
+	var sels []Selection
+	for {
+		if ok, err := p.skip("}"); err != nil {
+			return nil, err
+		} else if ok {
+			if len(sels) == 0 {
+				return nil, newError(p.tok.loc, "Empty selection set")
+			}
+			return sels, nil
+		}
+		var sel Selection
+		var err error
+		if p.peek(tokenPunct, "...") {
+			sel, err = p.parseFragmentSelection()
+		} else {
+			sel, err = p.parseField()
+		}
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+}
+
+func (p *parser) parseField() (*Field, error) {
+	f := &Field{Loc: p.tok.loc}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip(":"); err != nil {
+		return nil, err
+	} else if ok {
+		f.Alias = name
+		if name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	f.Name = name
+	if f.Arguments, err = p.parseArguments(false); err != nil {
+		return nil, err
+	}
+	if f.Directives, err = p.parseDirectives(false); err != nil {
+		return nil, err
+	}
+	if p.peek(tokenPunct, "{") {
+		if f.Selections, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (p *parser) parseFragmentSelection() (Selection, error) {
+	loc := p.tok.loc
+	if err := p.expect(tokenPunct, "..."); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenName && p.tok.value != "on" {
+		p.spreads++
+		if p.spreads > MaxSpreads {
+			return nil, newError(loc, "Too many fragment spreads (max=%d)", MaxSpreads)
+		}
+		fs := &FragmentSpread{Name: p.tok.value, Loc: loc}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		dirs, err := p.parseDirectives(false)
+		if err != nil {
+			return nil, err
+		}
+		fs.Directives = dirs
+		return fs, nil
+	}
+	f := &InlineFragment{Loc: loc}
+	if p.peek(tokenName, "on") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		f.TypeCondition = name
+	}
+	var err error
+	if f.Directives, err = p.parseDirectives(false); err != nil {
+		return nil, err
+	}
+	if f.Selections, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (p *parser) parseFragment() (*Fragment, error) {
+	f := &Fragment{Loc: p.tok.loc}
+	if err := p.expect(tokenName, "fragment"); err != nil {
+		return nil, err
+	}
+	if p.peek(tokenName, "on") {
+		return nil, p.unexpected()
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	f.Name = name
+	if err := p.expect(tokenName, "on"); err != nil {
+		return nil, err
+	}
+	if f.TypeCondition, err = p.name(); err != nil {
+		return nil, err
+	}
+	if f.Directives, err = p.parseDirectives(false); err != nil {
+		return nil, err
+	}
+	if f.Selections, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (p *parser) parseValue(constant bool) (*Value, error) {
+	v := &Value{Loc: p.tok.loc, Raw: p.tok.value}
+	switch p.tok.kind {
+	case tokenInt:
+		v.Kind = ValueInt
+	case tokenFloat:
+		v.Kind = ValueFloat
+	case tokenString:
+		v.Kind = ValueString
+	case tokenName:
+		switch p.tok.value {
+		case "true", "false":
+			v.Kind = ValueBoolean
+		case "null":
+			v.Kind = ValueNull
+		default:
+			v.Kind = ValueEnum
+		}
+	case tokenPunct:
+		switch p.tok.value {
+		case "$":
+			if constant {
+				return nil, p.unexpected()
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			v.Kind, v.Raw = ValueVariable, name
+			return v, nil
+		case "[":
+			return p.parseList(v, constant)
+		case "{":
+			return p.parseObject(v, constant)
+		}
+		return nil, p.unexpected()
+	default:
+		return nil, p.unexpected()
+	}
+	return v, p.advance()
+}
+
+func (p *parser) parseList(v *Value, constant bool) (*Value, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	v.Kind, v.Raw = ValueList, ""
+	if err := p.expect(tokenPunct, "["); err != nil {
+		return nil, err
+	}
+	for {
+		if ok, err := p.skip("]"); err != nil || ok {
+			return v, err
+		}
+		item, err := p.parseValue(constant)
+		if err != nil {
+			return nil, err
+		}
+		v.List = append(v.List, item)
+	}
+}
+
+func (p *parser) parseObject(v *Value, constant bool) (*Value, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	v.Kind, v.Raw = ValueObject, ""
+	if err := p.expect(tokenPunct, "{"); err != nil {
+		return nil, err
+	}
+	for {
+		if ok, err := p.skip("}"); err != nil || ok {
+			return v, err
+		}
+		field := &Argument{Loc: p.tok.loc}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		field.Name = name
+		if err := p.expect(tokenPunct, ":"); err != nil {
+			return nil, err
+		}
+		if field.Value, err = p.parseValue(constant); err != nil {
+			return nil, err
+		}
+		v.Fields = append(v.Fields, field)
+	}
+}
@@ -0,0 +1,102 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_Basic(t *testing.T) {
+	doc, err := Parse(`
+		# comment
+		query Q($h: Long = 10, $hash: Bytes!) @skip(if: false) {
+			b: block(height: $h) {
+				hash, height
+				...F @include(if: true)
+				... on Block { version }
+				... { timestamp }
+			}
+			transaction(hash: $hash) { hash }
+		}
+		fragment F on Block { proposer }
+	`)
+	assert.NoError(t, err)
+	assert.Len(t, doc.Operations, 1)
+	op := doc.Operations[0]
+	assert.Equal(t, "query", op.Type)
+	assert.Equal(t, "Q", op.Name)
+	assert.Len(t, op.Variables, 2)
+	assert.Equal(t, "Long", op.Variables[0].Type)
+	assert.Equal(t, ValueInt, op.Variables[0].Default.Kind)
+	assert.Equal(t, "Bytes", op.Variables[1].Type)
+	assert.True(t, op.Variables[1].NonNull)
+	assert.Len(t, op.Directives, 1)
+
+	assert.Len(t, op.Selections, 2)
+	f := op.Selections[0].(*Field)
+	assert.Equal(t, "b", f.ResponseKey())
+	assert.Equal(t, "block", f.Name)
+	assert.Equal(t, ValueVariable, f.Arguments[0].Value.Kind)
+	assert.Equal(t, "h", f.Arguments[0].Value.Raw)
+	assert.Len(t, f.Selections, 5)
+	assert.Equal(t, "F", f.Selections[2].(*FragmentSpread).Name)
+	assert.Equal(t, "Block", f.Selections[3].(*InlineFragment).TypeCondition)
+	assert.Equal(t, "", f.Selections[4].(*InlineFragment).TypeCondition)
+	assert.Equal(t, Location{Line: 4, Column: 4}, f.Loc)
+
+	assert.Equal(t, "Block", doc.Fragments["F"].TypeCondition)
+}
+
+func TestParse_Values(t *testing.T) {
+	doc, err := Parse(`{ f(a: -12, b: 1.5e3, c: "x\nA\"", d: [1 [true]], e: {k: null, l: ENUM}, g: """
+		block
+		  string
+	""") }`)
+	assert.NoError(t, err)
+	args := doc.Operations[0].Selections[0].(*Field).Arguments
+	values, err := argumentsOf(args, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-12), values["a"])
+	assert.Equal(t, 1500.0, values["b"])
+	assert.Equal(t, "x\nA\"", values["c"])
+	assert.Equal(t, []interface{}{int64(1), []interface{}{true}}, values["d"])
+	assert.Equal(t, map[string]interface{}{"k": nil, "l": "ENUM"}, values["e"])
+	assert.Equal(t, "block\n  string", values["g"])
+}
+
+func manyFragments(n int) string {
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, " fragment F%d on T { a }", i)
+	}
+	return sb.String()
+}
+
+func TestParse_Errors(t *testing.T) {
+	cases := []struct {
+		src string
+		msg string
+	}{
+		{"", "No operation"},
+		{"{ a ", "Unexpected <EOF>"},
+		{"{ }", "Empty selection set"},
+		{"{ a(x: 1, x: 2) }", "Duplicate argument"},
+		{"{ a(x: 01.) }", "Invalid number"},
+		{`{ a(x: "abc) }`, "Unterminated string"},
+		{"{ a } fragment F on T { a } fragment F on T { b }", "Duplicate fragment"},
+		{"query ($x: Int = $y) { a }", "Unexpected"},
+		{"{ a ? }", "Unexpected character"},
+		{"type Query { a: Int }", "Unexpected Name"},
+		{strings.Repeat("{ a ", MaxDepth+1) + strings.Repeat("}", MaxDepth+1), "Too deep"},
+		{"{ a }" + manyFragments(MaxFragments+1), "Too many fragments"},
+		{"{ a" + strings.Repeat(" ...F", MaxSpreads+1) + " }", "Too many fragment spreads"},
+	}
+	for _, c := range cases {
+		_, err := Parse(c.src)
+		if assert.Error(t, err, c.src) {
+			assert.Contains(t, err.Error(), c.msg, c.src)
+		}
+	}
+}
@@ -0,0 +1,669 @@
+package graphql
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+// MaxBlocks is the maximum number of blocks queried by blocks field.
+var MaxBlocks int64 = 100
+
+// DefaultListSize is the number of items of the list assumed for
+// estimation of the cost if the arguments don't limit the size.
+var DefaultListSize = 100
+
+// resolveContext is shared by resolvers for a request. The last block
+// is pinned at the beginning of the request, so every field of the query
+// sees the same state even though new blocks are finalized meanwhile.
+type resolveContext struct {
+	chain  module.Chain
+	bm     module.BlockManager
+	sm     module.ServiceManager
+	height int64
+}
+
+func newResolveContext(chain module.Chain) (*resolveContext, error) {
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, errors.InvalidStateError.New("Stopped")
+	}
+	last, err := bm.GetLastBlock()
+	if err != nil {
+		return nil, err
+	}
+	return &resolveContext{
+		chain:  chain,
+		bm:     bm,
+		sm:     sm,
+		height: last.Height(),
+	}, nil
+}
+
+func (rc *resolveContext) checkHeight(height int64) error {
+	if height < 0 {
+		return errors.NotFoundError.Errorf("NegativeHeight(height=%d)", height)
+	}
+	base := rc.chain.GenesisStorage().Height()
+	if height < base {
+		return errors.NotFoundError.Errorf(
+			"PrunedBlock(height=%d,base=%d)", height, base)
+	}
+	return nil
+}
+
+// blockAt returns the block at the height. It returns nil for the height
+// after the pinned one.
+func (rc *resolveContext) blockAt(height int64) (module.Block, error) {
+	if height > rc.height {
+		return nil, nil
+	}
+	if err := rc.checkHeight(height); err != nil {
+		return nil, err
+	}
+	return rc.bm.GetBlockByHeight(height)
+}
+
+// heightOf returns the height in the argument, or the pinned height.
+func (rc *resolveContext) heightOf(args arguments) (int64, error) {
+	height, ok, err := args.Long("height")
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return rc.height, nil
+	}
+	return height, nil
+}
+
+func hexOf(bs []byte) interface{} {
+	if bs == nil {
+		return nil
+	}
+	return "0x" + hex.EncodeToString(bs)
+}
+
+func addressOf(addr module.Address) interface{} {
+	if addr == nil {
+		return nil
+	}
+	return addr.String()
+}
+
+// jsonOf converts the object into generic JSON value.
+func jsonOf(v interface{}) (map[string]interface{}, error) {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var jso map[string]interface{}
+	if err := json.Unmarshal(bs, &jso); err != nil {
+		return nil, err
+	}
+	return jso, nil
+}
+
+// paginate returns the range of items by first and skip arguments.
+func paginate(args arguments, size int) (int, int, error) {
+	skip, _, err := args.Int("skip")
+	if err != nil {
+		return 0, 0, err
+	}
+	first, ok, err := args.Int("first")
+	if err != nil {
+		return 0, 0, err
+	}
+	if skip < 0 || first < 0 {
+		return 0, 0, errors.IllegalArgumentError.Errorf(
+			"NegativeRange(first=%d,skip=%d)", first, skip)
+	}
+	start := skip
+	if start > size {
+		start = size
+	}
+	end := size
+	if ok && start+first < end {
+		end = start + first
+	}
+	return start, end, nil
+}
+
+// pageSize returns the maximum size of the page with first and skip.
+func pageSize(args arguments) int {
+	if first, ok, err := args.Int("first"); err == nil && ok && first >= 0 {
+		return first
+	}
+	return DefaultListSize
+}
+
+type txValue struct {
+	tx    module.Transaction
+	blk   module.Block
+	index int
+	jso   map[string]interface{}
+}
+
+func (t *txValue) field(name string) (interface{}, error) {
+	if t.jso == nil {
+		obj, err := t.tx.ToJSON(module.JSONVersion3)
+		if err != nil {
+			return nil, err
+		}
+		if t.jso, err = jsonOf(obj); err != nil {
+			return nil, err
+		}
+	}
+	return t.jso[name], nil
+}
+
+type receiptValue struct {
+	rct module.Receipt
+	tx  *txValue
+}
+
+type eventLogValue struct {
+	el    module.EventLog
+	index int
+}
+
+type accountValue struct {
+	addr module.Address
+	blk  module.Block
+}
+
+func transactionsOf(blk module.Block) ([]interface{}, error) {
+	var txs []interface{}
+	for it := blk.NormalTransactions().Iterator(); it.Has(); it.Next() {
+		tx, index, err := it.Get()
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, &txValue{tx: tx, blk: blk, index: index})
+	}
+	return txs, nil
+}
+
+func txField(name string, typ string) *fieldDef {
+	return &fieldDef{
+		typ:  typ,
+		cost: 1,
+		resolve: func(rc *resolveContext, src interface{}, args arguments) (interface{}, error) {
+			return src.(*txValue).field(name)
+		},
+	}
+}
+
+func property(typ string, get func(src interface{}) (interface{}, error)) *fieldDef {
+	return &fieldDef{
+		typ:  typ,
+		cost: 1,
+		resolve: func(rc *resolveContext, src interface{}, args arguments) (interface{}, error) {
+			return get(src)
+		},
+	}
+}
+
+var pageArgs = map[string]string{"first": TypeInt, "skip": TypeInt}
+
+var queryType = &objectType{
+	name: "Query",
+	fields: map[string]*fieldDef{
+		"height": {
+			typ:  TypeLong,
+			cost: 1,
+			resolve: func(rc *resolveContext, src interface{}, args arguments) (interface{}, error) {
+				return rc.height, nil
+			},
+		},
+		"block": {
+			typ:     "Block",
+			args:    map[string]string{"height": TypeLong, "hash": TypeBytes},
+			cost:    10,
+			resolve: resolveBlock,
+		},
+		"blocks": {
+			typ:      "Block",
+			list:     true,
+			args:     map[string]string{"from": TypeLong + "!", "to": TypeLong},
+			cost:     1,
+			itemCost: 10,
+			size:     blocksSize,
+			resolve:  resolveBlocks,
+		},
+		"transaction": {
+			typ:     "Transaction",
+			args:    map[string]string{"hash": TypeBytes + "!"},
+			cost:    10,
+			resolve: resolveTransaction,
+		},
+		"account": {
+			typ:     "Account",
+			args:    map[string]string{"address": TypeAddress + "!", "height": TypeLong},
+			cost:    10,
+			resolve: resolveAccount,
+		},
+	},
+}
+
+func resolveBlock(rc *resolveContext, _ interface{}, args arguments) (interface{}, error) {
+	hash, ok, err := args.Bytes("hash")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if _, ok := args["height"]; ok {
+			return nil, errors.IllegalArgumentError.New("BothHeightAndHash")
+		}
+		blk, err := rc.bm.GetBlock(hash)
+		if errors.NotFoundError.Equals(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if blk.Height() > rc.height {
+			return nil, nil
+		}
+		return blk, nil
+	}
+	height, err := rc.heightOf(args)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := rc.blockAt(height)
+	if blk == nil || err != nil {
+		return nil, err
+	}
+	return blk, nil
+}
+
+// blocksSize returns the maximum number of blocks with from and to.
+func blocksSize(args arguments) int {
+	from, _, err1 := args.Long("from")
+	to, ok, err2 := args.Long("to")
+	if err1 != nil || err2 != nil || !ok {
+		return int(MaxBlocks)
+	}
+	if from > to {
+		return 0
+	}
+	if n := to - from + 1; n > 0 && n <= MaxBlocks {
+		return int(n)
+	}
+	return int(MaxBlocks)
+}
+
+func resolveBlocks(rc *resolveContext, _ interface{}, args arguments) (interface{}, error) {
+	from, _, err := args.Long("from")
+	if err != nil {
+		return nil, err
+	}
+	to, ok, err := args.Long("to")
+	if err != nil {
+		return nil, err
+	}
+	if !ok || to > rc.height {
+		to = rc.height
+	}
+	if from > to {
+		return []interface{}{}, nil
+	}
+	if to-from+1 > MaxBlocks {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"TooManyBlocks(from=%d,to=%d,limit=%d)", from, to, MaxBlocks)
+	}
+	if err := rc.checkHeight(from); err != nil {
+		return nil, err
+	}
+	blks := make([]interface{}, 0, to-from+1)
+	for h := from; h <= to; h++ {
+		blk, err := rc.bm.GetBlockByHeight(h)
+		if err != nil {
+			return nil, err
+		}
+		blks = append(blks, blk)
+	}
+	return blks, nil
+}
+
+func resolveTransaction(rc *resolveContext, _ interface{}, args arguments) (interface{}, error) {
+	hash, _, err := args.Bytes("hash")
+	if err != nil {
+		return nil, err
+	}
+	txInfo, err := rc.bm.GetTransactionInfo(hash)
+	if errors.NotFoundError.Equals(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	blk := txInfo.Block()
+	if blk.Height() > rc.height {
+		return nil, nil
+	}
+	if err := rc.checkHeight(blk.Height()); err != nil {
+		return nil, err
+	}
+	tx, err := txInfo.Transaction()
+	if err != nil {
+		return nil, err
+	}
+	return &txValue{tx: tx, blk: blk, index: txInfo.Index()}, nil
+}
+
+func resolveAccount(rc *resolveContext, _ interface{}, args arguments) (interface{}, error) {
+	addr, _, err := args.Address("address")
+	if err != nil {
+		return nil, err
+	}
+	height, err := rc.heightOf(args)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := rc.blockAt(height)
+	if blk == nil || err != nil {
+		return nil, err
+	}
+	return &accountValue{addr: addr, blk: blk}, nil
+}
+
+var blockType = &objectType{
+	name: "Block",
+	fields: map[string]*fieldDef{
+		"height": property(TypeLong, func(src interface{}) (interface{}, error) {
+			return src.(module.Block).Height(), nil
+		}),
+		"hash": property(TypeBytes, func(src interface{}) (interface{}, error) {
+			return hexOf(src.(module.Block).ID()), nil
+		}),
+		"parentHash": property(TypeBytes, func(src interface{}) (interface{}, error) {
+			return hexOf(src.(module.Block).PrevID()), nil
+		}),
+		"version": property(TypeInt, func(src interface{}) (interface{}, error) {
+			return src.(module.Block).Version(), nil
+		}),
+		"timestamp": property(TypeLong, func(src interface{}) (interface{}, error) {
+			return src.(module.Block).Timestamp(), nil
+		}),
+		"proposer": property(TypeAddress, func(src interface{}) (interface{}, error) {
+			return addressOf(src.(module.Block).Proposer()), nil
+		}),
+		"nextValidatorsHash": property(TypeBytes, func(src interface{}) (interface{}, error) {
+			return hexOf(src.(module.Block).NextValidatorsHash()), nil
+		}),
+		"transactionCount": {
+			typ:  TypeInt,
+			cost: 5,
+			resolve: func(rc *resolveContext, src interface{}, args arguments) (interface{}, error) {
+				txs, err := transactionsOf(src.(module.Block))
+				if err != nil {
+					return nil, err
+				}
+				return len(txs), nil
+			},
+		},
+		"transactions": {
+			typ:      "Transaction",
+			list:     true,
+			args:     pageArgs,
+			cost:     5,
+			itemCost: 1,
+			size:     pageSize,
+			resolve: func(rc *resolveContext, src interface{}, args arguments) (interface{}, error) {
+				txs, err := transactionsOf(src.(module.Block))
+				if err != nil {
+					return nil, err
+				}
+				start, end, err := paginate(args, len(txs))
+				if err != nil {
+					return nil, err
+				}
+				return append([]interface{}{}, txs[start:end]...), nil
+			},
+		},
+	},
+}
+
+var transactionType = &objectType{
+	name: "Transaction",
+	fields: map[string]*fieldDef{
+		"hash": property(TypeBytes, func(src interface{}) (interface{}, error) {
+			return hexOf(src.(*txValue).tx.ID()), nil
+		}),
+		"from": property(TypeAddress, func(src interface{}) (interface{}, error) {
+			return addressOf(src.(*txValue).tx.From()), nil
+		}),
+		"version":   txField("version", TypeBigInt),
+		"to":        txField("to", TypeAddress),
+		"value":     txField("value", TypeBigInt),
+		"stepLimit": txField("stepLimit", TypeBigInt),
+		"timestamp": txField("timestamp", TypeBigInt),
+		"nid":       txField("nid", TypeBigInt),
+		"nonce":     txField("nonce", TypeBigInt),
+		"signature": txField("signature", TypeString),
+		"dataType":  txField("dataType", TypeString),
+		"data":      txField("data", TypeJSON),
+		"index": property(TypeInt, func(src interface{}) (interface{}, error) {
+			return src.(*txValue).index, nil
+		}),
+		"block": property("Block", func(src interface{}) (interface{}, error) {
+			return src.(*txValue).blk, nil
+		}),
+		"receipt": {
+			typ:     "Receipt",
+			cost:    10,
+			resolve: resolveReceipt,
+		},
+	},
+}
+
+// resolveReceipt returns the receipt of the transaction. Results of
+// transactions are in the next block, so it's null if the next block is
+// after the pinned one.
+func resolveReceipt(rc *resolveContext, src interface{}, _ arguments) (interface{}, error) {
+	tv := src.(*txValue)
+	height := tv.blk.Height() + 1
+	if height > rc.height {
+		return nil, nil
+	}
+	rblk, err := rc.bm.GetBlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	rl, err := rc.sm.ReceiptListFromResult(rblk.Result(), tv.tx.Group())
+	if err != nil {
+		return nil, err
+	}
+	rct, err := rl.Get(tv.index)
+	if block.ResultNotFinalizedError.Equals(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &receiptValue{rct: rct, tx: tv}, nil
+}
+
+var receiptType = &objectType{
+	name: "Receipt",
+	fields: map[string]*fieldDef{
+		"status": property(TypeInt, func(src interface{}) (interface{}, error) {
+			if src.(*receiptValue).rct.Status() == module.StatusSuccess {
+				return 1, nil
+			}
+			return 0, nil
+		}),
+		"failure": property(TypeJSON, func(src interface{}) (interface{}, error) {
+			jso, err := jsonOf(src.(*receiptValue).rct)
+			if err != nil {
+				return nil, err
+			}
+			return jso["failure"], nil
+		}),
+		"to": property(TypeAddress, func(src interface{}) (interface{}, error) {
+			return addressOf(src.(*receiptValue).rct.To()), nil
+		}),
+		"scoreAddress": property(TypeAddress, func(src interface{}) (interface{}, error) {
+			return addressOf(src.(*receiptValue).rct.SCOREAddress()), nil
+		}),
+		"stepUsed": property(TypeBigInt, func(src interface{}) (interface{}, error) {
+			return intconv.FormatBigInt(src.(*receiptValue).rct.StepUsed()), nil
+		}),
+		"stepPrice": property(TypeBigInt, func(src interface{}) (interface{}, error) {
+			return intconv.FormatBigInt(src.(*receiptValue).rct.StepPrice()), nil
+		}),
+		"cumulativeStepUsed": property(TypeBigInt, func(src interface{}) (interface{}, error) {
+			return intconv.FormatBigInt(src.(*receiptValue).rct.CumulativeStepUsed()), nil
+		}),
+		"logsBloom": property(TypeBytes, func(src interface{}) (interface{}, error) {
+			return hexOf(src.(*receiptValue).rct.LogsBloom().Bytes()), nil
+		}),
+		"transaction": property("Transaction", func(src interface{}) (interface{}, error) {
+			return src.(*receiptValue).tx, nil
+		}),
+		"eventLogs": {
+			typ:      "EventLog",
+			list:     true,
+			args:     pageArgs,
+			cost:     1,
+			itemCost: 1,
+			size:     pageSize,
+			resolve: func(rc *resolveContext, src interface{}, args arguments) (interface{}, error) {
+				var logs []interface{}
+				for it := src.(*receiptValue).rct.EventLogIterator(); it.Has(); it.Next() {
+					el, err := it.Get()
+					if err != nil {
+						return nil, err
+					}
+					logs = append(logs, &eventLogValue{el: el, index: len(logs)})
+				}
+				start, end, err := paginate(args, len(logs))
+				if err != nil {
+					return nil, err
+				}
+				return append([]interface{}{}, logs[start:end]...), nil
+			},
+		},
+	},
+}
+
+func eventLogField(name string) func(src interface{}) (interface{}, error) {
+	return func(src interface{}) (interface{}, error) {
+		el := src.(*eventLogValue).el
+		jso, err := jsonOf(txresult.EventLogToJSON(el.Address(), el.Indexed(), el.Data()))
+		if err != nil {
+			return nil, err
+		}
+		return jso[name], nil
+	}
+}
+
+var eventLogType = &objectType{
+	name: "EventLog",
+	fields: map[string]*fieldDef{
+		"scoreAddress": property(TypeAddress, func(src interface{}) (interface{}, error) {
+			return addressOf(src.(*eventLogValue).el.Address()), nil
+		}),
+		"signature": property(TypeString, func(src interface{}) (interface{}, error) {
+			indexed := src.(*eventLogValue).el.Indexed()
+			if len(indexed) == 0 {
+				return nil, nil
+			}
+			return string(indexed[0]), nil
+		}),
+		"indexed": property(TypeJSON, eventLogField("indexed")),
+		"data":    property(TypeJSON, eventLogField("data")),
+		"index": property(TypeInt, func(src interface{}) (interface{}, error) {
+			return src.(*eventLogValue).index, nil
+		}),
+	},
+}
+
+var accountType = &objectType{
+	name: "Account",
+	fields: map[string]*fieldDef{
+		"address": property(TypeAddress, func(src interface{}) (interface{}, error) {
+			return addressOf(src.(*accountValue).addr), nil
+		}),
+		"height": property(TypeLong, func(src interface{}) (interface{}, error) {
+			return src.(*accountValue).blk.Height(), nil
+		}),
+		"isContract": property(TypeBoolean, func(src interface{}) (interface{}, error) {
+			return src.(*accountValue).addr.IsContract(), nil
+		}),
+		"balance": {
+			typ:  TypeBigInt,
+			cost: 10,
+			resolve: func(rc *resolveContext, src interface{}, args arguments) (interface{}, error) {
+				av := src.(*accountValue)
+				balance, err := rc.sm.GetBalance(av.blk.Result(), av.addr)
+				if err != nil {
+					return nil, err
+				}
+				return intconv.FormatBigInt(balance), nil
+			},
+		},
+		"score": {
+			typ:     "Score",
+			cost:    10,
+			resolve: resolveScore,
+		},
+	},
+}
+
+// resolveScore returns the status of the SCORE. It's null for the account
+// which is not a contract.
+func resolveScore(rc *resolveContext, src interface{}, _ arguments) (interface{}, error) {
+	av := src.(*accountValue)
+	if !av.addr.IsContract() {
+		return nil, nil
+	}
+	status, err := rc.sm.GetSCOREStatus(av.blk.Result(), av.addr)
+	if errors.NotFoundError.Equals(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	obj, err := status.ToJSON(av.blk.Height(), module.JSONVersion3)
+	if err != nil {
+		return nil, err
+	}
+	return jsonOf(obj)
+}
+
+func scoreField(name string, typ string) *fieldDef {
+	return property(typ, func(src interface{}) (interface{}, error) {
+		v := src.(map[string]interface{})[name]
+		if typ == TypeBoolean {
+			return v == "0x1", nil
+		}
+		return v, nil
+	})
+}
+
+var scoreType = &objectType{
+	name: "Score",
+	fields: map[string]*fieldDef{
+		"owner":            scoreField("owner", TypeAddress),
+		"current":          scoreField("current", TypeJSON),
+		"next":             scoreField("next", TypeJSON),
+		"depositInfo":      scoreField("depositInfo", TypeJSON),
+		"disabled":         scoreField("disabled", TypeBoolean),
+		"blocked":          scoreField("blocked", TypeBoolean),
+		"useSystemDeposit": scoreField("useSystemDeposit", TypeBoolean),
+	},
+}
+
+var chainSchema = newSchema("Query",
+	queryType,
+	blockType,
+	transactionType,
+	receiptType,
+	eventLogType,
+	accountType,
+	scoreType,
+)
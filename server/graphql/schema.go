@@ -0,0 +1,225 @@
+package graphql
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+)
+
+// Names of scalar types.
+const (
+	TypeInt     = "Int"
+	TypeLong    = "Long"
+	TypeBigInt  = "BigInt"
+	TypeString  = "String"
+	TypeBoolean = "Boolean"
+	TypeBytes   = "Bytes"
+	TypeAddress = "Address"
+	TypeJSON    = "JSON"
+)
+
+type resolver func(rc *resolveContext, src interface{}, args arguments) (interface{}, error)
+
+// sizer returns the maximum number of items of the list field with the
+// arguments. It's used to estimate the cost before execution.
+type sizer func(args arguments) int
+
+// fieldDef is the definition of the field. Cost is charged for each
+// resolution of the field, and itemCost is charged for each item of the
+// list additionally. Size of the list is DefaultListSize if size is nil.
+type fieldDef struct {
+	typ      string
+	list     bool
+	args     map[string]string
+	cost     int
+	itemCost int
+	size     sizer
+	resolve  resolver
+}
+
+type objectType struct {
+	name   string
+	fields map[string]*fieldDef
+}
+
+type schema struct {
+	query *objectType
+	types map[string]*objectType
+}
+
+func newSchema(query string, types ...*objectType) *schema {
+	s := &schema{types: make(map[string]*objectType)}
+	for _, t := range types {
+		s.types[t.name] = t
+	}
+	s.query = s.types[query]
+	return s
+}
+
+// objectOf returns the object type of the field. It returns nil if the
+// type of the field is a scalar type.
+func (s *schema) objectOf(fd *fieldDef) *objectType {
+	return s.types[fd.typ]
+}
+
+// arguments has values of arguments after substitution of variables.
+// Values are one of nil, bool, int64, float64, json.Number, string,
+// []interface{} and map[string]interface{}.
+type arguments map[string]interface{}
+
+func valueOf(v *Value, vars map[string]interface{}) (interface{}, error) {
+	switch v.Kind {
+	case ValueVariable:
+		return vars[v.Raw], nil
+	case ValueInt:
+		i, err := strconv.ParseInt(v.Raw, 10, 64)
+		if err != nil {
+			return json.Number(v.Raw), nil
+		}
+		return i, nil
+	case ValueFloat:
+		f, err := strconv.ParseFloat(v.Raw, 64)
+		if err != nil {
+			return nil, newError(v.Loc, "Invalid float %s", v.Raw)
+		}
+		return f, nil
+	case ValueString, ValueEnum:
+		return v.Raw, nil
+	case ValueBoolean:
+		return v.Raw == "true", nil
+	case ValueNull:
+		return nil, nil
+	case ValueList:
+		list := make([]interface{}, len(v.List))
+		for i, item := range v.List {
+			value, err := valueOf(item, vars)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = value
+		}
+		return list, nil
+	case ValueObject:
+		obj := make(map[string]interface{}, len(v.Fields))
+		for _, f := range v.Fields {
+			value, err := valueOf(f.Value, vars)
+			if err != nil {
+				return nil, err
+			}
+			obj[f.Name] = value
+		}
+		return obj, nil
+	default:
+		return nil, newError(v.Loc, "Unknown value")
+	}
+}
+
+func argumentsOf(args []*Argument, vars map[string]interface{}) (arguments, error) {
+	values := make(arguments, len(args))
+	for _, arg := range args {
+		v, err := valueOf(arg.Value, vars)
+		if err != nil {
+			return nil, err
+		}
+		values[arg.Name] = v
+	}
+	return values, nil
+}
+
+// Long returns the integer value of the argument. Integers in string
+// (decimal or hexadecimal with 0x prefix) are also accepted.
+func (a arguments) Long(name string) (int64, bool, error) {
+	switch v := a[name].(type) {
+	case nil:
+		return 0, false, nil
+	case int64:
+		return v, true, nil
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > math.MaxInt64 {
+			return 0, false, errors.IllegalArgumentError.Errorf("InvalidLong(%s=%v)", name, v)
+		}
+		return int64(v), true, nil
+	case json.Number:
+		i, err := v.Int64()
+		if err != nil {
+			return 0, false, errors.IllegalArgumentError.Errorf("InvalidLong(%s=%s)", name, v)
+		}
+		return i, true, nil
+	case string:
+		i, err := intconv.ParseInt(v, 64)
+		if err != nil {
+			return 0, false, errors.IllegalArgumentError.Errorf("InvalidLong(%s=%s)", name, v)
+		}
+		return i, true, nil
+	default:
+		return 0, false, errors.IllegalArgumentError.Errorf("InvalidLong(%s=%v)", name, v)
+	}
+}
+
+// Int returns the integer value of the argument in range of 32 bits.
+func (a arguments) Int(name string) (int, bool, error) {
+	v, ok, err := a.Long(name)
+	if err != nil || !ok {
+		return 0, ok, err
+	}
+	if v > math.MaxInt32 || v < math.MinInt32 {
+		return 0, false, errors.IllegalArgumentError.Errorf("InvalidInt(%s=%d)", name, v)
+	}
+	return int(v), true, nil
+}
+
+func (a arguments) String(name string) (string, bool, error) {
+	switch v := a[name].(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return v, true, nil
+	default:
+		return "", false, errors.IllegalArgumentError.Errorf("InvalidString(%s=%v)", name, v)
+	}
+}
+
+// Bytes returns bytes of the argument in hexadecimal with 0x prefix.
+func (a arguments) Bytes(name string) ([]byte, bool, error) {
+	s, ok, err := a.String(name)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	if !strings.HasPrefix(s, "0x") {
+		return nil, false, errors.IllegalArgumentError.Errorf("InvalidBytes(%s=%s)", name, s)
+	}
+	bs, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return nil, false, errors.IllegalArgumentError.Errorf("InvalidBytes(%s=%s)", name, s)
+	}
+	return bs, true, nil
+}
+
+func (a arguments) Address(name string) (*common.Address, bool, error) {
+	s, ok, err := a.String(name)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	addr, err := common.NewAddressFromString(s)
+	if err != nil {
+		return nil, false, errors.IllegalArgumentError.Errorf("InvalidAddress(%s=%s)", name, s)
+	}
+	return addr, true, nil
+}
+
+func (a arguments) Boolean(name string) (bool, bool, error) {
+	switch v := a[name].(type) {
+	case nil:
+		return false, false, nil
+	case bool:
+		return v, true, nil
+	default:
+		return false, false, errors.IllegalArgumentError.Errorf("InvalidBoolean(%s=%v)", name, v)
+	}
+}
@@ -72,6 +72,12 @@ func (c ErrorCode) String() string {
 		return "Timeout"
 	case ErrorCodeSystemTimeout:
 		return "SystemTimeout"
+	case ErrorCodeSenderLimit:
+		return "SenderLimit"
+	case ErrorCodeRateLimit:
+		return "RateLimit"
+	case ErrorCodeCanceled:
+		return "Canceled"
 	default:
 		switch {
 		case c < ErrorCodeServer && c > ErrorCodeServer-1000:
@@ -104,6 +110,9 @@ const (
 	ErrorLackOfResource     ErrorCode = -31005
 	ErrorCodeTimeout        ErrorCode = -31006
 	ErrorCodeSystemTimeout  ErrorCode = -31007
+	ErrorCodeSenderLimit    ErrorCode = -31008
+	ErrorCodeRateLimit      ErrorCode = -31009
+	ErrorCodeCanceled       ErrorCode = -31010
 )
 
 type Error struct {
@@ -32,7 +32,8 @@ func TestErrorCode_String(t *testing.T) {
 		{"ServerError(001)", ErrorCodeServer - 1, "ServerError(-32001)"},
 		{"ServerError(999)", ErrorCodeServer - 999, "ServerError(-32999)"},
 		{"SystemError", ErrorCodeSystem, "SystemError"},
-		{"SystemError(010)", ErrorCodeSystem - 10, "SystemError(-31010)"},
+		{"Canceled", ErrorCodeCanceled, "Canceled"},
+		{"SystemError(100)", ErrorCodeSystem - 100, "SystemError(-31100)"},
 		{"SystemError(999)", ErrorCodeSystem - 999, "SystemError(-31999)"},
 		{"SCOREError(0)", ErrorCodeScore, "SCOREError(-30000)"},
 		{"SCOREError(1)", ErrorCodeScore - 1, "SCOREError(-30001)"},
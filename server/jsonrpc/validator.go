@@ -13,6 +13,7 @@ var (
 	hexInt            = regexp.MustCompile("^0x(0|[1-9a-f][0-9a-f]*)$")
 	hashRegex         = regexp.MustCompile("^0x[0-9a-f]{64}$")
 	rosettaHashRegex  = regexp.MustCompile("^[0b]x[0-9a-f]{64}$")
+	hexBytesRegex     = regexp.MustCompile("^0x([0-9a-f]{2})+$")
 )
 
 type Validator struct {
@@ -34,6 +35,7 @@ func NewValidator() *Validator {
 	v.RegisterValidation("t_int", isHexInt)
 	v.RegisterValidation("t_hash", isHash)
 	v.RegisterValidation("t_rhash", isRosettaHash)
+	v.RegisterValidation("t_bytes", isHexBytes)
 
 	v.RegisterAlias("t_sig", "base64")
 	v.RegisterAlias("t_addr", "t_addr_eoa|t_addr_score")
@@ -85,3 +87,7 @@ func isHash(fl validator.FieldLevel) bool {
 func isRosettaHash(fl validator.FieldLevel) bool {
 	return rosettaHashRegex.MatchString(fl.Field().String())
 }
+
+func isHexBytes(fl validator.FieldLevel) bool {
+	return hexBytesRegex.MatchString(fl.Field().String())
+}
@@ -22,8 +22,11 @@ var (
 		msAvg: stats.Int64("jsonrpc_retrieve_avg", "moving average of jsonrpc retrieve methods", "ns"),
 		mks:   []tag.Key{mkMethod},
 	}
-	emptyMks = []tag.Key{}
-	msMap    = map[string]*measure{
+	mkGroup       = NewMetricKey("group")
+	mkThrottledBy = NewMetricKey("by")
+	msThrottled   = stats.Int64("jsonrpc_throttled", "jsonrpc requests throttled by rate limit", stats.UnitDimensionless)
+	emptyMks      = []tag.Key{}
+	msMap         = map[string]*measure{
 		"icx_getLastBlock":     msRetrieve,
 		"icx_getBlockByHeight": msRetrieve,
 		"icx_getBlockByHash":   msRetrieve,
@@ -69,11 +72,40 @@ var (
 			stats.Int64("jsonrpc_get_trace_avg", "moving average of jsonrpc debug_getTrace method", "ns"),
 			emptyMks,
 		},
+		"debug_getBlockStateDiff": {
+			stats.Int64("jsonrpc_get_block_state_diff", "jsonrpc debug_getBlockStateDiff method", "ns"),
+			stats.Int64("jsonrpc_get_block_state_diff_avg", "moving average of jsonrpc debug_getBlockStateDiff method", "ns"),
+			emptyMks,
+		},
+		"debug_traceBlocks": {
+			stats.Int64("jsonrpc_trace_blocks", "jsonrpc debug_traceBlocks method", "ns"),
+			stats.Int64("jsonrpc_trace_blocks_avg", "moving average of jsonrpc debug_traceBlocks method", "ns"),
+			emptyMks,
+		},
 		"debug_estimateStep": {
 			stats.Int64("jsonrpc_estimate_step", "jsonrpc debug_estimateStep method", "ns"),
 			stats.Int64("jsonrpc_estimate_step_avg", "moving average of jsonrpc debug_estimateStep method", "ns"),
 			emptyMks,
 		},
+		"debug_traceCall": {
+			stats.Int64("jsonrpc_trace_call", "jsonrpc debug_traceCall method", "ns"),
+			stats.Int64("jsonrpc_trace_call_avg", "moving average of jsonrpc debug_traceCall method", "ns"),
+			emptyMks,
+		},
+		"web3_clientVersion":        msRetrieve,
+		"web3_sha3":                 msRetrieve,
+		"net_version":               msRetrieve,
+		"net_listening":             msRetrieve,
+		"eth_chainId":               msRetrieve,
+		"eth_blockNumber":           msRetrieve,
+		"eth_getBlockByNumber":      msRetrieve,
+		"eth_getBalance":            msRetrieve,
+		"eth_getTransactionReceipt": msRetrieve,
+		"eth_getLogs": {
+			stats.Int64("jsonrpc_eth_get_logs", "jsonrpc eth_getLogs method", "ns"),
+			stats.Int64("jsonrpc_eth_get_logs_avg", "moving average of jsonrpc eth_getLogs method", "ns"),
+			emptyMks,
+		},
 		"rosetta_getTrace": {
 			stats.Int64("jsonrpc_rosetta_trace_", "jsonrpc rosetta_getTrace method", "ns"),
 			stats.Int64("jsonrpc_rosetta_trace_avg", "moving average of jsonrpc rosetta_getTTrace method", "ns"),
@@ -100,6 +132,7 @@ func RegisterJsonrpc() {
 	RegisterMetricView(msFailure.msAvg, view.LastValue(), emptyMks)
 	RegisterMetricView(msRetrieve.ms, view.Count(), msRetrieve.mks)
 	RegisterMetricView(msRetrieve.msAvg, view.LastValue(), emptyMks)
+	RegisterMetricView(msThrottled, view.Count(), []tag.Key{mkGroup, mkThrottledBy})
 	for _, v := range msMap {
 		if v != msRetrieve {
 			RegisterMetricView(v.ms, view.Count(), v.mks)
@@ -197,6 +230,14 @@ func (m *JsonrpcMetric) OnHandle(ctx context.Context, method string, ts time.Tim
 	jm.RemoveAndRecord(ctx, ts, m.expire)
 }
 
+// OnThrottle counts the request of the method group throttled by the limit
+// for the client IP or the API key.
+func (m *JsonrpcMetric) OnThrottle(group, by string) {
+	ctx := GetMetricContext(DefaultMetricContext(), &mkGroup, group)
+	ctx = GetMetricContext(ctx, &mkThrottledBy, by)
+	stats.Record(ctx, msThrottled.M(1))
+}
+
 func NewJsonrpcMetric(expire time.Duration, durationsSize int, useDefault bool) *JsonrpcMetric {
 	jmsMtx.Lock()
 	defer jmsMtx.Unlock()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
@@ -25,10 +26,19 @@ func RegisterNetwork() {
 	RegisterMetricView(msRecv, view.Sum(), networkMks)
 }
 
+// ProtocolCounter is the number of bytes sent and received for a protocol.
+type ProtocolCounter struct {
+	Send int64 `json:"send"`
+	Recv int64 `json:"recv"`
+}
+
 type NetworkMetric struct {
 	ctx    context.Context
 	ctxMap map[string]context.Context
 	ctxMtx sync.RWMutex
+
+	counters   map[uint16]*ProtocolCounter
+	counterMtx sync.RWMutex
 }
 
 func (m *NetworkMetric) get(key string) (context.Context, bool) {
@@ -59,19 +69,49 @@ func (m *NetworkMetric) getMetricContext(dest byte, ttl byte, hint byte, protoco
 	return ctx
 }
 
+func (m *NetworkMetric) counter(protocol uint16) *ProtocolCounter {
+	m.counterMtx.RLock()
+	c, ok := m.counters[protocol]
+	m.counterMtx.RUnlock()
+	if ok {
+		return c
+	}
+
+	m.counterMtx.Lock()
+	defer m.counterMtx.Unlock()
+	if c, ok = m.counters[protocol]; !ok {
+		c = &ProtocolCounter{}
+		m.counters[protocol] = c
+	}
+	return c
+}
+
 func (m *NetworkMetric) OnSend(dest byte, ttl byte, hint byte, protocol uint16, pktLen uint32) {
 	ctx := m.getMetricContext(dest, ttl, hint, protocol)
 	stats.Record(ctx, msSend.M(int64(pktLen)))
+	atomic.AddInt64(&m.counter(protocol).Send, int64(pktLen))
 }
 
 func (m *NetworkMetric) OnRecv(dest byte, ttl byte, hint byte, protocol uint16, pktLen uint32) {
 	ctx := m.getMetricContext(dest, ttl, hint, protocol)
 	stats.Record(ctx, msRecv.M(int64(pktLen)))
+	atomic.AddInt64(&m.counter(protocol).Recv, int64(pktLen))
+}
+
+// ProtocolCounter returns the number of bytes sent and received for the
+// protocol.
+func (m *NetworkMetric) ProtocolCounter(protocol uint16) ProtocolCounter {
+	c := m.counter(protocol)
+	return ProtocolCounter{
+		Send: atomic.LoadInt64(&c.Send),
+		Recv: atomic.LoadInt64(&c.Recv),
+	}
 }
 
 func NewNetworkMetric(ctx context.Context) *NetworkMetric {
 	return &NetworkMetric{
-		ctx: ctx,
-		ctxMap: make(map[string]context.Context),
+		ctx:      ctx,
+		ctxMap:   make(map[string]context.Context),
+		counters: make(map[uint16]*ProtocolCounter),
 	}
 }
\ No newline at end of file
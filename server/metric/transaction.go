@@ -22,6 +22,12 @@ var (
 	msAddUserTx     = stats.Int64("txpool_user_add", "Add User Transaction", stats.UnitBytes)
 	msRemoveUserTx  = stats.Int64("txpool_user_remove", "Remove User Transaction", stats.UnitBytes)
 	msDropUserTx    = stats.Int64("txpool_user_drop", "Drop User Transaction", stats.UnitBytes)
+	msReplaceTx     = stats.Int64("txpool_replace", "Replace Transaction", stats.UnitBytes)
+	msCancelTx      = stats.Int64("txpool_cancel", "Cancel Transaction", stats.UnitBytes)
+	msReplaceUserTx = stats.Int64("txpool_user_replace", "Replace User Transaction", stats.UnitBytes)
+	msCancelUserTx  = stats.Int64("txpool_user_cancel", "Cancel User Transaction", stats.UnitBytes)
+	msRejectUserTx  = stats.Int64("txpool_user_reject", "Reject User Transaction by Sender Limit", stats.UnitBytes)
+	msRejectTx      = stats.Int64("txpool_reject", "Reject Transaction by Sender Limit", stats.UnitBytes)
 	msFinLatency    = stats.Int64("txlatency_finalize", "Finalize Transaction Latency", stats.UnitMilliseconds)
 	msCommitLatency = stats.Int64("txlatency_commit", "Commit Transaction Latency", stats.UnitMilliseconds)
 	mkTxType        = NewMetricKey("tx_type")
@@ -41,6 +47,18 @@ func RegisterTransaction() {
 	RegisterMetricView(msRemoveUserTx, view.Sum(), txPoolMks)
 	RegisterMetricView(msDropUserTx, view.Count(), txPoolMks)
 	RegisterMetricView(msDropUserTx, view.Sum(), txPoolMks)
+	RegisterMetricView(msReplaceTx, view.Count(), txPoolMks)
+	RegisterMetricView(msReplaceTx, view.Sum(), txPoolMks)
+	RegisterMetricView(msCancelTx, view.Count(), txPoolMks)
+	RegisterMetricView(msCancelTx, view.Sum(), txPoolMks)
+	RegisterMetricView(msReplaceUserTx, view.Count(), txPoolMks)
+	RegisterMetricView(msReplaceUserTx, view.Sum(), txPoolMks)
+	RegisterMetricView(msCancelUserTx, view.Count(), txPoolMks)
+	RegisterMetricView(msCancelUserTx, view.Sum(), txPoolMks)
+	RegisterMetricView(msRejectTx, view.Count(), txPoolMks)
+	RegisterMetricView(msRejectTx, view.Sum(), txPoolMks)
+	RegisterMetricView(msRejectUserTx, view.Count(), txPoolMks)
+	RegisterMetricView(msRejectUserTx, view.Sum(), txPoolMks)
 	RegisterMetricView(msFinLatency, view.LastValue(), txPoolMks)
 	RegisterMetricView(msCommitLatency, view.LastValue(), txPoolMks)
 }
@@ -77,6 +95,27 @@ func (c *TxMetric) OnDropTx(n int, user bool) {
 	}
 }
 
+func (c *TxMetric) OnReplaceTx(n int, user bool) {
+	stats.Record(c.context, msReplaceTx.M(int64(n)))
+	if user {
+		stats.Record(c.context, msReplaceUserTx.M(int64(n)))
+	}
+}
+
+func (c *TxMetric) OnCancelTx(n int, user bool) {
+	stats.Record(c.context, msCancelTx.M(int64(n)))
+	if user {
+		stats.Record(c.context, msCancelUserTx.M(int64(n)))
+	}
+}
+
+func (c *TxMetric) OnRejectTx(n int, user bool) {
+	stats.Record(c.context, msRejectTx.M(int64(n)))
+	if user {
+		stats.Record(c.context, msRejectUserTx.M(int64(n)))
+	}
+}
+
 func (c *TxMetric) OnFinalize(hash []byte, ts time.Time) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+const (
+	RateLimitGroupV3      = "v3"
+	RateLimitGroupV3d     = "v3d"
+	RateLimitGroupRosetta = "rosetta"
+	RateLimitGroupEth     = "eth"
+	RateLimitGroupGraphQL = "graphql"
+	RateLimitGroupWS      = "ws"
+
+	HeaderAPIKey = "X-Api-Key"
+
+	rateLimitByIP  = "ip"
+	rateLimitByKey = "key"
+
+	rateLimitSweepInterval = time.Minute
+)
+
+// RateLimit is the token bucket of a client. Rate is the number of requests
+// per second, and Burst is the size of the bucket. Zero Rate means no limit.
+// Each call of a JSON-RPC batch is counted as a request.
+type RateLimit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst,omitempty"`
+}
+
+func (l RateLimit) burst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return int(math.Max(1, math.Ceil(l.Rate)))
+}
+
+// RateLimitConfig has limits of each method group (v3, v3d, rosetta, eth,
+// graphql and ws). Requests with one of APIKeys in the X-Api-Key header are
+// limited by Key for the key, and others are limited by IP for the client
+// IP. With TrustProxy, the client IP is taken from X-Forwarded-For set by
+// proxies in the loopback or private network.
+type RateLimitConfig struct {
+	IP         map[string]RateLimit `json:"ip,omitempty"`
+	Key        map[string]RateLimit `json:"key,omitempty"`
+	APIKeys    []string             `json:"apiKeys,omitempty"`
+	TrustProxy bool                 `json:"trustProxy,omitempty"`
+}
+
+type bucket struct {
+	limiter *rate.Limiter
+	full    time.Duration
+	last    time.Time
+}
+
+type rateLimiter struct {
+	mtx       sync.Mutex
+	config    RateLimitConfig
+	keys      map[string]bool
+	extractIP echo.IPExtractor
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	l := &rateLimiter{}
+	l.setConfig(nil)
+	return l
+}
+
+func (l *rateLimiter) setConfig(config *RateLimitConfig) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if config == nil {
+		config = &RateLimitConfig{}
+	}
+	l.config = *config
+	l.keys = make(map[string]bool, len(config.APIKeys))
+	for _, key := range config.APIKeys {
+		if key != "" {
+			l.keys[key] = true
+		}
+	}
+	if config.TrustProxy {
+		l.extractIP = echo.ExtractIPFromXFFHeader()
+	} else {
+		l.extractIP = echo.ExtractIPDirect()
+	}
+	l.buckets = make(map[string]*bucket)
+}
+
+func (l *rateLimiter) getConfig() *RateLimitConfig {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	config := l.config
+	return &config
+}
+
+// sweep removes buckets refilled fully, which are the same as new ones.
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for id, b := range l.buckets {
+		if now.Sub(b.last) >= b.full {
+			delete(l.buckets, id)
+		}
+	}
+}
+
+// allow consumes n tokens of the client for the group. If it's throttled, it
+// returns the kind of the limit and the delay for the tokens.
+func (l *rateLimiter) allow(group string, req *http.Request, n int, now time.Time) (bool, string, time.Duration) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	var by, client string
+	var limit RateLimit
+	if key := req.Header.Get(HeaderAPIKey); l.keys[key] {
+		by, client, limit = rateLimitByKey, key, l.config.Key[group]
+	} else {
+		by, client, limit = rateLimitByIP, l.extractIP(req), l.config.IP[group]
+	}
+	if limit.Rate <= 0 {
+		return true, "", 0
+	}
+
+	l.sweep(now)
+	id := group + "/" + by + "/" + client
+	b, ok := l.buckets[id]
+	if !ok {
+		burst := limit.burst()
+		b = &bucket{
+			limiter: rate.NewLimiter(rate.Limit(limit.Rate), burst),
+			full:    time.Duration(float64(burst) / limit.Rate * float64(time.Second)),
+		}
+		l.buckets[id] = b
+	}
+	b.last = now
+	r := b.limiter.ReserveN(now, n)
+	if !r.OK() {
+		return false, by, b.full
+	}
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return false, by, delay
+	}
+	return true, "", 0
+}
+
+func (srv *Manager) SetRateLimit(config *RateLimitConfig) {
+	srv.rl.setConfig(config)
+}
+
+func (srv *Manager) RateLimit() *RateLimitConfig {
+	return srv.rl.getConfig()
+}
+
+// callsOf returns the number of calls of the request. A JSON-RPC batch
+// bound by JsonRpc() has as many calls as its elements.
+func callsOf(ctx echo.Context) int {
+	raw, ok := ctx.Get("raw").(json.RawMessage)
+	if !ok {
+		return 1
+	}
+	var raws []json.RawMessage
+	if err := json.Unmarshal(raw, &raws); err != nil || len(raws) == 0 {
+		return 1
+	}
+	return len(raws)
+}
+
+// RateLimiter returns the middleware limiting requests of the method group.
+// It should be used after JsonRpc() for JSON-RPC APIs to take a token for
+// each call of a batch. Throttled requests get 429 Too Many Requests with
+// the JSON-RPC error.
+func (srv *Manager) RateLimiter(group string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			ok, by, delay := srv.rl.allow(group, ctx.Request(), callsOf(ctx), time.Now())
+			if ok {
+				return next(ctx)
+			}
+			srv.mtr.OnThrottle(group, by)
+			retry := int64(math.Ceil(delay.Seconds()))
+			ctx.Response().Header().Set("Retry-After", strconv.FormatInt(retry, 10))
+			return ctx.JSON(http.StatusTooManyRequests, &jsonrpc.Response{
+				Version: jsonrpc.Version,
+				Error: jsonrpc.ErrorCodeRateLimit.Errorf(
+					"rate limit exceeded (group=%s, by=%s)", group, by),
+			})
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/server/metric"
+)
+
+func newRequest(ip, key string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/v3", nil)
+	req.RemoteAddr = ip + ":1234"
+	if key != "" {
+		req.Header.Set(HeaderAPIKey, key)
+	}
+	return req
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	l := newRateLimiter()
+	l.setConfig(&RateLimitConfig{
+		IP: map[string]RateLimit{
+			RateLimitGroupV3: {Rate: 1, Burst: 2},
+		},
+		Key: map[string]RateLimit{
+			RateLimitGroupV3: {Rate: 10, Burst: 3},
+		},
+		APIKeys: []string{"key1"},
+	})
+	now := time.Now()
+
+	// limited by client IP
+	for i := 0; i < 2; i++ {
+		ok, _, _ := l.allow(RateLimitGroupV3, newRequest("10.0.0.1", ""), 1, now)
+		assert.True(t, ok)
+	}
+	ok, by, delay := l.allow(RateLimitGroupV3, newRequest("10.0.0.1", ""), 1, now)
+	assert.False(t, ok)
+	assert.Equal(t, rateLimitByIP, by)
+	assert.Equal(t, time.Second, delay)
+
+	// other clients and groups are not affected
+	ok, _, _ = l.allow(RateLimitGroupV3, newRequest("10.0.0.2", ""), 1, now)
+	assert.True(t, ok)
+	for i := 0; i < 10; i++ {
+		ok, _, _ = l.allow(RateLimitGroupV3d, newRequest("10.0.0.1", ""), 1, now)
+		assert.True(t, ok)
+	}
+
+	// unknown key is limited by client IP
+	ok, by, _ = l.allow(RateLimitGroupV3, newRequest("10.0.0.1", "unknown"), 1, now)
+	assert.False(t, ok)
+	assert.Equal(t, rateLimitByIP, by)
+
+	// registered key is limited by the key
+	for i := 0; i < 3; i++ {
+		ok, _, _ = l.allow(RateLimitGroupV3, newRequest("10.0.0.1", "key1"), 1, now)
+		assert.True(t, ok)
+	}
+	ok, by, _ = l.allow(RateLimitGroupV3, newRequest("10.0.0.3", "key1"), 1, now)
+	assert.False(t, ok)
+	assert.Equal(t, rateLimitByKey, by)
+
+	// refilled
+	ok, _, _ = l.allow(RateLimitGroupV3, newRequest("10.0.0.1", ""), 1, now.Add(time.Second))
+	assert.True(t, ok)
+}
+
+func TestRateLimiter_Sweep(t *testing.T) {
+	l := newRateLimiter()
+	l.setConfig(&RateLimitConfig{
+		IP: map[string]RateLimit{RateLimitGroupWS: {Rate: 1}},
+	})
+	now := time.Now()
+	l.allow(RateLimitGroupWS, newRequest("10.0.0.1", ""), 1, now)
+	l.allow(RateLimitGroupWS, newRequest("10.0.0.2", ""), 1, now.Add(rateLimitSweepInterval/2))
+	assert.Len(t, l.buckets, 2)
+
+	l.allow(RateLimitGroupWS, newRequest("10.0.0.3", ""), 1, now.Add(rateLimitSweepInterval))
+	assert.Len(t, l.buckets, 1)
+}
+
+func TestRateLimiter_TrustProxy(t *testing.T) {
+	l := newRateLimiter()
+	config := &RateLimitConfig{
+		IP: map[string]RateLimit{RateLimitGroupV3: {Rate: 1}},
+	}
+	l.setConfig(config)
+	now := time.Now()
+
+	req := newRequest("127.0.0.1", "")
+	req.Header.Set("X-Forwarded-For", "1.2.3.4")
+	ok, _, _ := l.allow(RateLimitGroupV3, req, 1, now)
+	assert.True(t, ok)
+	req.Header.Set("X-Forwarded-For", "1.2.3.5")
+	ok, _, _ = l.allow(RateLimitGroupV3, req, 1, now)
+	assert.False(t, ok)
+
+	config.TrustProxy = true
+	l.setConfig(config)
+	ok, _, _ = l.allow(RateLimitGroupV3, req, 1, now)
+	assert.True(t, ok)
+	req.Header.Set("X-Forwarded-For", "1.2.3.4")
+	ok, _, _ = l.allow(RateLimitGroupV3, req, 1, now)
+	assert.True(t, ok)
+}
+
+func TestRateLimiter_Batch(t *testing.T) {
+	srv := &Manager{
+		rl:  newRateLimiter(),
+		mtr: metric.NewJsonrpcMetric(metric.DefaultJsonrpcDurationsExpire, metric.DefaultJsonrpcDurationsSize, false),
+	}
+	srv.SetRateLimit(&RateLimitConfig{
+		IP: map[string]RateLimit{RateLimitGroupV3: {Rate: 1, Burst: 4}},
+	})
+	e := echo.New()
+	e.POST("/api/v3", func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusOK)
+	}, JsonRpc(), srv.RateLimiter(RateLimitGroupV3))
+	call := `{"jsonrpc":"2.0","id":1,"method":"icx_getLastBlock"}`
+	post := func(body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/api/v3", strings.NewReader(body))
+		req.RemoteAddr = "10.0.0.1:1234"
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// a batch of 3 calls takes 3 tokens
+	assert.Equal(t, http.StatusOK, post("["+strings.Repeat(call+",", 2)+call+"]"))
+	assert.Equal(t, http.StatusTooManyRequests, post("["+call+","+call+"]"))
+	assert.Equal(t, http.StatusOK, post(call))
+	assert.Equal(t, http.StatusTooManyRequests, post(call))
+
+	// a batch larger than the bucket is never allowed
+	srv.SetRateLimit(srv.RateLimit())
+	assert.Equal(t, http.StatusTooManyRequests, post("["+strings.Repeat(call+",", 4)+call+"]"))
+	assert.Equal(t, http.StatusOK, post("["+strings.Repeat(call+",", 3)+call+"]"))
+}
@@ -0,0 +1,363 @@
+package rosetta
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service"
+	"github.com/icon-project/goloop/service/transaction"
+)
+
+// transferTx is the transaction_v3 for transferring value. Construction API
+// uses its JSON as unsigned and signed transactions.
+type transferTx struct {
+	Version   common.HexUint16  `json:"version"`
+	From      common.Address    `json:"from"`
+	To        common.Address    `json:"to"`
+	Value     common.HexInt     `json:"value"`
+	StepLimit common.HexInt     `json:"stepLimit"`
+	Timestamp common.HexInt64   `json:"timestamp"`
+	NID       common.HexInt64   `json:"nid"`
+	Signature *common.Signature `json:"signature,omitempty"`
+}
+
+func (tx *transferTx) Bytes() []byte {
+	js, _ := json.Marshal(tx)
+	return js
+}
+
+// Transaction returns the transaction. It verifies the signature if
+// the transaction is signed.
+func (tx *transferTx) Transaction() (transaction.Transaction, *Error) {
+	t, err := transaction.NewTransactionFromJSON(tx.Bytes())
+	if err != nil {
+		return nil, ErrInvalidTx.Wrap(err)
+	}
+	if tx.Signature != nil {
+		if err := t.Verify(); err != nil {
+			if transaction.InvalidSignatureError.Equals(err) {
+				return nil, ErrInvalidSig.Wrap(err)
+			}
+			return nil, ErrInvalidTx.Wrap(err)
+		}
+	}
+	return t, nil
+}
+
+func parseTransferTx(s string, signed bool) (*transferTx, *Error) {
+	tx := new(transferTx)
+	if err := json.Unmarshal([]byte(s), tx); err != nil {
+		return nil, ErrInvalidTx.Wrap(err)
+	}
+	if tx.Version.Value != module.TransactionVersion3 {
+		return nil, ErrInvalidTx.Errorf("InvalidVersion(%d)", tx.Version.Value)
+	}
+	if signed != (tx.Signature != nil) {
+		return nil, ErrInvalidTx.Errorf("InvalidSigned(signed=%t)", tx.Signature != nil)
+	}
+	return tx, nil
+}
+
+func parsePublicKey(pk *PublicKey) (*crypto.PublicKey, *Error) {
+	if pk == nil {
+		return nil, ErrInvalidKey.With("NoPublicKey")
+	}
+	if pk.CurveType != CurveType {
+		return nil, ErrInvalidKey.Errorf("InvalidCurveType(%s)", pk.CurveType)
+	}
+	bs, err := hex.DecodeString(pk.HexBytes)
+	if err != nil {
+		return nil, ErrInvalidKey.Wrap(err)
+	}
+	key, err := crypto.ParsePublicKey(bs)
+	if err != nil {
+		return nil, ErrInvalidKey.Wrap(err)
+	}
+	return key, nil
+}
+
+func (h *Handler) constructionDerive(c echo.Context) error {
+	req := new(ConstructionDeriveRequest)
+	if err := bind(c, req); err != nil {
+		return respond(c, nil, err)
+	}
+	if _, err := h.chainOf(req.NetworkIdentifier); err != nil {
+		return respond(c, nil, err)
+	}
+	key, err := parsePublicKey(req.PublicKey)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	return respond(c, &ConstructionDeriveResponse{
+		AccountIdentifier: accountOf(common.NewAccountAddressFromPublicKey(key)),
+	}, nil)
+}
+
+func (h *Handler) constructionPreprocess(c echo.Context) error {
+	req := new(ConstructionPreprocessRequest)
+	if err := bind(c, req); err != nil {
+		return respond(c, nil, err)
+	}
+	if _, err := h.chainOf(req.NetworkIdentifier); err != nil {
+		return respond(c, nil, err)
+	}
+	from, to, value, err := parseTransfer(req.Operations)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	return respond(c, &ConstructionPreprocessResponse{
+		Options: &TransferOptions{
+			From:  from.String(),
+			To:    to.String(),
+			Value: value.String(),
+		},
+		RequiredPublicKeys: []*AccountIdentifier{accountOf(from)},
+	}, nil)
+}
+
+// stepLimitMargin is the margin of the step limit in percent of the
+// estimated steps, for the state changed until the transaction is executed.
+const stepLimitMargin = 10
+
+// constructionMetadata estimates steps for the transfer on the last block
+// as debug_estimateStep does.
+func (h *Handler) constructionMetadata(c echo.Context) error {
+	req := new(ConstructionMetadataRequest)
+	if err := bind(c, req); err != nil {
+		return respond(c, nil, err)
+	}
+	chain, err := h.chainOf(req.NetworkIdentifier)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	opts := req.Options
+	if opts == nil {
+		return respond(c, nil, ErrInvalidRequest.With("NoOptions"))
+	}
+	tx := &transferTx{
+		Version: common.HexUint16{Value: module.TransactionVersion3},
+		NID:     common.HexInt64{Value: int64(chain.NID())},
+	}
+	if err := tx.From.SetString(opts.From); err != nil {
+		return respond(c, nil, ErrInvalidAccount.Wrap(err))
+	}
+	if err := tx.To.SetString(opts.To); err != nil {
+		return respond(c, nil, ErrInvalidAccount.Wrap(err))
+	}
+	if _, ok := tx.Value.SetString(opts.Value, 10); !ok {
+		return respond(c, nil, ErrInvalidRequest.Errorf("InvalidValue(%s)", opts.Value))
+	}
+
+	blk, berr := chain.BlockManager().GetLastBlock()
+	if berr != nil {
+		return respond(c, nil, ErrNotAvailable.Wrap(berr))
+	}
+	ts := common.UnixMicroFromTime(time.Now())
+	if ts <= blk.Timestamp() {
+		ts = blk.Timestamp() + 1
+	}
+	tx.Timestamp.Value = ts
+	rct, rerr := chain.ServiceManager().ExecuteTransaction(
+		blk.Result(),
+		blk.NextValidators().Hash(),
+		tx.Bytes(),
+		common.NewBlockInfo(blk.Height()+1, ts),
+	)
+	if rerr != nil {
+		return respond(c, nil, ErrInvalidTx.Wrap(rerr))
+	}
+	if status := rct.Status(); status != module.StatusSuccess {
+		return respond(c, nil, ErrInvalidTx.Errorf("ExecutionFailure(status=%d)", status))
+	}
+	fee := new(big.Int).Mul(rct.StepUsed(), rct.StepPrice())
+	limit := new(big.Int).Mul(rct.StepUsed(), big.NewInt(100+stepLimitMargin))
+	limit.Div(limit, big.NewInt(100))
+	return respond(c, &ConstructionMetadataResponse{
+		Metadata: &TransactionMetadata{
+			NID:       tx.NID.String(),
+			StepLimit: (&common.HexInt{Int: *limit}).String(),
+			Timestamp: tx.Timestamp.String(),
+		},
+		SuggestedFee: []*Amount{amountOf(fee)},
+	}, nil)
+}
+
+func (h *Handler) constructionPayloads(c echo.Context) error {
+	req := new(ConstructionPayloadsRequest)
+	if err := bind(c, req); err != nil {
+		return respond(c, nil, err)
+	}
+	if _, err := h.chainOf(req.NetworkIdentifier); err != nil {
+		return respond(c, nil, err)
+	}
+	from, to, value, err := parseTransfer(req.Operations)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	md := req.Metadata
+	if md == nil {
+		return respond(c, nil, ErrInvalidRequest.With("NoMetadata"))
+	}
+	tx := &transferTx{
+		Version: common.HexUint16{Value: module.TransactionVersion3},
+		Value:   common.HexInt{Int: *value},
+	}
+	tx.From.Set(from)
+	tx.To.Set(to)
+	if _, ok := tx.StepLimit.SetString(md.StepLimit, 0); !ok {
+		return respond(c, nil, ErrInvalidRequest.Errorf("InvalidStepLimit(%s)", md.StepLimit))
+	}
+	if v, perr := intconv.ParseInt(md.NID, 64); perr != nil {
+		return respond(c, nil, ErrInvalidRequest.Errorf("InvalidNID(%s)", md.NID))
+	} else {
+		tx.NID.Value = v
+	}
+	if v, perr := intconv.ParseInt(md.Timestamp, 64); perr != nil {
+		return respond(c, nil, ErrInvalidRequest.Errorf("InvalidTimestamp(%s)", md.Timestamp))
+	} else {
+		tx.Timestamp.Value = v
+	}
+	t, err := tx.Transaction()
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	return respond(c, &ConstructionPayloadsResponse{
+		UnsignedTransaction: string(tx.Bytes()),
+		Payloads: []*SigningPayload{{
+			AccountIdentifier: accountOf(from),
+			HexBytes:          hex.EncodeToString(t.ID()),
+			SignatureType:     SignatureType,
+		}},
+	}, nil)
+}
+
+func (h *Handler) constructionCombine(c echo.Context) error {
+	req := new(ConstructionCombineRequest)
+	if err := bind(c, req); err != nil {
+		return respond(c, nil, err)
+	}
+	if _, err := h.chainOf(req.NetworkIdentifier); err != nil {
+		return respond(c, nil, err)
+	}
+	tx, err := parseTransferTx(req.UnsignedTransaction, false)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	if len(req.Signatures) != 1 {
+		return respond(c, nil, ErrInvalidSig.Errorf("InvalidSignatureCount(%d)", len(req.Signatures)))
+	}
+	s := req.Signatures[0]
+	if s.SignatureType != SignatureType {
+		return respond(c, nil, ErrInvalidSig.Errorf("InvalidSignatureType(%s)", s.SignatureType))
+	}
+	bs, herr := hex.DecodeString(s.HexBytes)
+	if herr != nil || len(bs) != crypto.SignatureLenRawWithV {
+		return respond(c, nil, ErrInvalidSig.Errorf("InvalidSignature(%s)", s.HexBytes))
+	}
+	sig, serr := crypto.ParseSignature(bs)
+	if serr != nil {
+		return respond(c, nil, ErrInvalidSig.Wrap(serr))
+	}
+	tx.Signature = &common.Signature{Signature: sig}
+	if _, err := tx.Transaction(); err != nil {
+		return respond(c, nil, err)
+	}
+	return respond(c, &ConstructionCombineResponse{
+		SignedTransaction: string(tx.Bytes()),
+	}, nil)
+}
+
+func (h *Handler) constructionParse(c echo.Context) error {
+	req := new(ConstructionParseRequest)
+	if err := bind(c, req); err != nil {
+		return respond(c, nil, err)
+	}
+	if _, err := h.chainOf(req.NetworkIdentifier); err != nil {
+		return respond(c, nil, err)
+	}
+	tx, err := parseTransferTx(req.Transaction, req.Signed)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	res := &ConstructionParseResponse{
+		Operations: transferOperations(&tx.From, &tx.To, &tx.Value.Int, nil),
+	}
+	if req.Signed {
+		res.AccountIdentifierSigners = []*AccountIdentifier{accountOf(&tx.From)}
+	}
+	return respond(c, res, nil)
+}
+
+func (h *Handler) constructionHash(c echo.Context) error {
+	req := new(ConstructionHashRequest)
+	if err := bind(c, req); err != nil {
+		return respond(c, nil, err)
+	}
+	if _, err := h.chainOf(req.NetworkIdentifier); err != nil {
+		return respond(c, nil, err)
+	}
+	tx, err := parseTransferTx(req.SignedTransaction, true)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	t, err := tx.Transaction()
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	return respond(c, &TransactionIdentifierResponse{
+		TransactionIdentifier: &TransactionIdentifier{
+			Hash: "0x" + hex.EncodeToString(t.ID()),
+		},
+	}, nil)
+}
+
+// constructionSubmit sends the transaction as icx_sendTransaction does.
+func (h *Handler) constructionSubmit(c echo.Context) error {
+	req := new(ConstructionSubmitRequest)
+	if err := bind(c, req); err != nil {
+		return respond(c, nil, err)
+	}
+	chain, err := h.chainOf(req.NetworkIdentifier)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	tx, err := parseTransferTx(req.SignedTransaction, true)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	if _, err := tx.Transaction(); err != nil {
+		return respond(c, nil, err)
+	}
+
+	var state []byte
+	var height int64
+	if chain.ValidateTxOnSend() {
+		blk, berr := chain.BlockManager().GetLastBlock()
+		if berr != nil {
+			return respond(c, nil, ErrNotAvailable.Wrap(berr))
+		}
+		state = blk.Result()
+		height = blk.Height() + 1
+	}
+	hash, serr := chain.ServiceManager().SendTransaction(state, height, json.RawMessage(tx.Bytes()))
+	if serr != nil {
+		if service.TransactionPoolOverflowError.Equals(serr) ||
+			service.SenderLimitExceededError.Equals(serr) {
+			return respond(c, nil, ErrSubmitFailure.Wrap(serr))
+		}
+		return respond(c, nil, ErrInvalidTx.Wrap(serr))
+	}
+	return respond(c, &TransactionIdentifierResponse{
+		TransactionIdentifier: &TransactionIdentifier{
+			Hash: "0x" + hex.EncodeToString(hash),
+		},
+	}, nil)
+}
@@ -0,0 +1,227 @@
+package rosetta
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/module"
+)
+
+type testChain struct {
+	module.Chain
+}
+
+func (c *testChain) BlockManager() module.BlockManager {
+	return struct{ module.BlockManager }{}
+}
+
+func (c *testChain) ServiceManager() module.ServiceManager {
+	return struct{ module.ServiceManager }{}
+}
+
+type testChainProvider map[string]module.Chain
+
+func (p testChainProvider) Chain(channel string) module.Chain {
+	return p[channel]
+}
+
+func (p testChainProvider) Channels() []string {
+	channels := make([]string, 0, len(p))
+	for channel := range p {
+		channels = append(channels, channel)
+	}
+	return channels
+}
+
+const testChannel = "test"
+
+func newTestServer() *echo.Echo {
+	return newTestServerWith(&testChain{})
+}
+
+func newTestServerWith(chain module.Chain) *echo.Echo {
+	e := echo.New()
+	h := NewHandler(testChainProvider{testChannel: chain})
+	h.RegisterRoutes(e.Group(""), "/rosetta")
+	return e
+}
+
+func post(t *testing.T, e *echo.Echo, path string, req, res interface{}) *Error {
+	js, err := json.Marshal(req)
+	assert.NoError(t, err)
+	hreq := httptest.NewRequest(http.MethodPost, "/rosetta"+path, bytes.NewReader(js))
+	hreq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, hreq)
+	if rec.Code != http.StatusOK {
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		rerr := new(Error)
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), rerr))
+		return rerr
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), res))
+	return nil
+}
+
+func TestConstruction_RoundTrip(t *testing.T) {
+	e := newTestServer()
+	network := networkOf(testChannel)
+
+	priv, pub := crypto.GenerateKeyPair()
+	derive := new(ConstructionDeriveResponse)
+	assert.Nil(t, post(t, e, "/construction/derive", &ConstructionDeriveRequest{
+		NetworkIdentifier: network,
+		PublicKey: &PublicKey{
+			HexBytes:  hex.EncodeToString(pub.SerializeCompressed()),
+			CurveType: CurveType,
+		},
+	}, derive))
+	from := common.NewAccountAddressFromPublicKey(pub)
+	assert.Equal(t, from.String(), derive.AccountIdentifier.Address)
+
+	to := common.MustNewAddressFromString("hx1234")
+	ops := transferOperations(from, to, big.NewInt(1000), nil)
+
+	pre := new(ConstructionPreprocessResponse)
+	assert.Nil(t, post(t, e, "/construction/preprocess", &ConstructionPreprocessRequest{
+		NetworkIdentifier: network,
+		Operations:        ops,
+	}, pre))
+	assert.Equal(t, &TransferOptions{
+		From:  from.String(),
+		To:    to.String(),
+		Value: "1000",
+	}, pre.Options)
+	assert.Equal(t, []*AccountIdentifier{accountOf(from)}, pre.RequiredPublicKeys)
+
+	payloads := new(ConstructionPayloadsResponse)
+	assert.Nil(t, post(t, e, "/construction/payloads", &ConstructionPayloadsRequest{
+		NetworkIdentifier: network,
+		Operations:        ops,
+		Metadata: &TransactionMetadata{
+			NID:       "0x1",
+			StepLimit: "0x186a0",
+			Timestamp: "0x5c4d6a0b9e5b0",
+		},
+	}, payloads))
+	assert.Equal(t, 1, len(payloads.Payloads))
+	payload := payloads.Payloads[0]
+	assert.Equal(t, SignatureType, payload.SignatureType)
+
+	parsed := new(ConstructionParseResponse)
+	assert.Nil(t, post(t, e, "/construction/parse", &ConstructionParseRequest{
+		NetworkIdentifier: network,
+		Signed:            false,
+		Transaction:       payloads.UnsignedTransaction,
+	}, parsed))
+	assert.Equal(t, ops, parsed.Operations)
+	assert.Nil(t, parsed.AccountIdentifierSigners)
+
+	hash, _ := hex.DecodeString(payload.HexBytes)
+	sig, err := crypto.NewSignature(hash, priv)
+	assert.NoError(t, err)
+	rsv, err := sig.SerializeRSV()
+	assert.NoError(t, err)
+
+	combined := new(ConstructionCombineResponse)
+	assert.Nil(t, post(t, e, "/construction/combine", &ConstructionCombineRequest{
+		NetworkIdentifier:   network,
+		UnsignedTransaction: payloads.UnsignedTransaction,
+		Signatures: []*Signature{{
+			SigningPayload: payload,
+			PublicKey: &PublicKey{
+				HexBytes:  hex.EncodeToString(pub.SerializeCompressed()),
+				CurveType: CurveType,
+			},
+			SignatureType: SignatureType,
+			HexBytes:      hex.EncodeToString(rsv),
+		}},
+	}, combined))
+
+	parsed = new(ConstructionParseResponse)
+	assert.Nil(t, post(t, e, "/construction/parse", &ConstructionParseRequest{
+		NetworkIdentifier: network,
+		Signed:            true,
+		Transaction:       combined.SignedTransaction,
+	}, parsed))
+	assert.Equal(t, ops, parsed.Operations)
+	assert.Equal(t, []*AccountIdentifier{accountOf(from)}, parsed.AccountIdentifierSigners)
+
+	txHash := new(TransactionIdentifierResponse)
+	assert.Nil(t, post(t, e, "/construction/hash", &ConstructionHashRequest{
+		NetworkIdentifier: network,
+		SignedTransaction: combined.SignedTransaction,
+	}, txHash))
+	assert.Equal(t, "0x"+payload.HexBytes, txHash.TransactionIdentifier.Hash)
+}
+
+func TestConstruction_InvalidSignature(t *testing.T) {
+	e := newTestServer()
+	network := networkOf(testChannel)
+
+	_, pub := crypto.GenerateKeyPair()
+	from := common.NewAccountAddressFromPublicKey(pub)
+	to := common.MustNewAddressFromString("hx1234")
+
+	payloads := new(ConstructionPayloadsResponse)
+	assert.Nil(t, post(t, e, "/construction/payloads", &ConstructionPayloadsRequest{
+		NetworkIdentifier: network,
+		Operations:        transferOperations(from, to, big.NewInt(1), nil),
+		Metadata: &TransactionMetadata{
+			NID:       "0x1",
+			StepLimit: "0x186a0",
+			Timestamp: "0x5c4d6a0b9e5b0",
+		},
+	}, payloads))
+
+	// signed by the other key
+	other, _ := crypto.GenerateKeyPair()
+	hash, _ := hex.DecodeString(payloads.Payloads[0].HexBytes)
+	sig, _ := crypto.NewSignature(hash, other)
+	rsv, _ := sig.SerializeRSV()
+
+	rerr := post(t, e, "/construction/combine", &ConstructionCombineRequest{
+		NetworkIdentifier:   network,
+		UnsignedTransaction: payloads.UnsignedTransaction,
+		Signatures: []*Signature{{
+			SignatureType: SignatureType,
+			HexBytes:      hex.EncodeToString(rsv),
+		}},
+	}, nil)
+	if assert.NotNil(t, rerr) {
+		assert.Equal(t, ErrInvalidSig.Code, rerr.Code)
+	}
+}
+
+func TestNetwork_InvalidIdentifier(t *testing.T) {
+	e := newTestServer()
+
+	list := new(NetworkListResponse)
+	assert.Nil(t, post(t, e, "/network/list", struct{}{}, list))
+	assert.Equal(t, []*NetworkIdentifier{networkOf(testChannel)}, list.NetworkIdentifiers)
+
+	rerr := post(t, e, "/network/options", &NetworkRequest{
+		NetworkIdentifier: &NetworkIdentifier{Blockchain: Blockchain, Network: "unknown"},
+	}, nil)
+	if assert.NotNil(t, rerr) {
+		assert.Equal(t, ErrInvalidNetwork.Code, rerr.Code)
+	}
+
+	options := new(NetworkOptionsResponse)
+	assert.Nil(t, post(t, e, "/network/options", &NetworkRequest{
+		NetworkIdentifier: networkOf(testChannel),
+	}, options))
+	assert.Equal(t, RosettaVersion, options.Version.RosettaVersion)
+	assert.Contains(t, options.Allow.OperationTypes, opTypeTransfer)
+	assert.Equal(t, len(allErrors), len(options.Allow.Errors))
+}
@@ -0,0 +1,352 @@
+package rosetta
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/v3"
+	"github.com/icon-project/goloop/service/trace"
+)
+
+func parseHash(s string) ([]byte, *Error) {
+	if len(s) < 2 || (s[:2] != "0x" && s[:2] != "bx") {
+		return nil, ErrInvalidRequest.Errorf("InvalidHash(%s)", s)
+	}
+	bs, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return nil, ErrInvalidRequest.Errorf("InvalidHash(%s)", s)
+	}
+	return bs, nil
+}
+
+func blockIdentifierOf(blk module.Block) *BlockIdentifier {
+	return &BlockIdentifier{
+		Index: blk.Height(),
+		Hash:  "0x" + hex.EncodeToString(blk.ID()),
+	}
+}
+
+// timestampOf returns the timestamp of the block in milliseconds.
+func timestampOf(blk module.Block) int64 {
+	return blk.Timestamp() / 1000
+}
+
+// currentBlock returns the latest block having the result of its
+// transactions, which is the previous block of the last block.
+func currentBlock(chain module.Chain) (module.Block, *Error) {
+	bm := chain.BlockManager()
+	last, err := bm.GetLastBlock()
+	if err != nil {
+		return nil, ErrNotAvailable.Wrap(err)
+	}
+	if last.Height() < 1 {
+		return nil, ErrNotAvailable.With("NoFinalizedBlock")
+	}
+	blk, err := bm.GetBlockByHeight(last.Height() - 1)
+	if err != nil {
+		return nil, ErrSystem.Wrap(err)
+	}
+	return blk, nil
+}
+
+// blockOf returns the block identified by pbi. It returns the current
+// block if no identifier is specified.
+func blockOf(chain module.Chain, pbi *PartialBlockIdentifier) (module.Block, *Error) {
+	if pbi == nil || (pbi.Index == nil && pbi.Hash == nil) {
+		return currentBlock(chain)
+	}
+	bm := chain.BlockManager()
+	last, err := bm.GetLastBlock()
+	if err != nil {
+		return nil, ErrNotAvailable.Wrap(err)
+	}
+
+	var blk module.Block
+	if pbi.Hash != nil {
+		id, rerr := parseHash(*pbi.Hash)
+		if rerr != nil {
+			return nil, rerr
+		}
+		blk, err = bm.GetBlock(id)
+		if pbi.Index != nil && err == nil && blk.Height() != *pbi.Index {
+			return nil, ErrBlockNotFound.Errorf(
+				"HeightMismatch(index=%d,height=%d)", *pbi.Index, blk.Height())
+		}
+	} else {
+		height := *pbi.Index
+		if height < chain.GenesisStorage().Height() || height > last.Height() {
+			return nil, ErrBlockNotFound.Errorf("InvalidHeight(%d)", height)
+		}
+		blk, err = bm.GetBlockByHeight(height)
+	}
+	if errors.NotFoundError.Equals(err) {
+		return nil, ErrBlockNotFound.Wrap(err)
+	} else if err != nil {
+		return nil, ErrSystem.Wrap(err)
+	}
+	if blk.Height() >= last.Height() {
+		return nil, ErrNotFinalized
+	}
+	return blk, nil
+}
+
+func blockTransactions(chain module.Chain, blk module.Block) ([]*Transaction, *Error) {
+	bt, err := v3.TraceBalanceChanges(chain, blk, module.TraceRangeBlock, 0, false)
+	if err != nil {
+		return nil, errorOf(err)
+	}
+	btxs := bt.Transactions(blk.Height())
+	txs := make([]*Transaction, len(btxs))
+	for i, btx := range btxs {
+		txs[i] = transactionOf(btx)
+	}
+	return txs, nil
+}
+
+func (h *Handler) networkList(c echo.Context) error {
+	channels := h.cp.Channels()
+	res := &NetworkListResponse{
+		NetworkIdentifiers: make([]*NetworkIdentifier, len(channels)),
+	}
+	for i, channel := range channels {
+		res.NetworkIdentifiers[i] = networkOf(channel)
+	}
+	return respond(c, res, nil)
+}
+
+func (h *Handler) networkOptions(c echo.Context) error {
+	req := new(NetworkRequest)
+	if err := bind(c, req); err != nil {
+		return respond(c, nil, err)
+	}
+	if _, err := h.chainOf(req.NetworkIdentifier); err != nil {
+		return respond(c, nil, err)
+	}
+	return respond(c, &NetworkOptionsResponse{
+		Version: &Version{
+			RosettaVersion: RosettaVersion,
+			NodeVersion:    NodeVersion,
+		},
+		Allow: &Allow{
+			OperationStatuses: []*OperationStatus{
+				{Status: StatusSuccess, Successful: true},
+			},
+			OperationTypes:          trace.OpTypeNames(),
+			Errors:                  allErrors,
+			HistoricalBalanceLookup: true,
+			CallMethods:             []string{},
+			BalanceExemptions:       []interface{}{},
+		},
+	}, nil)
+}
+
+func (h *Handler) networkStatus(c echo.Context) error {
+	req := new(NetworkRequest)
+	if err := bind(c, req); err != nil {
+		return respond(c, nil, err)
+	}
+	chain, err := h.chainOf(req.NetworkIdentifier)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	blk, err := currentBlock(chain)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	gblk, gerr := chain.BlockManager().GetBlockByHeight(chain.GenesisStorage().Height())
+	if gerr != nil {
+		return respond(c, nil, ErrSystem.Wrap(gerr))
+	}
+	return respond(c, &NetworkStatusResponse{
+		CurrentBlockIdentifier: blockIdentifierOf(blk),
+		CurrentBlockTimestamp:  timestampOf(blk),
+		GenesisBlockIdentifier: blockIdentifierOf(gblk),
+		Peers:                  []*Peer{},
+	}, nil)
+}
+
+func (h *Handler) block(c echo.Context) error {
+	req := new(BlockRequest)
+	if err := bind(c, req); err != nil {
+		return respond(c, nil, err)
+	}
+	chain, err := h.chainOf(req.NetworkIdentifier)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	blk, err := blockOf(chain, req.BlockIdentifier)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	txs, err := blockTransactions(chain, blk)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+
+	// parent of the genesis block is itself
+	parent := blockIdentifierOf(blk)
+	if blk.Height() > 0 {
+		parent = &BlockIdentifier{
+			Index: blk.Height() - 1,
+			Hash:  "0x" + hex.EncodeToString(blk.PrevID()),
+		}
+	}
+	return respond(c, &BlockResponse{
+		Block: &Block{
+			BlockIdentifier:       blockIdentifierOf(blk),
+			ParentBlockIdentifier: parent,
+			Timestamp:             timestampOf(blk),
+			Transactions:          txs,
+		},
+	}, nil)
+}
+
+func (h *Handler) blockTransaction(c echo.Context) error {
+	req := new(BlockTransactionRequest)
+	if err := bind(c, req); err != nil {
+		return respond(c, nil, err)
+	}
+	chain, err := h.chainOf(req.NetworkIdentifier)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	if req.BlockIdentifier == nil || req.TransactionIdentifier == nil {
+		return respond(c, nil, ErrInvalidRequest.With("NoIdentifier"))
+	}
+	blk, err := blockOf(chain, &PartialBlockIdentifier{
+		Index: &req.BlockIdentifier.Index,
+		Hash:  &req.BlockIdentifier.Hash,
+	})
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	txs, err := blockTransactions(chain, blk)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	for _, tx := range txs {
+		if strings.EqualFold(tx.TransactionIdentifier.Hash, req.TransactionIdentifier.Hash) {
+			return respond(c, &BlockTransactionResponse{Transaction: tx}, nil)
+		}
+	}
+	return respond(c, nil, ErrTxNotFound.With(req.TransactionIdentifier.Hash))
+}
+
+func (h *Handler) accountBalance(c echo.Context) error {
+	req := new(AccountBalanceRequest)
+	if err := bind(c, req); err != nil {
+		return respond(c, nil, err)
+	}
+	chain, err := h.chainOf(req.NetworkIdentifier)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	addr, err := parseAccount(req.AccountIdentifier)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	blk, err := blockOf(chain, req.BlockIdentifier)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	if herr := v3.CheckBaseHeight(chain, blk.Height()); herr != nil {
+		return respond(c, nil, ErrNoState.Wrap(herr))
+	}
+
+	// the result of the transactions in the block is in the next block
+	nblk, nerr := chain.BlockManager().GetBlockByHeight(blk.Height() + 1)
+	if nerr != nil {
+		return respond(c, nil, ErrSystem.Wrap(nerr))
+	}
+	balance, berr := chain.ServiceManager().GetBalance(nblk.Result(), addr)
+	if berr != nil {
+		return respond(c, nil, ErrSystem.Wrap(berr))
+	}
+	return respond(c, &AccountBalanceResponse{
+		BlockIdentifier: blockIdentifierOf(blk),
+		Balances:        []*Amount{amountOf(balance)},
+	}, nil)
+}
+
+func (h *Handler) mempool(c echo.Context) error {
+	req := new(NetworkRequest)
+	if err := bind(c, req); err != nil {
+		return respond(c, nil, err)
+	}
+	chain, err := h.chainOf(req.NetworkIdentifier)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	ptxs := chain.ServiceManager().GetPendingTransactions(nil)
+	res := &MempoolResponse{
+		TransactionIdentifiers: make([]*TransactionIdentifier, len(ptxs)),
+	}
+	for i, ptx := range ptxs {
+		res.TransactionIdentifiers[i] = &TransactionIdentifier{
+			Hash: "0x" + hex.EncodeToString(ptx.ID()),
+		}
+	}
+	return respond(c, res, nil)
+}
+
+func (h *Handler) mempoolTransaction(c echo.Context) error {
+	req := new(MempoolTransactionRequest)
+	if err := bind(c, req); err != nil {
+		return respond(c, nil, err)
+	}
+	chain, err := h.chainOf(req.NetworkIdentifier)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	if req.TransactionIdentifier == nil {
+		return respond(c, nil, ErrInvalidRequest.With("NoIdentifier"))
+	}
+	id, err := parseHash(req.TransactionIdentifier.Hash)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	ptx := chain.ServiceManager().GetPendingTransaction(id)
+	if ptx == nil {
+		return respond(c, nil, ErrTxNotFound.With(req.TransactionIdentifier.Hash))
+	}
+
+	// only transfer of the value is known before execution
+	tx, err := transferOf(ptx.Transaction)
+	if err != nil {
+		return respond(c, nil, err)
+	}
+	ops := []*Operation{}
+	if tx.Value.Sign() > 0 {
+		ops = transferOperations(&tx.From, &tx.To, &tx.Value.Int, nil)
+	}
+	return respond(c, &MempoolTransactionResponse{
+		Transaction: &Transaction{
+			TransactionIdentifier: &TransactionIdentifier{
+				Hash: "0x" + hex.EncodeToString(ptx.ID()),
+			},
+			Operations: ops,
+		},
+	}, nil)
+}
+
+// transferOf returns the fields of the transaction for transfer.
+func transferOf(tx module.Transaction) (*transferTx, *Error) {
+	jso, err := tx.ToJSON(module.JSONVersion3)
+	if err != nil {
+		return nil, ErrInvalidTx.Wrap(err)
+	}
+	js, err := json.Marshal(jso)
+	if err != nil {
+		return nil, ErrInvalidTx.Wrap(err)
+	}
+	ttx := new(transferTx)
+	if err := json.Unmarshal(js, ttx); err != nil {
+		return nil, ErrInvalidTx.Wrap(err)
+	}
+	return ttx, nil
+}
@@ -0,0 +1,194 @@
+package rosetta
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+type testValidatorList struct {
+	module.ValidatorList
+}
+
+func (l *testValidatorList) Hash() []byte {
+	return nil
+}
+
+type testBlock struct {
+	module.Block
+	height int64
+}
+
+func (b *testBlock) Height() int64 {
+	return b.height
+}
+
+func (b *testBlock) ID() []byte {
+	return []byte(fmt.Sprintf("block%d", b.height))
+}
+
+func (b *testBlock) Timestamp() int64 {
+	return b.height * 1000000
+}
+
+func (b *testBlock) Result() []byte {
+	return nil
+}
+
+func (b *testBlock) NextValidators() module.ValidatorList {
+	return &testValidatorList{}
+}
+
+type testBlockManager struct {
+	module.BlockManager
+	last int64
+}
+
+func (bm *testBlockManager) GetBlockByHeight(height int64) (module.Block, error) {
+	if height < 0 || height > bm.last {
+		return nil, errors.NotFoundError.Errorf("NoBlock(height=%d)", height)
+	}
+	return &testBlock{height: height}, nil
+}
+
+func (bm *testBlockManager) GetBlock(id []byte) (module.Block, error) {
+	var height int64
+	if _, err := fmt.Sscanf(string(id), "block%d", &height); err != nil {
+		return nil, errors.NotFoundError.Errorf("NoBlock(id=%x)", id)
+	}
+	return bm.GetBlockByHeight(height)
+}
+
+func (bm *testBlockManager) GetLastBlock() (module.Block, error) {
+	return &testBlock{height: bm.last}, nil
+}
+
+type testServiceManager struct {
+	module.ServiceManager
+	stepUsed int64
+}
+
+func (sm *testServiceManager) GetBalance(result []byte, addr module.Address) (*big.Int, error) {
+	return big.NewInt(100), nil
+}
+
+func (sm *testServiceManager) ExecuteTransaction(result []byte, vh []byte, js []byte, bi module.BlockInfo) (module.Receipt, error) {
+	r := txresult.NewReceipt(db.NewMapDB(), module.LatestRevision,
+		common.MustNewAddressFromString("hx1234"))
+	r.SetResult(module.StatusSuccess, big.NewInt(sm.stepUsed), big.NewInt(10), nil)
+	return r, nil
+}
+
+type testGenesisStorage struct {
+	module.GenesisStorage
+	height int64
+}
+
+func (gs *testGenesisStorage) Height() int64 {
+	return gs.height
+}
+
+// testStateChain has blocks up to the last height, and states of blocks
+// from the base height.
+type testStateChain struct {
+	module.Chain
+	bm *testBlockManager
+	sm *testServiceManager
+	gs *testGenesisStorage
+}
+
+func newTestStateChain(base, last int64) *testStateChain {
+	return &testStateChain{
+		bm: &testBlockManager{last: last},
+		sm: &testServiceManager{stepUsed: 100000},
+		gs: &testGenesisStorage{height: base},
+	}
+}
+
+func (c *testStateChain) BlockManager() module.BlockManager {
+	return c.bm
+}
+
+func (c *testStateChain) ServiceManager() module.ServiceManager {
+	return c.sm
+}
+
+func (c *testStateChain) GenesisStorage() module.GenesisStorage {
+	return c.gs
+}
+
+func (c *testStateChain) NID() int {
+	return 1
+}
+
+func TestAccountBalance_BaseHeight(t *testing.T) {
+	e := newTestServerWith(newTestStateChain(5, 10))
+	account := &AccountIdentifier{Address: "hx1234"}
+	hashOf := func(height int64) *string {
+		hash := "0x" + hex.EncodeToString([]byte(fmt.Sprintf("block%d", height)))
+		return &hash
+	}
+	indexOf := func(height int64) *int64 {
+		return &height
+	}
+
+	tests := []struct {
+		name string
+		pbi  *PartialBlockIdentifier
+		code int32
+	}{
+		{"Current", nil, 0},
+		{"Base", &PartialBlockIdentifier{Index: indexOf(5)}, 0},
+		{"BaseByHash", &PartialBlockIdentifier{Hash: hashOf(5)}, 0},
+		{"PrunedByIndex", &PartialBlockIdentifier{Index: indexOf(4)}, ErrBlockNotFound.Code},
+		{"PrunedByHash", &PartialBlockIdentifier{Hash: hashOf(4)}, ErrNoState.Code},
+		{"NotFinalized", &PartialBlockIdentifier{Index: indexOf(10)}, ErrNotFinalized.Code},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := new(AccountBalanceResponse)
+			rerr := post(t, e, "/account/balance", &AccountBalanceRequest{
+				NetworkIdentifier: networkOf(testChannel),
+				AccountIdentifier: account,
+				BlockIdentifier:   tt.pbi,
+			}, res)
+			if tt.code != 0 {
+				if assert.NotNil(t, rerr) {
+					assert.Equal(t, tt.code, rerr.Code, rerr.Error())
+				}
+				return
+			}
+			if assert.Nil(t, rerr) {
+				assert.Equal(t, []*Amount{amountOf(big.NewInt(100))}, res.Balances)
+			}
+		})
+	}
+}
+
+func TestConstruction_MetadataStepLimit(t *testing.T) {
+	c := newTestStateChain(0, 10)
+	e := newTestServerWith(c)
+
+	res := new(ConstructionMetadataResponse)
+	assert.Nil(t, post(t, e, "/construction/metadata", &ConstructionMetadataRequest{
+		NetworkIdentifier: networkOf(testChannel),
+		Options: &TransferOptions{
+			From:  "hx1234",
+			To:    "hx5678",
+			Value: "1000",
+		},
+	}, res))
+	assert.Equal(t, "0x1", res.Metadata.NID)
+	// 10% margin of the estimated steps
+	assert.Equal(t, "0x1adb0", res.Metadata.StepLimit)
+	assert.Equal(t, []*Amount{amountOf(big.NewInt(100000 * 10))}, res.SuggestedFee)
+}
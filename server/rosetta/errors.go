@@ -0,0 +1,72 @@
+package rosetta
+
+import (
+	"fmt"
+)
+
+// Error is the error object of Rosetta API. It's returned with
+// HTTP status 500.
+type Error struct {
+	Code        int32  `json:"code"`
+	Message     string `json:"message"`
+	Description string `json:"description,omitempty"`
+	Retriable   bool   `json:"retriable"`
+}
+
+func (e *Error) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("%s(%s)", e.Message, e.Description)
+	}
+	return e.Message
+}
+
+// With returns a copy of the error with the description.
+func (e *Error) With(description string) *Error {
+	ne := *e
+	ne.Description = description
+	return &ne
+}
+
+// Errorf returns a copy of the error with the formatted description.
+func (e *Error) Errorf(format string, args ...interface{}) *Error {
+	return e.With(fmt.Sprintf(format, args...))
+}
+
+// Wrap returns a copy of the error with the description of err.
+func (e *Error) Wrap(err error) *Error {
+	return e.With(err.Error())
+}
+
+var (
+	ErrInvalidRequest = &Error{Code: 1, Message: "Invalid request"}
+	ErrInvalidNetwork = &Error{Code: 2, Message: "Invalid network identifier"}
+	ErrNotAvailable   = &Error{Code: 3, Message: "Node is not available", Retriable: true}
+	ErrBlockNotFound  = &Error{Code: 4, Message: "Block not found"}
+	ErrNotFinalized   = &Error{Code: 5, Message: "Block is not finalized", Retriable: true}
+	ErrTxNotFound     = &Error{Code: 6, Message: "Transaction not found"}
+	ErrInvalidAccount = &Error{Code: 7, Message: "Invalid account identifier"}
+	ErrUnsupportedOps = &Error{Code: 8, Message: "Unsupported operations"}
+	ErrInvalidTx      = &Error{Code: 9, Message: "Invalid transaction"}
+	ErrInvalidKey     = &Error{Code: 10, Message: "Invalid public key"}
+	ErrInvalidSig     = &Error{Code: 11, Message: "Invalid signature"}
+	ErrSubmitFailure  = &Error{Code: 12, Message: "Fail to submit transaction", Retriable: true}
+	ErrSystem         = &Error{Code: 13, Message: "System error", Retriable: true}
+	ErrNoState        = &Error{Code: 14, Message: "State is not available"}
+)
+
+var allErrors = []*Error{
+	ErrInvalidRequest,
+	ErrInvalidNetwork,
+	ErrNotAvailable,
+	ErrBlockNotFound,
+	ErrNotFinalized,
+	ErrTxNotFound,
+	ErrInvalidAccount,
+	ErrUnsupportedOps,
+	ErrInvalidTx,
+	ErrInvalidKey,
+	ErrInvalidSig,
+	ErrSubmitFailure,
+	ErrSystem,
+	ErrNoState,
+}
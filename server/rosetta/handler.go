@@ -0,0 +1,105 @@
+package rosetta
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+// ChainProvider provides chains for the networks. The network of
+// Rosetta API is identified by the channel of the chain.
+type ChainProvider interface {
+	Chain(channel string) module.Chain
+	Channels() []string
+}
+
+// NodeVersion is the version of the node reported by /network/options.
+var NodeVersion = "unknown"
+
+type Handler struct {
+	cp ChainProvider
+}
+
+func NewHandler(cp ChainProvider) *Handler {
+	return &Handler{cp: cp}
+}
+
+// RegisterRoutes registers endpoints of Data API and Construction API
+// under the prefix of the group. Middlewares are applied to the endpoints
+// only, so they may share the group with other routes. The prefix should
+// be used only for them, or endpoints like /block may shadow other routes
+// under the prefix.
+func (h *Handler) RegisterRoutes(g *echo.Group, prefix string, m ...echo.MiddlewareFunc) {
+	g.POST(prefix+"/network/list", h.networkList, m...)
+	g.POST(prefix+"/network/options", h.networkOptions, m...)
+	g.POST(prefix+"/network/status", h.networkStatus, m...)
+	g.POST(prefix+"/block", h.block, m...)
+	g.POST(prefix+"/block/transaction", h.blockTransaction, m...)
+	g.POST(prefix+"/account/balance", h.accountBalance, m...)
+	g.POST(prefix+"/mempool", h.mempool, m...)
+	g.POST(prefix+"/mempool/transaction", h.mempoolTransaction, m...)
+
+	g.POST(prefix+"/construction/derive", h.constructionDerive, m...)
+	g.POST(prefix+"/construction/preprocess", h.constructionPreprocess, m...)
+	g.POST(prefix+"/construction/metadata", h.constructionMetadata, m...)
+	g.POST(prefix+"/construction/payloads", h.constructionPayloads, m...)
+	g.POST(prefix+"/construction/combine", h.constructionCombine, m...)
+	g.POST(prefix+"/construction/parse", h.constructionParse, m...)
+	g.POST(prefix+"/construction/hash", h.constructionHash, m...)
+	g.POST(prefix+"/construction/submit", h.constructionSubmit, m...)
+}
+
+func respond(c echo.Context, res interface{}, err *Error) error {
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func bind(c echo.Context, req interface{}) *Error {
+	if err := c.Bind(req); err != nil {
+		return ErrInvalidRequest.Wrap(err)
+	}
+	return nil
+}
+
+func networkOf(channel string) *NetworkIdentifier {
+	return &NetworkIdentifier{
+		Blockchain: Blockchain,
+		Network:    channel,
+	}
+}
+
+// chainOf returns the chain of the network. Stopped chain is not
+// available.
+func (h *Handler) chainOf(ni *NetworkIdentifier) (module.Chain, *Error) {
+	if ni == nil || ni.Blockchain != Blockchain || ni.Network == "" {
+		return nil, ErrInvalidNetwork
+	}
+	chain := h.cp.Chain(ni.Network)
+	if chain == nil {
+		return nil, ErrInvalidNetwork.Errorf("UnknownNetwork(%s)", ni.Network)
+	}
+	if chain.BlockManager() == nil || chain.ServiceManager() == nil {
+		return nil, ErrNotAvailable.With("Stopped")
+	}
+	return chain, nil
+}
+
+// errorOf converts the error of jsonrpc package.
+func errorOf(err error) *Error {
+	if je, ok := err.(*jsonrpc.Error); ok {
+		switch je.Code {
+		case jsonrpc.ErrorCodeExecuting:
+			return ErrNotFinalized
+		case jsonrpc.ErrorCodeNotFound:
+			return ErrBlockNotFound.With(je.Message)
+		case jsonrpc.ErrorCodeServer:
+			return ErrNotAvailable.With(je.Message)
+		}
+	}
+	return ErrSystem.Wrap(err)
+}
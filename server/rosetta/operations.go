@@ -0,0 +1,165 @@
+package rosetta
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/trace"
+)
+
+const (
+	Blockchain     = "ICON"
+	RosettaVersion = "1.4.12"
+
+	StatusSuccess = "SUCCESS"
+
+	SignatureType = "ecdsa_recovery"
+	CurveType     = "secp256k1"
+)
+
+var ICX = &Currency{
+	Symbol:   "ICX",
+	Decimals: 18,
+}
+
+var opTypeTransfer = trace.OpTypeName(module.Transfer)
+
+func amountOf(v *big.Int) *Amount {
+	return &Amount{
+		Value:    v.String(),
+		Currency: ICX,
+	}
+}
+
+func parseAmount(a *Amount) (*big.Int, *Error) {
+	if a == nil || a.Currency == nil {
+		return nil, ErrInvalidRequest.With("NoAmount")
+	}
+	if a.Currency.Symbol != ICX.Symbol || a.Currency.Decimals != ICX.Decimals {
+		return nil, ErrInvalidRequest.Errorf("InvalidCurrency(%s)", a.Currency.Symbol)
+	}
+	v, ok := new(big.Int).SetString(a.Value, 10)
+	if !ok {
+		return nil, ErrInvalidRequest.Errorf("InvalidAmount(%s)", a.Value)
+	}
+	return v, nil
+}
+
+func accountOf(addr module.Address) *AccountIdentifier {
+	return &AccountIdentifier{Address: addr.String()}
+}
+
+func parseAccount(a *AccountIdentifier) (module.Address, *Error) {
+	if a == nil {
+		return nil, ErrInvalidAccount.With("NoAccount")
+	}
+	addr, err := common.NewAddressFromString(a.Address)
+	if err != nil {
+		return nil, ErrInvalidAccount.Wrap(err)
+	}
+	return addr, nil
+}
+
+// balanceOperations converts balance changes into Rosetta operations.
+// A change with both of sender and receiver is converted into two
+// related operations.
+func balanceOperations(ops []*trace.BalanceOperation, status *string) []*Operation {
+	res := make([]*Operation, 0, len(ops)*2)
+	add := func(opType string, addr module.Address, v *big.Int, related *OperationIdentifier) *OperationIdentifier {
+		id := &OperationIdentifier{Index: int64(len(res))}
+		op := &Operation{
+			OperationIdentifier: id,
+			Type:                opType,
+			Status:              status,
+			Account:             accountOf(addr),
+			Amount:              amountOf(v),
+		}
+		if related != nil {
+			op.RelatedOperations = []*OperationIdentifier{related}
+		}
+		res = append(res, op)
+		return id
+	}
+	for _, op := range ops {
+		opType := trace.OpTypeName(op.Type)
+		var related *OperationIdentifier
+		if op.From != nil {
+			related = add(opType, op.From, new(big.Int).Neg(op.Amount), nil)
+		}
+		if op.To != nil {
+			add(opType, op.To, op.Amount, related)
+		}
+	}
+	return res
+}
+
+func txHashOf(hash []byte, isBlockTx bool) string {
+	if isBlockTx {
+		return "bx" + hex.EncodeToString(hash)
+	}
+	return "0x" + hex.EncodeToString(hash)
+}
+
+func transactionOf(tx *trace.BalanceTransaction) *Transaction {
+	status := StatusSuccess
+	return &Transaction{
+		TransactionIdentifier: &TransactionIdentifier{
+			Hash: txHashOf(tx.Hash, tx.IsBlockTx),
+		},
+		Operations: balanceOperations(tx.Ops, &status),
+	}
+}
+
+// transferOperations returns operations for transferring value.
+func transferOperations(from, to module.Address, value *big.Int, status *string) []*Operation {
+	return balanceOperations([]*trace.BalanceOperation{{
+		Type:   module.Transfer,
+		From:   from,
+		To:     to,
+		Amount: value,
+	}}, status)
+}
+
+// parseTransfer returns sender, receiver and value of the transfer
+// described by the operations. Only a pair of TRANSFER operations, one
+// with negative amount for the sender and the other for the receiver,
+// is supported.
+func parseTransfer(ops []*Operation) (module.Address, module.Address, *big.Int, *Error) {
+	if len(ops) != 2 {
+		return nil, nil, nil, ErrUnsupportedOps.Errorf("InvalidOperationCount(%d)", len(ops))
+	}
+	var from, to module.Address
+	var sent, received *big.Int
+	for _, op := range ops {
+		if op.Type != opTypeTransfer {
+			return nil, nil, nil, ErrUnsupportedOps.Errorf("InvalidOperationType(%s)", op.Type)
+		}
+		addr, err := parseAccount(op.Account)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		v, err := parseAmount(op.Amount)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		// for zero value, the first one is the sender.
+		if v.Sign() < 0 || (v.Sign() == 0 && from == nil) {
+			from, sent = addr, v
+		} else {
+			to, received = addr, v
+		}
+	}
+	if from == nil || to == nil {
+		return nil, nil, nil, ErrUnsupportedOps.With("NoSenderOrReceiver")
+	}
+	if from.IsContract() {
+		return nil, nil, nil, ErrUnsupportedOps.Errorf("InvalidSender(%s)", from)
+	}
+	if new(big.Int).Neg(sent).Cmp(received) != 0 {
+		return nil, nil, nil, ErrUnsupportedOps.Errorf(
+			"AmountMismatch(sent=%s,received=%s)", sent, received)
+	}
+	return from, to, received, nil
+}
@@ -0,0 +1,91 @@
+package rosetta
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/trace"
+)
+
+func TestBalanceOperations(t *testing.T) {
+	from := common.MustNewAddressFromString("hx01")
+	to := common.MustNewAddressFromString("cx02")
+	status := StatusSuccess
+
+	ops := balanceOperations([]*trace.BalanceOperation{
+		{Type: module.Transfer, From: from, To: to, Amount: big.NewInt(100)},
+		{Type: module.Fee, From: from, Amount: big.NewInt(10)},
+		{Type: module.Issue, To: to, Amount: big.NewInt(5)},
+	}, &status)
+	assert.Equal(t, 4, len(ops))
+
+	assert.Equal(t, "TRANSFER", ops[0].Type)
+	assert.Equal(t, from.String(), ops[0].Account.Address)
+	assert.Equal(t, "-100", ops[0].Amount.Value)
+	assert.Nil(t, ops[0].RelatedOperations)
+
+	assert.Equal(t, to.String(), ops[1].Account.Address)
+	assert.Equal(t, "100", ops[1].Amount.Value)
+	assert.Equal(t, []*OperationIdentifier{{Index: 0}}, ops[1].RelatedOperations)
+
+	assert.Equal(t, "FEE", ops[2].Type)
+	assert.Equal(t, "-10", ops[2].Amount.Value)
+	assert.Equal(t, int64(2), ops[2].OperationIdentifier.Index)
+
+	assert.Equal(t, "ISSUE", ops[3].Type)
+	assert.Equal(t, "5", ops[3].Amount.Value)
+	assert.Nil(t, ops[3].RelatedOperations)
+	for _, op := range ops {
+		assert.Equal(t, &status, op.Status)
+	}
+}
+
+func TestParseTransfer(t *testing.T) {
+	from := common.MustNewAddressFromString("hx01")
+	to := common.MustNewAddressFromString("cx02")
+
+	ops := transferOperations(from, to, big.NewInt(100), nil)
+	pf, pt, pv, err := parseTransfer(ops)
+	assert.Nil(t, err)
+	assert.True(t, from.Equal(pf))
+	assert.True(t, to.Equal(pt))
+	assert.Equal(t, int64(100), pv.Int64())
+
+	// order of operations doesn't matter
+	pf, pt, _, err = parseTransfer([]*Operation{ops[1], ops[0]})
+	assert.Nil(t, err)
+	assert.True(t, from.Equal(pf))
+	assert.True(t, to.Equal(pt))
+
+	// zero value
+	pf, pt, pv, err = parseTransfer(transferOperations(from, to, big.NewInt(0), nil))
+	assert.Nil(t, err)
+	assert.True(t, from.Equal(pf))
+	assert.True(t, to.Equal(pt))
+	assert.Equal(t, 0, pv.Sign())
+
+	_, _, _, err = parseTransfer(ops[:1])
+	assert.Equal(t, ErrUnsupportedOps.Code, err.Code)
+
+	_, _, _, err = parseTransfer(transferOperations(to, from, big.NewInt(100), nil))
+	assert.Equal(t, ErrUnsupportedOps.Code, err.Code)
+
+	mismatch := transferOperations(from, to, big.NewInt(100), nil)
+	mismatch[1].Amount.Value = "99"
+	_, _, _, err = parseTransfer(mismatch)
+	assert.Equal(t, ErrUnsupportedOps.Code, err.Code)
+
+	fee := transferOperations(from, to, big.NewInt(100), nil)
+	fee[0].Type = "FEE"
+	_, _, _, err = parseTransfer(fee)
+	assert.Equal(t, ErrUnsupportedOps.Code, err.Code)
+
+	currency := transferOperations(from, to, big.NewInt(100), nil)
+	currency[0].Amount.Currency = &Currency{Symbol: "ETH", Decimals: 18}
+	_, _, _, err = parseTransfer(currency)
+	assert.Equal(t, ErrInvalidRequest.Code, err.Code)
+}
@@ -0,0 +1,262 @@
+package rosetta
+
+// Types of Rosetta API. See https://www.rosetta-api.org/docs/api_objects.html
+
+type NetworkIdentifier struct {
+	Blockchain string `json:"blockchain"`
+	Network    string `json:"network"`
+}
+
+type BlockIdentifier struct {
+	Index int64  `json:"index"`
+	Hash  string `json:"hash"`
+}
+
+type PartialBlockIdentifier struct {
+	Index *int64  `json:"index,omitempty"`
+	Hash  *string `json:"hash,omitempty"`
+}
+
+type TransactionIdentifier struct {
+	Hash string `json:"hash"`
+}
+
+type AccountIdentifier struct {
+	Address string `json:"address"`
+}
+
+type Currency struct {
+	Symbol   string `json:"symbol"`
+	Decimals int32  `json:"decimals"`
+}
+
+type Amount struct {
+	Value    string    `json:"value"`
+	Currency *Currency `json:"currency"`
+}
+
+type OperationIdentifier struct {
+	Index int64 `json:"index"`
+}
+
+type Operation struct {
+	OperationIdentifier *OperationIdentifier   `json:"operation_identifier"`
+	RelatedOperations   []*OperationIdentifier `json:"related_operations,omitempty"`
+	Type                string                 `json:"type"`
+	Status              *string                `json:"status,omitempty"`
+	Account             *AccountIdentifier     `json:"account,omitempty"`
+	Amount              *Amount                `json:"amount,omitempty"`
+}
+
+type Transaction struct {
+	TransactionIdentifier *TransactionIdentifier `json:"transaction_identifier"`
+	Operations            []*Operation           `json:"operations"`
+}
+
+type Block struct {
+	BlockIdentifier       *BlockIdentifier `json:"block_identifier"`
+	ParentBlockIdentifier *BlockIdentifier `json:"parent_block_identifier"`
+	Timestamp             int64            `json:"timestamp"`
+	Transactions          []*Transaction   `json:"transactions"`
+}
+
+type Version struct {
+	RosettaVersion string `json:"rosetta_version"`
+	NodeVersion    string `json:"node_version"`
+}
+
+type OperationStatus struct {
+	Status     string `json:"status"`
+	Successful bool   `json:"successful"`
+}
+
+type Allow struct {
+	OperationStatuses       []*OperationStatus `json:"operation_statuses"`
+	OperationTypes          []string           `json:"operation_types"`
+	Errors                  []*Error           `json:"errors"`
+	HistoricalBalanceLookup bool               `json:"historical_balance_lookup"`
+	CallMethods             []string           `json:"call_methods"`
+	BalanceExemptions       []interface{}      `json:"balance_exemptions"`
+	MempoolCoins            bool               `json:"mempool_coins"`
+}
+
+type Peer struct {
+	PeerID string `json:"peer_id"`
+}
+
+type PublicKey struct {
+	HexBytes  string `json:"hex_bytes"`
+	CurveType string `json:"curve_type"`
+}
+
+type SigningPayload struct {
+	AccountIdentifier *AccountIdentifier `json:"account_identifier"`
+	HexBytes          string             `json:"hex_bytes"`
+	SignatureType     string             `json:"signature_type"`
+}
+
+type Signature struct {
+	SigningPayload *SigningPayload `json:"signing_payload"`
+	PublicKey      *PublicKey      `json:"public_key"`
+	SignatureType  string          `json:"signature_type"`
+	HexBytes       string          `json:"hex_bytes"`
+}
+
+// Requests and responses of Data API
+
+type NetworkRequest struct {
+	NetworkIdentifier *NetworkIdentifier `json:"network_identifier"`
+}
+
+type NetworkListResponse struct {
+	NetworkIdentifiers []*NetworkIdentifier `json:"network_identifiers"`
+}
+
+type NetworkOptionsResponse struct {
+	Version *Version `json:"version"`
+	Allow   *Allow   `json:"allow"`
+}
+
+type NetworkStatusResponse struct {
+	CurrentBlockIdentifier *BlockIdentifier `json:"current_block_identifier"`
+	CurrentBlockTimestamp  int64            `json:"current_block_timestamp"`
+	GenesisBlockIdentifier *BlockIdentifier `json:"genesis_block_identifier"`
+	Peers                  []*Peer          `json:"peers"`
+}
+
+type BlockRequest struct {
+	NetworkIdentifier *NetworkIdentifier      `json:"network_identifier"`
+	BlockIdentifier   *PartialBlockIdentifier `json:"block_identifier"`
+}
+
+type BlockResponse struct {
+	Block *Block `json:"block"`
+}
+
+type BlockTransactionRequest struct {
+	NetworkIdentifier     *NetworkIdentifier     `json:"network_identifier"`
+	BlockIdentifier       *BlockIdentifier       `json:"block_identifier"`
+	TransactionIdentifier *TransactionIdentifier `json:"transaction_identifier"`
+}
+
+type BlockTransactionResponse struct {
+	Transaction *Transaction `json:"transaction"`
+}
+
+type AccountBalanceRequest struct {
+	NetworkIdentifier *NetworkIdentifier      `json:"network_identifier"`
+	AccountIdentifier *AccountIdentifier      `json:"account_identifier"`
+	BlockIdentifier   *PartialBlockIdentifier `json:"block_identifier,omitempty"`
+}
+
+type AccountBalanceResponse struct {
+	BlockIdentifier *BlockIdentifier `json:"block_identifier"`
+	Balances        []*Amount        `json:"balances"`
+}
+
+type MempoolResponse struct {
+	TransactionIdentifiers []*TransactionIdentifier `json:"transaction_identifiers"`
+}
+
+type MempoolTransactionRequest struct {
+	NetworkIdentifier     *NetworkIdentifier     `json:"network_identifier"`
+	TransactionIdentifier *TransactionIdentifier `json:"transaction_identifier"`
+}
+
+type MempoolTransactionResponse struct {
+	Transaction *Transaction `json:"transaction"`
+}
+
+// Requests and responses of Construction API
+
+type ConstructionDeriveRequest struct {
+	NetworkIdentifier *NetworkIdentifier `json:"network_identifier"`
+	PublicKey         *PublicKey         `json:"public_key"`
+}
+
+type ConstructionDeriveResponse struct {
+	AccountIdentifier *AccountIdentifier `json:"account_identifier"`
+}
+
+type ConstructionPreprocessRequest struct {
+	NetworkIdentifier *NetworkIdentifier `json:"network_identifier"`
+	Operations        []*Operation       `json:"operations"`
+}
+
+type ConstructionPreprocessResponse struct {
+	Options            *TransferOptions     `json:"options"`
+	RequiredPublicKeys []*AccountIdentifier `json:"required_public_keys"`
+}
+
+// TransferOptions is the options for /construction/metadata made by
+// /construction/preprocess.
+type TransferOptions struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Value string `json:"value"`
+}
+
+type ConstructionMetadataRequest struct {
+	NetworkIdentifier *NetworkIdentifier `json:"network_identifier"`
+	Options           *TransferOptions   `json:"options"`
+}
+
+type ConstructionMetadataResponse struct {
+	Metadata     *TransactionMetadata `json:"metadata"`
+	SuggestedFee []*Amount            `json:"suggested_fee"`
+}
+
+// TransactionMetadata is the metadata for /construction/payloads made by
+// /construction/metadata. Values are formatted in the same way as fields of
+// the transaction.
+type TransactionMetadata struct {
+	NID       string `json:"nid"`
+	StepLimit string `json:"stepLimit"`
+	Timestamp string `json:"timestamp"`
+}
+
+type ConstructionPayloadsRequest struct {
+	NetworkIdentifier *NetworkIdentifier   `json:"network_identifier"`
+	Operations        []*Operation         `json:"operations"`
+	Metadata          *TransactionMetadata `json:"metadata"`
+}
+
+type ConstructionPayloadsResponse struct {
+	UnsignedTransaction string            `json:"unsigned_transaction"`
+	Payloads            []*SigningPayload `json:"payloads"`
+}
+
+type ConstructionCombineRequest struct {
+	NetworkIdentifier   *NetworkIdentifier `json:"network_identifier"`
+	UnsignedTransaction string             `json:"unsigned_transaction"`
+	Signatures          []*Signature       `json:"signatures"`
+}
+
+type ConstructionCombineResponse struct {
+	SignedTransaction string `json:"signed_transaction"`
+}
+
+type ConstructionParseRequest struct {
+	NetworkIdentifier *NetworkIdentifier `json:"network_identifier"`
+	Signed            bool               `json:"signed"`
+	Transaction       string             `json:"transaction"`
+}
+
+type ConstructionParseResponse struct {
+	Operations               []*Operation         `json:"operations"`
+	AccountIdentifierSigners []*AccountIdentifier `json:"account_identifier_signers,omitempty"`
+}
+
+type ConstructionHashRequest struct {
+	NetworkIdentifier *NetworkIdentifier `json:"network_identifier"`
+	SignedTransaction string             `json:"signed_transaction"`
+}
+
+type ConstructionSubmitRequest struct {
+	NetworkIdentifier *NetworkIdentifier `json:"network_identifier"`
+	SignedTransaction string             `json:"signed_transaction"`
+}
+
+type TransactionIdentifierResponse struct {
+	TransactionIdentifier *TransactionIdentifier `json:"transaction_identifier"`
+}
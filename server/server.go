@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"net/http"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -12,8 +13,11 @@ import (
 
 	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/eth"
+	"github.com/icon-project/goloop/server/graphql"
 	"github.com/icon-project/goloop/server/jsonrpc"
 	"github.com/icon-project/goloop/server/metric"
+	"github.com/icon-project/goloop/server/rosetta"
 	"github.com/icon-project/goloop/server/v3"
 )
 
@@ -28,9 +32,13 @@ type Config struct {
 	JSONRPCDump           bool
 	JSONRPCIncludeDebug   bool
 	JSONRPCRosetta        bool
+	JSONRPCEth            bool
+	GraphQL               bool
+	GraphQLMaxCost        int
 	JSONRPCDefaultChannel string
 	JSONRPCBatchLimit     int
 	WSMaxSession          int
+	RateLimit             *RateLimitConfig
 }
 
 type Manager struct {
@@ -43,11 +51,15 @@ type Manager struct {
 	jsonrpcDefaultChannel string
 	jsonrpcMessageDump    int32
 	jsonrpcRosetta        int32
+	jsonrpcEth            int32
+	graphql               int32
+	graphqlHandler        *graphql.Handler
 	jsonrpcIncludeDebug   int32
 	jsonrpcBatchLimit     int32
 	logger                log.Logger
 	metricsHandler        echo.HandlerFunc
 	mtr                   *metric.JsonrpcMetric
+	rl                    *rateLimiter
 }
 
 func NewManager(
@@ -80,10 +92,15 @@ func NewManager(
 		logger:                logger,
 		metricsHandler:        echo.WrapHandler(metric.PrometheusExporter()),
 		mtr:                   mtr,
+		graphqlHandler:        graphql.NewHandler(config.GraphQLMaxCost),
+		rl:                    newRateLimiter(),
 	}
 	m.SetMessageDump(config.JSONRPCDump)
 	m.SetIncludeDebug(config.JSONRPCIncludeDebug)
 	m.SetRosetta(config.JSONRPCRosetta)
+	m.SetEth(config.JSONRPCEth)
+	m.SetGraphQL(config.GraphQL)
+	m.SetRateLimit(config.RateLimit)
 	return m
 }
 
@@ -126,6 +143,19 @@ func (srv *Manager) Chain(channel string) module.Chain {
 	return srv.chains[channel]
 }
 
+// Channels returns sorted channels of the chains.
+func (srv *Manager) Channels() []string {
+	defer srv.mtx.RUnlock()
+	srv.mtx.RLock()
+
+	channels := make([]string, 0, len(srv.chains))
+	for channel := range srv.chains {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
 func (srv *Manager) SetDefaultChannel(jsonrpcDefaultChannel string) {
 	defer srv.mtx.Unlock()
 	srv.mtx.Lock()
@@ -172,6 +202,22 @@ func (srv *Manager) Rosetta() bool {
 	return atomicLoad(&srv.jsonrpcRosetta)
 }
 
+func (srv *Manager) SetEth(enable bool) {
+	atomicStore(&srv.jsonrpcEth, enable)
+}
+
+func (srv *Manager) Eth() bool {
+	return atomicLoad(&srv.jsonrpcEth)
+}
+
+func (srv *Manager) SetGraphQL(enable bool) {
+	atomicStore(&srv.graphql, enable)
+}
+
+func (srv *Manager) GraphQL() bool {
+	return atomicLoad(&srv.graphql)
+}
+
 func (srv *Manager) SetBatchLimit(limitOfBatch int) {
 	atomic.StoreInt32(&srv.jsonrpcBatchLimit, int32(limitOfBatch))
 }
@@ -223,31 +269,53 @@ func (srv *Manager) RegisterAPIHandler(g *echo.Group) {
 	// v3 APIs
 	mr := v3.MethodRepository(srv.mtr)
 	v3api := rpc.Group("/v3")
-	v3api.Use(JsonRpc(), Chunk())
+	v3api.Use(JsonRpc(), srv.RateLimiter(RateLimitGroupV3), Chunk())
 	v3api.POST("", mr.Handle, ChainInjector(srv))
 	v3api.POST("/", mr.Handle, ChainInjector(srv))
 	v3api.POST("/:channel", mr.Handle, ChainInjector(srv))
 
 	dmr := v3.DebugMethodRepository(srv.mtr)
 	v3dbg := rpc.Group("/v3d")
-	v3dbg.Use(srv.CheckDebug(), JsonRpc(), Chunk())
+	v3dbg.Use(srv.CheckDebug(), JsonRpc(), srv.RateLimiter(RateLimitGroupV3d), Chunk())
 	v3dbg.POST("", dmr.Handle, ChainInjector(srv))
 	v3dbg.POST("/", dmr.Handle, ChainInjector(srv))
 	v3dbg.POST("/:channel", dmr.Handle, ChainInjector(srv))
 
 	// Rosetta APIs
 	rmr := v3.RosettaMethodRepository(srv.mtr)
+	rh := rosetta.NewHandler(srv)
 	rosetta := rpc.Group("/rosetta")
-	rosetta.Use(srv.CheckRosetta(), JsonRpc(), Chunk())
+	rosetta.Use(srv.CheckRosetta(), JsonRpc(), srv.RateLimiter(RateLimitGroupRosetta), Chunk())
 	rosetta.POST("", rmr.Handle, ChainInjector(srv))
 	rosetta.POST("/", rmr.Handle, ChainInjector(srv))
 	rosetta.POST("/:channel", rmr.Handle, ChainInjector(srv))
+	// REST APIs are under their own prefix not to shadow the channels
+	rh.RegisterRoutes(rpc, "/rosetta/rest", srv.CheckRosetta(), srv.RateLimiter(RateLimitGroupRosetta))
+
+	// Ethereum compatible APIs
+	emr := eth.MethodRepository(srv.mtr)
+	ethapi := rpc.Group("/eth")
+	ethapi.Use(srv.CheckEth(), JsonRpc(), srv.RateLimiter(RateLimitGroupEth), Chunk())
+	ethapi.POST("", emr.Handle, ChainInjector(srv))
+	ethapi.POST("/", emr.Handle, ChainInjector(srv))
+	ethapi.POST("/:channel", emr.Handle, ChainInjector(srv))
+
+	// GraphQL
+	gh := srv.graphqlHandler.Handle
+	gql := rpc.Group("/graphql")
+	gql.Use(srv.CheckGraphQL(), srv.RateLimiter(RateLimitGroupGraphQL), Chunk())
+	gql.GET("", gh, ChainInjector(srv))
+	gql.POST("", gh, ChainInjector(srv))
+	gql.GET("/:channel", gh, ChainInjector(srv))
+	gql.POST("/:channel", gh, ChainInjector(srv))
 
 	// group for websocket
 	ws := g.Group("")
-	ws.GET("/v3/:channel/block", srv.wssm.RunBlockSession, ChainInjector(srv))
-	ws.GET("/v3/:channel/event", srv.wssm.RunEventSession, ChainInjector(srv))
-	ws.GET("/v3/:channel/btp", srv.wssm.RunBtpSession, ChainInjector(srv))
+	wsrl := srv.RateLimiter(RateLimitGroupWS)
+	ws.GET("/v3/:channel/block", srv.wssm.RunBlockSession, wsrl, ChainInjector(srv))
+	ws.GET("/v3/:channel/event", srv.wssm.RunEventSession, wsrl, ChainInjector(srv))
+	ws.GET("/v3/:channel/btp", srv.wssm.RunBtpSession, wsrl, ChainInjector(srv))
+	ws.GET("/v3d/:channel/trace", srv.wssm.RunTraceSession, srv.CheckDebug(), wsrl, ChainInjector(srv))
 }
 
 func (srv *Manager) RegisterMetricsHandler(g *echo.Group) {
@@ -281,6 +349,28 @@ func (srv *Manager) CheckRosetta() echo.MiddlewareFunc {
 	}
 }
 
+func (srv *Manager) CheckEth() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if !srv.Eth() {
+				return ctx.String(http.StatusNotFound, "rpc_eth is false")
+			}
+			return next(ctx)
+		}
+	}
+}
+
+func (srv *Manager) CheckGraphQL() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if !srv.GraphQL() {
+				return ctx.String(http.StatusNotFound, "rpc_graphql is false")
+			}
+			return next(ctx)
+		}
+	}
+}
+
 func (srv *Manager) Stop() error {
 	srv.logger.Infoln("shutting down the server")
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
@@ -15,12 +16,14 @@ import (
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/server/jsonrpc"
 	"github.com/icon-project/goloop/server/metric"
 	"github.com/icon-project/goloop/service"
 	"github.com/icon-project/goloop/service/scoreresult"
 	"github.com/icon-project/goloop/service/trace"
+	"github.com/icon-project/goloop/service/transaction"
 	"github.com/icon-project/goloop/service/txresult"
 )
 
@@ -28,6 +31,12 @@ const (
 	ConfigShowPatchTransaction = false
 )
 
+// Limits of APIs returning event logs over a range of blocks.
+const (
+	LogsMaxLimit = 1000
+	LogsMaxRange = 1000
+)
+
 func MethodRepository(mtr *metric.JsonrpcMetric) *jsonrpc.MethodRepository {
 	mr := jsonrpc.NewMethodRepository(mtr)
 
@@ -42,6 +51,7 @@ func MethodRepository(mtr *metric.JsonrpcMetric) *jsonrpc.MethodRepository {
 	mr.RegisterMethod("icx_getTransactionByHash", getTransactionByHash)
 	mr.RegisterMethod("icx_sendTransaction", sendTransaction)
 	mr.RegisterMethod("icx_sendTransactionAndWait", sendTransactionAndWait)
+	mr.RegisterMethod("icx_cancelTransaction", cancelTransaction)
 	mr.RegisterMethod("icx_waitTransactionResult", waitTransactionResult)
 
 	mr.RegisterMethod("icx_getDataByHash", getDataByHash)
@@ -49,7 +59,12 @@ func MethodRepository(mtr *metric.JsonrpcMetric) *jsonrpc.MethodRepository {
 	mr.RegisterMethod("icx_getVotesByHeight", getVotesByHeight)
 	mr.RegisterMethod("icx_getProofForResult", getProofForResult)
 	mr.RegisterMethod("icx_getProofForEvents", getProofForEvents)
+	mr.RegisterMethod("icx_getProofForAccount", getProofForAccount)
+	mr.RegisterMethod("icx_getProofForStorage", getProofForStorage)
 	mr.RegisterMethod("icx_getScoreStatus", getScoreStatus)
+	mr.RegisterMethod("icx_getTransactionsByAddress", getTransactionsByAddress)
+	mr.RegisterMethod("icx_getEventLogsByAddress", getEventLogsByAddress)
+	mr.RegisterMethod("icx_getLogs", getLogs)
 
 	mr.RegisterMethod("btp_getNetworkInfo", getBTPNetworkInfo)
 	mr.RegisterMethod("btp_getNetworkTypeInfo", getBTPNetworkTypeInfo)
@@ -84,7 +99,9 @@ func fillTransactions(blockJson interface{}, b module.Block, v module.JSONVersio
 	return nil
 }
 
-func checkBaseHeight(c module.Chain, height int64) error {
+// CheckBaseHeight returns NotFoundError if the block at the height is
+// before the base height of the chain, which has no states.
+func CheckBaseHeight(c module.Chain, height int64) error {
 	if height < 0 {
 		return errors.NotFoundError.Errorf("NegativeHeight(height=%d)", height)
 	}
@@ -146,7 +163,7 @@ func getBlockByHeight(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}
 		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
 	}
 
-	if err := checkBaseHeight(chain, height); err != nil {
+	if err := CheckBaseHeight(chain, height); err != nil {
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
 	}
 
@@ -197,7 +214,7 @@ func getBlockByHash(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{},
 	} else if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
-	if err := checkBaseHeight(chain, block.Height()); err != nil {
+	if err := CheckBaseHeight(chain, block.Height()); err != nil {
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
 	}
 
@@ -258,7 +275,7 @@ func getBlock(chain module.Chain, bm module.BlockManager, height jsonrpc.HexInt)
 		block, err = bm.GetLastBlock()
 	} else {
 		h, _ := height.Int64()
-		if err := checkBaseHeight(chain, h); err != nil {
+		if err := CheckBaseHeight(chain, h); err != nil {
 			return nil, err
 		}
 		block, err = bm.GetBlockByHeight(h)
@@ -397,7 +414,7 @@ func getTransactionResult(ctx *jsonrpc.Context, params *jsonrpc.Params) (interfa
 	}
 
 	blk := txInfo.Block()
-	if err := checkBaseHeight(chain, blk.Height()); err != nil {
+	if err := CheckBaseHeight(chain, blk.Height()); err != nil {
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
 	}
 	receipt, err := txInfo.GetReceipt()
@@ -445,13 +462,21 @@ func getTransactionByHash(ctx *jsonrpc.Context, params *jsonrpc.Params) (interfa
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
 
-	tx, err := txInfo.Transaction()
+	result, err := transactionInfoToJSON(txInfo)
 	if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
+	return result, nil
+}
+
+func transactionInfoToJSON(txInfo module.TransactionInfo) (map[string]interface{}, error) {
+	tx, err := txInfo.Transaction()
+	if err != nil {
+		return nil, err
+	}
 	res, err := tx.ToJSON(module.JSONVersion3)
 	if err != nil {
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		return nil, err
 	}
 
 	blk := txInfo.Block()
@@ -459,7 +484,6 @@ func getTransactionByHash(ctx *jsonrpc.Context, params *jsonrpc.Params) (interfa
 	result["blockHash"] = "0x" + hex.EncodeToString(blk.ID())
 	result["blockHeight"] = "0x" + strconv.FormatInt(int64(blk.Height()), 16)
 	result["txIndex"] = "0x" + strconv.FormatInt(int64(txInfo.Index()), 16)
-
 	return result, nil
 }
 
@@ -498,6 +522,47 @@ func sendTransaction(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{},
 		if service.TransactionPoolOverflowError.Equals(err) {
 			return nil, jsonrpc.ErrorCodeTxPoolOverflow.Wrap(err, debug)
 		}
+		if service.SenderLimitExceededError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeSenderLimit.Wrap(err, debug)
+		}
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+
+	result := "0x" + hex.EncodeToString(hash)
+
+	return result, nil
+}
+
+func cancelTransaction(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var param CancelTransactionParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	sm := chain.ServiceManager()
+	if sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	hash, err := sm.CancelTransaction(params.RawMessage())
+	if err != nil {
+		switch {
+		case errors.NotFoundError.Equals(err):
+			return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+		case errors.IllegalArgumentError.Equals(err),
+			errors.InvalidNetworkError.Equals(err),
+			transaction.InvalidSignatureError.Equals(err):
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		case errors.InvalidStateError.Equals(err):
+			return nil, jsonrpc.ErrorCodeInvalidRequest.Wrap(err, debug)
+		}
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
 
@@ -554,7 +619,7 @@ func getBlockHeaderByHeight(ctx *jsonrpc.Context, params *jsonrpc.Params) (inter
 		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
 	}
 
-	if err := checkBaseHeight(chain, height); err != nil {
+	if err := CheckBaseHeight(chain, height); err != nil {
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
 	}
 
@@ -595,7 +660,7 @@ func getVotesByHeight(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}
 		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
 	}
 
-	if err := checkBaseHeight(chain, height); err != nil {
+	if err := CheckBaseHeight(chain, height); err != nil {
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
 	}
 
@@ -642,7 +707,7 @@ func getProofForResult(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{
 	} else if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
-	if err := checkBaseHeight(chain, block.Height()); err != nil {
+	if err := CheckBaseHeight(chain, block.Height()); err != nil {
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
 	}
 
@@ -695,7 +760,7 @@ func getProofForEvents(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{
 	} else if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
-	if err := checkBaseHeight(chain, block.Height()); err != nil {
+	if err := CheckBaseHeight(chain, block.Height()); err != nil {
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
 	}
 
@@ -735,6 +800,60 @@ func getProofForEvents(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{
 	return proofs, nil
 }
 
+func getProofForAccount(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var param AddressParam
+	debug := ctx.IncludeDebug()
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	return getAccountProof(ctx, param.Address, nil, param.Height)
+}
+
+func getProofForStorage(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var param ProofStorageParam
+	debug := ctx.IncludeDebug()
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	keys := make([][]byte, len(param.Keys))
+	for i, k := range param.Keys {
+		keys[i] = k.Bytes()
+	}
+	return getAccountProof(ctx, param.Score, keys, param.Height)
+}
+
+func getAccountProof(ctx *jsonrpc.Context, addr jsonrpc.Address, keys [][]byte, height jsonrpc.HexInt) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+	b, err := getBlock(chain, bm, height)
+	if err != nil {
+		if errors.NotFoundError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+		}
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	p, err := sm.GetAccountProof(b.Result(), addr.Address(), keys)
+	if err != nil {
+		if errors.IllegalArgumentError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		}
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	jso, err := p.ToJSON(module.JSONVersion3)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	return jso, nil
+}
+
 func getScoreStatus(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 	var param ScoreAddressParam
 	debug := ctx.IncludeDebug()
@@ -771,6 +890,307 @@ func getScoreStatus(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{},
 	return jso, nil
 }
 
+const (
+	addressIndexDefaultLimit = 20
+	addressIndexMaxLimit     = 100
+)
+
+// addressIndexQuery resolves the address index and the range of the query.
+func addressIndexQuery(ctx *jsonrpc.Context, param *AddressIndexParam) (module.AddressIndex, int64, int64, int, error) {
+	debug := ctx.IncludeDebug()
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, 0, 0, 0, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+	bm := chain.BlockManager()
+	if bm == nil {
+		return nil, 0, 0, 0, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+	ai, err := bm.GetAddressIndex()
+	if err != nil {
+		return nil, 0, 0, 0, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+	from, to, err := ai.Range()
+	if err != nil {
+		return nil, 0, 0, 0, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	if from > to {
+		return nil, 0, 0, 0, jsonrpc.ErrorCodeNotFound.New("NoIndexedBlock")
+	}
+	if param.FromHeight != "" {
+		h, _ := param.FromHeight.Int64()
+		if h < from {
+			return nil, 0, 0, 0, jsonrpc.ErrorCodeNotFound.Errorf(
+				"NotIndexedHeight(height=%d,from=%d)", h, from)
+		}
+		from = h
+	}
+	if param.ToHeight != "" {
+		h, _ := param.ToHeight.Int64()
+		if h > to {
+			return nil, 0, 0, 0, jsonrpc.ErrorCodeNotFound.Errorf(
+				"NotIndexedHeight(height=%d,to=%d)", h, to)
+		}
+		to = h
+	}
+	if from > to {
+		return nil, 0, 0, 0, jsonrpc.ErrorCodeInvalidParams.Errorf(
+			"InvalidRange(from=%d,to=%d)", from, to)
+	}
+	limit := addressIndexDefaultLimit
+	if param.Limit != "" {
+		l, _ := param.Limit.Int64()
+		if l <= 0 || l > addressIndexMaxLimit {
+			return nil, 0, 0, 0, jsonrpc.ErrorCodeInvalidParams.Errorf(
+				"InvalidLimit(limit=%d,max=%d)", l, addressIndexMaxLimit)
+		}
+		limit = int(l)
+	}
+	return ai, from, to, limit, nil
+}
+
+func addressIndexResult(from, to int64, key string, items []interface{}, cursor []byte) map[string]interface{} {
+	result := map[string]interface{}{
+		"fromHeight": "0x" + strconv.FormatInt(from, 16),
+		"toHeight":   "0x" + strconv.FormatInt(to, 16),
+		key:          items,
+	}
+	if cursor != nil {
+		result["cursor"] = "0x" + hex.EncodeToString(cursor)
+	}
+	return result
+}
+
+func getTransactionsByAddress(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var param AddressIndexParam
+	debug := ctx.IncludeDebug()
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	ai, from, to, limit, err := addressIndexQuery(ctx, &param)
+	if err != nil {
+		return nil, err
+	}
+	var cursor []byte
+	if param.Cursor != "" {
+		cursor = param.Cursor.Bytes()
+	}
+	txs, cursor, err := ai.GetTransactions(param.Address.Address(), from, to, cursor, limit)
+	if err != nil {
+		if errors.IllegalArgumentError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		}
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	items := make([]interface{}, 0, len(txs))
+	for _, txInfo := range txs {
+		item, err := transactionInfoToJSON(txInfo)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		items = append(items, item)
+	}
+	return addressIndexResult(from, to, "transactions", items, cursor), nil
+}
+
+func getEventLogsByAddress(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var param AddressIndexParam
+	debug := ctx.IncludeDebug()
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	ai, from, to, limit, err := addressIndexQuery(ctx, &param)
+	if err != nil {
+		return nil, err
+	}
+	var cursor []byte
+	if param.Cursor != "" {
+		cursor = param.Cursor.Bytes()
+	}
+	els, cursor, err := ai.GetEventLogs(param.Address.Address(), from, to, cursor, limit)
+	if err != nil {
+		if errors.IllegalArgumentError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		}
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	items := make([]interface{}, 0, len(els))
+	for _, elInfo := range els {
+		el, err := elInfo.EventLog()
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		txInfo := elInfo.TransactionInfo()
+		tx, err := txInfo.Transaction()
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		blk := txInfo.Block()
+		items = append(items, map[string]interface{}{
+			"txHash":      "0x" + hex.EncodeToString(tx.ID()),
+			"blockHash":   "0x" + hex.EncodeToString(blk.ID()),
+			"blockHeight": "0x" + strconv.FormatInt(blk.Height(), 16),
+			"txIndex":     "0x" + strconv.FormatInt(int64(txInfo.Index()), 16),
+			"eventIndex":  "0x" + strconv.FormatInt(int64(elInfo.Index()), 16),
+			"eventLog":    el,
+		})
+	}
+	return addressIndexResult(from, to, "eventLogs", items, cursor), nil
+}
+
+type LogsResult struct {
+	LastHeight common.HexInt64 `json:"lastHeight"`
+	Logs       []*LogInfo      `json:"logs"`
+}
+
+type LogInfo struct {
+	BlockHash   common.HexBytes `json:"blockHash"`
+	BlockHeight common.HexInt64 `json:"blockHeight"`
+	TxHash      common.HexBytes `json:"txHash"`
+	TxIndex     common.HexInt32 `json:"txIndex"`
+	EventIndex  common.HexInt32 `json:"eventIndex"`
+	EventLog    module.EventLog `json:"eventLog"`
+}
+
+// getLogs returns event logs of normal transactions in the range of
+// block heights matching the filter. It returns logs of whole blocks, so
+// LastHeight of the result is less than ToHeight if logs of the next block
+// exceed the limit. It scans at most LogsMaxRange blocks in a call, so
+// clients should continue from LastHeight+1 until it reaches ToHeight.
+func getLogs(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var param LogsParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	if err := param.Compile(); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	limit := LogsMaxLimit
+	if param.Limit != nil {
+		if l := int(param.Limit.Value); l > 0 && l < limit {
+			limit = l
+		}
+	}
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	// results of normal transactions in a block are in the next block.
+	last, err := bm.GetLastBlock()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	from := param.FromHeight.Value
+	to := last.Height() - 1
+	if param.ToHeight != nil {
+		if param.ToHeight.Value > to {
+			return nil, jsonrpc.ErrorCodeNotFound.Errorf(
+				"NoResultYet(toHeight=%d,last=%d)", param.ToHeight.Value, to)
+		}
+		to = param.ToHeight.Value
+	}
+	if gh := chain.GenesisStorage().Height(); from < gh {
+		return nil, jsonrpc.ErrorCodeNotFound.Errorf(
+			"PrunedBlock(fromHeight=%d,base=%d)", from, gh)
+	}
+	if from > to {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Errorf(
+			"InvalidRange(fromHeight=%d,toHeight=%d)", from, to)
+	}
+
+	if to-from+1 > LogsMaxRange {
+		to = from + LogsMaxRange - 1
+	}
+
+	reqCtx := ctx.Request().Context()
+	result := &LogsResult{
+		LastHeight: common.HexInt64{Value: from - 1},
+		Logs:       []*LogInfo{},
+	}
+	for h := from; h <= to; h++ {
+		if err := reqCtx.Err(); err != nil {
+			return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+		}
+		rblk, err := bm.GetBlockByHeight(h + 1)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		if !param.ContainedIn(rblk.LogsBloom()) {
+			result.LastHeight.Value = h
+			continue
+		}
+		logs, err := getLogsOfBlock(bm, sm, &param, h, rblk)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		if len(result.Logs)+len(logs) > limit {
+			if len(result.Logs) == 0 {
+				return nil, jsonrpc.ErrorCodeInvalidRequest.Errorf(
+					"TooManyLogs(height=%d,logs=%d,limit=%d)", h, len(logs), limit)
+			}
+			break
+		}
+		result.Logs = append(result.Logs, logs...)
+		result.LastHeight.Value = h
+	}
+	return result, nil
+}
+
+func getLogsOfBlock(
+	bm module.BlockManager, sm module.ServiceManager,
+	param *LogsParam, height int64, rblk module.Block,
+) ([]*LogInfo, error) {
+	rl, err := sm.ReceiptListFromResult(rblk.Result(), module.TransactionGroupNormal)
+	if err != nil {
+		return nil, err
+	}
+	var blk module.Block
+	var logs []*LogInfo
+	index := 0
+	for rit := rl.Iterator(); rit.Has(); log.Must(rit.Next()) {
+		rct, err := rit.Get()
+		if err != nil {
+			return nil, err
+		}
+		indexes, els, err := param.MatchWithLogs(rct, true)
+		if err != nil {
+			return nil, err
+		}
+		if len(indexes) > 0 {
+			if blk == nil {
+				if blk, err = bm.GetBlockByHeight(height); err != nil {
+					return nil, err
+				}
+			}
+			tx, err := blk.NormalTransactions().Get(index)
+			if err != nil {
+				return nil, err
+			}
+			for i, idx := range indexes {
+				logs = append(logs, &LogInfo{
+					BlockHash:   blk.ID(),
+					BlockHeight: common.HexInt64{Value: height},
+					TxHash:      tx.ID(),
+					TxIndex:     common.HexInt32{Value: int32(index)},
+					EventIndex:  idx,
+					EventLog:    els[i],
+				})
+			}
+		}
+		index++
+	}
+	return logs, nil
+}
+
 func getBTPNetworkInfo(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 	debug := ctx.IncludeDebug()
 
@@ -895,7 +1315,7 @@ func getBTPMessages(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{},
 		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
 	}
 
-	if err := checkBaseHeight(chain, height); err != nil {
+	if err := CheckBaseHeight(chain, height); err != nil {
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
 	}
 	block, err := bm.GetBlockByHeight(height)
@@ -975,7 +1395,7 @@ func getBTPHeader(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, er
 		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
 	}
 
-	if err := checkBaseHeight(chain, height); err != nil {
+	if err := CheckBaseHeight(chain, height); err != nil {
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
 	}
 	block, err := bm.GetBlockByHeight(height)
@@ -1022,7 +1442,7 @@ func getBTPProof(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, err
 		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
 	}
 
-	if err := checkBaseHeight(chain, height); err != nil {
+	if err := CheckBaseHeight(chain, height); err != nil {
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
 	}
 	block, err := bm.GetBlockByHeight(height)
@@ -1143,6 +1563,9 @@ func sendTransactionAndWait(ctx *jsonrpc.Context, params *jsonrpc.Params) (inter
 		if service.TransactionPoolOverflowError.Equals(err) {
 			return nil, jsonrpc.ErrorCodeTxPoolOverflow.Wrap(err, debug)
 		}
+		if service.SenderLimitExceededError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeSenderLimit.Wrap(err, debug)
+		}
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
 
@@ -1235,7 +1658,7 @@ func waitTransactionResultOnChannel(ctx *jsonrpc.Context, chain module.Chain, bm
 	}
 
 	blk := txInfo.Block()
-	if err := checkBaseHeight(chain, blk.Height()); err != nil {
+	if err := CheckBaseHeight(chain, blk.Height()); err != nil {
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
 	}
 	res, err := receipt.ToJSON(module.JSONVersion3)
@@ -1256,14 +1679,27 @@ func DebugMethodRepository(mtr *metric.JsonrpcMetric) *jsonrpc.MethodRepository
 
 	mr.RegisterMethod("debug_getTrace", getTrace)
 	mr.RegisterMethod("debug_estimateStep", estimateStep)
+	mr.RegisterMethod("debug_getBlockStateDiff", getBlockStateDiff)
+	mr.RegisterMethod("debug_traceCall", traceCall)
+	mr.RegisterMethod("debug_traceBlocks", traceBlocks)
+	mr.RegisterMethod("txpool_status", getTxPoolStatus)
+	mr.RegisterMethod("txpool_content", getTxPoolContent)
+	mr.RegisterMethod("txpool_getTransaction", getTxPoolTransaction)
 
 	return mr
 }
 
+const (
+	traceModeInvoke      = "invoke"
+	traceModeCallTree    = "callTree"
+	traceModeStateDiff   = "stateDiff"
+	traceModeStepProfile = "stepProfile"
+)
+
 func getTrace(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 	debug := ctx.IncludeDebug()
 
-	var param TransactionHashParam
+	var param TraceParam
 	if err := params.Convert(&param); err != nil {
 		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
 	}
@@ -1294,7 +1730,7 @@ func getTrace(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error)
 	}
 
 	blk := txInfo.Block()
-	if err = checkBaseHeight(chain, blk.Height()); err != nil {
+	if err = CheckBaseHeight(chain, blk.Height()); err != nil {
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
 	}
 	_, err = txInfo.GetReceipt()
@@ -1325,8 +1761,24 @@ func getTrace(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error)
 		logs:    make([]interface{}, 0, 100),
 		channel: make(chan interface{}, 10),
 	}
+	mode := module.TraceModeInvoke
+	toJSON := cb.invokeTraceToJSON
+	switch param.Mode {
+	case traceModeCallTree:
+		mode = module.TraceModeCallTree
+		cb.ct = trace.NewCallTracer()
+		toJSON = cb.callTreeToJSON
+	case traceModeStateDiff:
+		mode = module.TraceModeStateDiff
+		cb.sdt = trace.NewStateDiffTracer(blk.ID())
+		toJSON = cb.stateDiffToJSON
+	case traceModeStepProfile:
+		mode = module.TraceModeStepProfile
+		cb.sp = trace.NewStepProfiler()
+		toJSON = cb.stepProfileToJSON
+	}
 	ti := module.TraceInfo{
-		TraceMode: module.TraceModeInvoke,
+		TraceMode: mode,
 		Range:     module.TraceRangeTransaction,
 		Group:     txInfo.Group(),
 		Index:     txInfo.Index(),
@@ -1345,44 +1797,273 @@ func getTrace(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error)
 			return nil, jsonrpc.ErrorCodeSystemTimeout.Errorf(
 				"Not enough time to get result of %x", param.Hash.Bytes())
 		case <-cb.channel:
-			return cb.invokeTraceToJSON(), nil
+			return toJSON(), nil
 		}
 	}
 	return nil, jsonrpc.ErrorCodeSystem.New("Unknown error on channel")
 }
 
-func estimateStep(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+func getBlockStateDiff(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 	debug := ctx.IncludeDebug()
 
+	var param BlockStateDiffParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
 	chain, err := ctx.Chain()
 	if err != nil {
 		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
 	}
 
-	var param TransactionParamForEstimate
-	if err := params.Convert(&param); err != nil {
-		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
-	}
-
 	bm := chain.BlockManager()
 	sm := chain.ServiceManager()
 	if bm == nil || sm == nil {
-		return nil, jsonrpc.ErrorCodeServer.New("ChannelStopped")
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
 	}
 
-	// get last block
-	blk, err := bm.GetLastBlock()
-	if err != nil {
-		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	var blk module.Block
+	if len(param.Hash) > 0 {
+		blk, err = bm.GetBlock(param.Hash.Bytes())
+	} else if len(param.Height) > 0 {
+		var height int64
+		if height, err = param.Height.Int64(); err != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		}
+		blk, err = bm.GetBlockByHeight(height)
+	} else {
+		return nil, jsonrpc.ErrorCodeInvalidParams.New("NoBlockSelector")
 	}
-
-	// new block information based on the last
-	oldTS := blk.Timestamp()
-	newTS := common.UnixMicroFromTime(time.Now())
-	if newTS <= oldTS {
-		newTS = oldTS + 1
+	if errors.NotFoundError.Equals(err) {
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
-	bi := common.NewBlockInfo(blk.Height()+1, newTS)
+	if err = CheckBaseHeight(chain, blk.Height()); err != nil {
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	}
+	return traceBlock(chain, blk, traceModeStateDiff, ctx.Request().Context().Done(),
+		time.After(traceBlockTimeout), debug)
+}
+
+// traceBlocksLimit is the maximum number of blocks for debug_traceBlocks.
+const traceBlocksLimit = 100
+
+var (
+	// traceBlockTimeout is the timeout for tracing a block.
+	traceBlockTimeout = 60 * time.Second
+
+	// traceBlocksTimeout is the timeout for all blocks of debug_traceBlocks.
+	traceBlocksTimeout = 60 * time.Second
+
+	// traceBlocksMaxSize is the maximum size of encoded results of
+	// debug_traceBlocks.
+	traceBlocksMaxSize = 32 * 1024 * 1024
+)
+
+func traceBlocks(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var param TraceBlocksParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	start, err := param.Start.Int64()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	end := start
+	if len(param.End) > 0 {
+		if end, err = param.End.Int64(); err != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		}
+	}
+	if end < start {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Errorf(
+			"InvalidRange(start=%d,end=%d)", start, end)
+	}
+	if end-start >= traceBlocksLimit {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Errorf(
+			"TooManyBlocks(count=%d,limit=%d)", end-start+1, traceBlocksLimit)
+	}
+	if err = CheckBaseHeight(chain, start); err != nil {
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	}
+	last, err := bm.GetLastBlock()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	if end >= last.Height() {
+		return nil, jsonrpc.ErrorCodeExecuting.Errorf(
+			"Executing(end=%d,last=%d)", end, last.Height())
+	}
+
+	cancel := ctx.Request().Context().Done()
+	timeout := time.After(traceBlocksTimeout)
+	results := make([]json.RawMessage, 0, end-start+1)
+	size := 0
+	for height := start; height <= end; height++ {
+		res, err := traceBlockAt(chain, height, param.Mode, cancel, timeout, debug)
+		if err != nil {
+			return nil, err
+		}
+		bs, err := json.Marshal(res)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		size += len(bs)
+		if size > traceBlocksMaxSize {
+			return nil, jsonrpc.ErrorLackOfResource.Errorf(
+				"TooLargeResult(height=%d,limit=%d)", height, traceBlocksMaxSize)
+		}
+		results = append(results, bs)
+	}
+	return results, nil
+}
+
+// TraceBlock replays the finalized block at the height with the trace mode
+// (one of invoke, callTree and stateDiff) and returns the result.
+// Replaying is cancelled with ErrorCodeCanceled if cancel is closed.
+// Returned error is always an error of jsonrpc package.
+func TraceBlock(chain module.Chain, height int64, mode string, cancel <-chan struct{}, debug bool) (interface{}, error) {
+	return traceBlockAt(chain, height, mode, cancel, time.After(traceBlockTimeout), debug)
+}
+
+func traceBlockAt(chain module.Chain, height int64, mode string, cancel <-chan struct{}, timeout <-chan time.Time, debug bool) (interface{}, error) {
+	bm := chain.BlockManager()
+	if bm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+	blk, err := bm.GetBlockByHeight(height)
+	if errors.NotFoundError.Equals(err) {
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	if err = CheckBaseHeight(chain, blk.Height()); err != nil {
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	}
+	return traceBlock(chain, blk, mode, cancel, timeout, debug)
+}
+
+func traceBlock(chain module.Chain, blk module.Block, mode string, cancel <-chan struct{}, timeout <-chan time.Time, debug bool) (interface{}, error) {
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	cb := &traceCallback{
+		logs:    make([]interface{}, 0, 100),
+		channel: make(chan interface{}, 10),
+	}
+	var tm module.TraceMode
+	switch mode {
+	case "", traceModeInvoke:
+		tm = module.TraceModeInvoke
+	case traceModeCallTree:
+		tm = module.TraceModeCallTree
+		cb.ct = trace.NewCallTracer()
+	case traceModeStateDiff:
+		tm = module.TraceModeStateDiff
+		cb.sdt = trace.NewStateDiffTracer(blk.ID())
+	case traceModeStepProfile:
+		tm = module.TraceModeStepProfile
+		cb.sp = trace.NewStepProfiler()
+	default:
+		return nil, jsonrpc.ErrorCodeInvalidParams.Errorf("InvalidTraceMode(%s)", mode)
+	}
+
+	csi, err := bm.NewConsensusInfo(blk)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	nblk, err := bm.GetBlockByHeight(blk.Height() + 1)
+	if errors.NotFoundError.Equals(err) {
+		return nil, jsonrpc.ErrorCodeExecuting.New("Executing")
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	tr1, err := sm.CreateInitialTransition(blk.Result(), blk.NextValidators())
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	tr2, err := sm.CreateTransition(tr1, blk.NormalTransactions(), blk, csi, true)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	tr2 = sm.PatchTransition(tr2, nblk.PatchTransactions(), nblk)
+
+	ti := module.TraceInfo{
+		TraceMode: tm,
+		Range:     module.TraceRangeBlock,
+		Callback:  cb,
+	}
+	canceller, err := tr2.ExecuteForTrace(ti)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+
+	for {
+		select {
+		case <-cancel:
+			canceller()
+			return nil, jsonrpc.ErrorCodeCanceled.Errorf(
+				"Canceled to trace block %#x", blk.ID())
+		case <-timeout:
+			canceller()
+			return nil, jsonrpc.ErrorCodeSystemTimeout.Errorf(
+				"Not enough time to trace block %#x", blk.ID())
+		case <-cb.channel:
+			return cb.blockTraceToJSON(blk), nil
+		}
+	}
+}
+
+func estimateStep(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	var param TransactionParamForEstimate
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("ChannelStopped")
+	}
+
+	// get last block
+	blk, err := bm.GetLastBlock()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	// new block information based on the last
+	oldTS := blk.Timestamp()
+	newTS := common.UnixMicroFromTime(time.Now())
+	if newTS <= oldTS {
+		newTS = oldTS + 1
+	}
+	bi := common.NewBlockInfo(blk.Height()+1, newTS)
 
 	// execute transaction
 	rct, err := sm.ExecuteTransaction(
@@ -1407,6 +2088,102 @@ func estimateStep(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, er
 	return steps, nil
 }
 
+func traceCall(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	var param TraceCallParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("ChannelStopped")
+	}
+
+	// remove parameters for tracing from the transaction
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(params.RawMessage(), &fields); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	delete(fields, "height")
+	delete(fields, "mode")
+	js, err := json.Marshal(fields)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+
+	if _, err := transaction.NewTransactionFromJSON(js); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	// use the state of the block as icx_call does.
+	blk, err := getBlock(chain, bm, param.Height)
+	if err != nil {
+		if errors.NotFoundError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+		}
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	result := blk.Result()
+	vh := blk.NextValidators().Hash()
+	bi := common.NewBlockInfo(blk.Height(), blk.Timestamp())
+
+	cb := &traceCallback{
+		logs:    make([]interface{}, 0, 100),
+		channel: make(chan interface{}, 10),
+	}
+	mode := module.TraceModeInvoke
+	toJSON := cb.invokeTraceToJSON
+	switch param.Mode {
+	case traceModeCallTree:
+		mode = module.TraceModeCallTree
+		cb.ct = trace.NewCallTracer()
+		toJSON = cb.callTreeToJSON
+	case traceModeStepProfile:
+		mode = module.TraceModeStepProfile
+		cb.sp = trace.NewStepProfiler()
+		toJSON = cb.stepProfileToJSON
+	}
+	ti := module.TraceInfo{
+		TraceMode: mode,
+		Callback:  cb,
+	}
+	rct, err := sm.TraceTransaction(result, vh, js, bi, ti)
+	if err != nil {
+		if scoreresult.InvalidParameterError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		}
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	res := toJSON().(map[string]interface{})
+	if status := rct.Status(); status != module.StatusSuccess {
+		res["status"] = "0x0"
+		failure := map[string]interface{}{
+			"code": status,
+		}
+		if rctex, ok := rct.(txresult.Receipt); ok && rctex.Reason() != nil {
+			failure["message"] = rctex.Reason().Error()
+		}
+		res["failure"] = failure
+	}
+	rctJSON, err := rct.ToJSON(module.JSONVersion3)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	res["receipt"] = rctJSON
+	res["stepUsed"] = &common.HexInt{Int: *rct.StepUsed()}
+	res["blockHeight"] = fmt.Sprintf("%#x", bi.Height())
+	return res, nil
+}
+
 const CIDForMainNet = 0x1
 
 func getTraceForRosetta(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
@@ -1433,12 +2210,50 @@ func getTraceForRosetta(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
 	}
 
+	var rng module.TraceRange
+	var index int
+	if txInfo != nil {
+		rng = module.TraceRangeTransaction
+		index = txInfo.Index()
+	} else if len(param.Tx) > 0 {
+		rng = module.TraceRangeBlockTransaction
+	} else {
+		rng = module.TraceRangeBlock
+	}
+	cb, err := traceBalanceChanges(chain, blk, rng, index, debug)
+	if err != nil {
+		return nil, err
+	}
+	return cb.balanceChangeToJSON(blk), nil
+}
+
+// TraceBalanceChanges replays the finalized block with TraceModeBalanceChange
+// and returns the tracer having changes of balances. With
+// TraceRangeTransaction, it replays only the normal transaction at index.
+// Returned error is always an error of jsonrpc package.
+func TraceBalanceChanges(chain module.Chain, blk module.Block, rng module.TraceRange, index int, debug bool) (*trace.BalanceTracer, error) {
+	cb, err := traceBalanceChanges(chain, blk, rng, index, debug)
+	if err != nil {
+		return nil, err
+	}
+	return cb.bt, nil
+}
+
+func traceBalanceChanges(chain module.Chain, blk module.Block, rng module.TraceRange, index int, debug bool) (*traceCallback, error) {
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
 	csi, err := bm.NewConsensusInfo(blk)
 	if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
 	nblk, err := bm.GetBlockByHeight(blk.Height() + 1)
-	if err != nil {
+	if errors.NotFoundError.Equals(err) {
+		return nil, jsonrpc.ErrorCodeExecuting.New("Executing")
+	} else if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
 	tr1, err := sm.CreateInitialTransition(blk.Result(), blk.NextValidators())
@@ -1467,18 +2282,12 @@ func getTraceForRosetta(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface
 	ti := module.TraceInfo{
 		TraceMode:  module.TraceModeBalanceChange,
 		TraceBlock: trace.NewTraceBlock(blk.ID(), rl),
+		Range:      rng,
 		Callback:   cb,
 	}
-	if txInfo != nil {
-		ti.Range = module.TraceRangeTransaction
+	if rng == module.TraceRangeTransaction {
 		ti.Group = module.TransactionGroupNormal
-		ti.Index = txInfo.Index()
-	} else {
-		if len(param.Tx) > 0 {
-			ti.Range = module.TraceRangeBlockTransaction
-		} else {
-			ti.Range = module.TraceRangeBlock
-		}
+		ti.Index = index
 	}
 	canceller, err := tr2.ExecuteForTrace(ti)
 	if err != nil {
@@ -1491,12 +2300,11 @@ func getTraceForRosetta(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface
 		case <-timer:
 			canceller()
 			return nil, jsonrpc.ErrorCodeSystemTimeout.Errorf(
-				"Not enough time to get result of %+v", param)
+				"Not enough time to trace block %#x", blk.ID())
 		case <-cb.channel:
-			return cb.balanceChangeToJSON(blk), nil
+			return cb, nil
 		}
 	}
-	return nil, jsonrpc.ErrorCodeSystem.New("Unknown error on channel")
 }
 
 func findBlockAndTxInfoByRosettaTraceParam(
@@ -1612,3 +2420,147 @@ func RosettaMethodRepository(mtr *metric.JsonrpcMetric) *jsonrpc.MethodRepositor
 
 	return mr
 }
+
+func transactionGroupName(g module.TransactionGroup) string {
+	if g == module.TransactionGroupPatch {
+		return "patch"
+	}
+	return "normal"
+}
+
+// TransactionPoolStatusToJSON returns JSON object for status of the
+// transaction pools.
+func TransactionPoolStatusToJSON(sm module.ServiceManager) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, s := range sm.GetTransactionPoolStatus() {
+		result[transactionGroupName(s.Group)] = map[string]interface{}{
+			"size":  intconv.FormatInt(int64(s.Size)),
+			"used":  intconv.FormatInt(int64(s.Used)),
+			"bytes": intconv.FormatInt(int64(s.Bytes)),
+		}
+	}
+	return result
+}
+
+// TransactionPoolContentToJSON returns JSON object for transactions in the
+// transaction pools grouped by the transaction group.
+func TransactionPoolContentToJSON(sm module.ServiceManager, from module.Address) (map[string]interface{}, error) {
+	now := time.Now()
+	content := map[string][]interface{}{
+		"normal": {},
+		"patch":  {},
+	}
+	for _, ptx := range sm.GetPendingTransactions(from) {
+		js, err := PendingTransactionToJSON(ptx, now, false)
+		if err != nil {
+			return nil, err
+		}
+		name := transactionGroupName(ptx.Group)
+		content[name] = append(content[name], js)
+	}
+	result := make(map[string]interface{}, len(content))
+	for name, txs := range content {
+		result[name] = txs
+	}
+	return result, nil
+}
+
+// PendingTransactionToJSON returns JSON object for the transaction in the
+// transaction pool. Age of the transaction is calculated with now. It
+// includes the transaction itself if full is true.
+func PendingTransactionToJSON(ptx *module.PendingTransaction, now time.Time, full bool) (map[string]interface{}, error) {
+	result := map[string]interface{}{
+		"txHash":  "0x" + hex.EncodeToString(ptx.ID()),
+		"group":   transactionGroupName(ptx.Group),
+		"size":    intconv.FormatInt(int64(len(ptx.Bytes()))),
+		"addedAt": intconv.FormatInt(ptx.AddedAt.UnixNano() / int64(time.Microsecond)),
+		"age":     intconv.FormatInt(int64(now.Sub(ptx.AddedAt) / time.Microsecond)),
+		"direct":  ptx.Direct,
+	}
+	if from := ptx.From(); from != nil {
+		result["from"] = from.String()
+	}
+	if tst, ok := ptx.Transaction.(interface{ Timestamp() int64 }); ok {
+		result["timestamp"] = intconv.FormatInt(tst.Timestamp())
+	}
+	if full {
+		tx, err := ptx.ToJSON(module.JSONVersion3)
+		if err != nil {
+			return nil, err
+		}
+		result["transaction"] = tx
+	}
+	return result, nil
+}
+
+func getTxPoolStatus(ctx *jsonrpc.Context, _ *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+	sm := chain.ServiceManager()
+	if sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+	return TransactionPoolStatusToJSON(sm), nil
+}
+
+func getTxPoolContent(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var param TxPoolContentParam
+	if !params.IsEmpty() {
+		if err := params.Convert(&param); err != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		}
+	}
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+	sm := chain.ServiceManager()
+	if sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	var from module.Address
+	if param.From != "" {
+		from = param.From.Address()
+	}
+	result, err := TransactionPoolContentToJSON(sm, from)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	return result, nil
+}
+
+func getTxPoolTransaction(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var param TransactionHashParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+	sm := chain.ServiceManager()
+	if sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	ptx := sm.GetPendingTransaction(param.Hash.Bytes())
+	if ptx == nil {
+		return nil, jsonrpc.ErrorCodeNotFound.New("NotFound")
+	}
+	result, err := PendingTransactionToJSON(ptx, time.Now(), true)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	return result, nil
+}
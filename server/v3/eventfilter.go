@@ -0,0 +1,179 @@
+package v3
+
+import (
+	"bytes"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+// EventFilter is a filter of event logs by the signature, addresses of
+// the emitter and values of the arguments. It should be compiled before use.
+type EventFilter struct {
+	Addr       *common.Address   `json:"addr,omitempty"`
+	Addrs      []*common.Address `json:"addrs,omitempty"`
+	Signature  string            `json:"event"`
+	Indexed    []*string         `json:"indexed,omitempty"`
+	Data       []*string         `json:"data,omitempty"`
+	addrs      []*common.Address
+	indexedBSs [][]byte
+	dataBSs    [][]byte
+	numOfArgs  int
+	lbs        []module.LogsBloom
+	indexes    []int
+}
+
+func (f *EventFilter) Compile() error {
+	f.addrs = f.addrs[:0]
+	if f.Addr != nil {
+		f.addrs = append(f.addrs, f.Addr)
+	}
+	for _, addr := range f.Addrs {
+		if addr == nil {
+			return errors.NewBase(errors.IllegalArgumentError, "null address")
+		}
+		if !f.HasAddress(addr) {
+			f.addrs = append(f.addrs, addr)
+		}
+	}
+	lb := txresult.NewLogsBloom(nil)
+	f.numOfArgs = len(f.Indexed) + len(f.Data)
+	name, pts := txresult.DecomposeEventSignature(f.Signature)
+	if len(name) == 0 || pts == nil || len(pts) < f.numOfArgs {
+		return errors.NewBase(errors.IllegalArgumentError, "bad event signature")
+	}
+	lb.AddIndexedOfLog(0, []byte(f.Signature))
+	idx := 0
+	f.indexedBSs = make([][]byte, len(f.Indexed))
+	for i, arg := range f.Indexed {
+		if arg != nil {
+			bs, err := txresult.EventDataStringToBytesByType(pts[idx], string(*arg))
+			if err != nil {
+				return errors.NewBase(errors.IllegalArgumentError, "bad event data")
+			}
+			lb.AddIndexedOfLog(i+1, bs)
+			f.indexedBSs[i] = bs
+		}
+		idx++
+	}
+	f.dataBSs = make([][]byte, len(f.Data))
+	for i, arg := range f.Data {
+		if arg != nil {
+			bs, err := txresult.EventDataStringToBytesByType(pts[idx], string(*arg))
+			if err != nil {
+				return errors.NewBase(errors.IllegalArgumentError, "bad event data")
+			}
+			f.dataBSs[i] = bs
+		}
+		idx++
+	}
+	if len(f.addrs) == 0 {
+		f.lbs = []module.LogsBloom{lb}
+	} else {
+		f.lbs = make([]module.LogsBloom, len(f.addrs))
+		for i, addr := range f.addrs {
+			alb := txresult.NewLogsBloom(nil)
+			alb.Merge(lb)
+			alb.AddAddressOfLog(addr)
+			f.lbs[i] = alb
+		}
+	}
+	return nil
+}
+
+// HasAddress returns whether the address is one of the compiled addresses.
+func (f *EventFilter) HasAddress(addr module.Address) bool {
+	for _, a := range f.addrs {
+		if a.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainedIn returns whether the logs bloom may have the events
+// matching with the filter.
+func (f *EventFilter) ContainedIn(lb module.LogsBloom) bool {
+	for _, flb := range f.lbs {
+		if lb.Contain(flb) {
+			return true
+		}
+	}
+	return false
+}
+
+// bytesEqual check equality of byte slice.
+// But it doesn't assume nil as empty bytes.
+func bytesEqual(b1 []byte, b2 []byte) bool {
+	if b1 == nil && b2 == nil {
+		return true
+	}
+	if b1 == nil || b2 == nil {
+		return false
+	}
+	return bytes.Equal(b1, b2)
+}
+
+func (f *EventFilter) MatchWithLogs(r module.Receipt, includeLogs bool) ([]common.HexInt32, []module.EventLog, error) {
+	var indexes []common.HexInt32
+	var logs []module.EventLog
+	if err := f.filterFunc(r, func(idx int, log module.EventLog) {
+		indexes = append(indexes, common.HexInt32{Value: int32(idx)})
+		if includeLogs {
+			logs = append(logs, log)
+		}
+	}); err != nil {
+		return nil, nil, err
+	}
+	return indexes, logs, nil
+}
+
+func (f *EventFilter) Match(r module.Receipt) ([]common.HexInt32, bool) {
+	eventIndexes := make([]common.HexInt32, 0)
+	if err := f.filterFunc(r, func(idx int, log module.EventLog) {
+		eventIndexes = append(eventIndexes, common.HexInt32{int32(idx)})
+	}); err != nil {
+		return []common.HexInt32{}, false
+	} else {
+		return eventIndexes, len(eventIndexes) > 0
+	}
+	return eventIndexes, false
+}
+
+func (f *EventFilter) filterFunc(r module.Receipt, v func(idx int, log module.EventLog)) error {
+	if f.ContainedIn(r.LogsBloom()) {
+	loop:
+		for it, idx := r.EventLogIterator(), 0; it.Has(); _, idx = it.Next(), idx+1 {
+			el, err := it.Get()
+			if err != nil {
+				return err
+			}
+
+			if bytes.Equal([]byte(f.Signature), el.Indexed()[0]) {
+				if len(f.addrs) > 0 && !f.HasAddress(el.Address()) {
+					continue loop
+				}
+				if f.numOfArgs > 0 {
+					if (len(el.Indexed()) + len(el.Data())) <= f.numOfArgs {
+						continue loop
+					}
+
+					for i, arg := range f.indexedBSs {
+						if arg != nil && !bytesEqual(arg, el.Indexed()[i+1]) {
+							continue loop
+						}
+					}
+					for i, arg := range f.dataBSs {
+						if arg != nil && !bytesEqual(arg, el.Data()[i]) {
+							continue loop
+						}
+					}
+				}
+				v(idx, el)
+			}
+		}
+	}
+	return nil
+}
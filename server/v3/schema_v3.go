@@ -1,6 +1,7 @@
 package v3
 
 import (
+	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/intconv"
 	"github.com/icon-project/goloop/server/jsonrpc"
 )
@@ -47,6 +48,26 @@ type TransactionHashParam struct {
 	Hash jsonrpc.HexBytes `json:"txHash" validate:"required,t_hash"`
 }
 
+type TraceParam struct {
+	Hash jsonrpc.HexBytes `json:"txHash" validate:"required,t_hash"`
+	Mode string           `json:"mode,omitempty" validate:"optional,oneof=invoke callTree stateDiff stepProfile"`
+}
+
+type BlockStateDiffParam struct {
+	Hash   jsonrpc.HexBytes `json:"hash,omitempty" validate:"optional,t_hash"`
+	Height jsonrpc.HexInt   `json:"height,omitempty" validate:"optional,t_int"`
+}
+
+type TraceBlocksParam struct {
+	Start jsonrpc.HexInt `json:"start" validate:"required,t_int"`
+	End   jsonrpc.HexInt `json:"end,omitempty" validate:"optional,t_int"`
+	Mode  string         `json:"mode,omitempty" validate:"optional,oneof=invoke callTree stateDiff stepProfile"`
+}
+
+type TxPoolContentParam struct {
+	From jsonrpc.Address `json:"from,omitempty" validate:"optional,t_addr"`
+}
+
 type TransactionParamForEstimate struct {
 	Version     jsonrpc.HexInt  `json:"version" validate:"required,t_int"`
 	FromAddress jsonrpc.Address `json:"from" validate:"required,t_addr_eoa"`
@@ -59,6 +80,12 @@ type TransactionParamForEstimate struct {
 	Data        interface{}     `json:"data,omitempty"`
 }
 
+type TraceCallParam struct {
+	TransactionParamForEstimate
+	Height jsonrpc.HexInt `json:"height,omitempty" validate:"optional,t_int"`
+	Mode   string         `json:"mode,omitempty" validate:"optional,oneof=invoke callTree stepProfile"`
+}
+
 type TransactionParam struct {
 	Version     jsonrpc.HexInt  `json:"version" validate:"required,t_int"`
 	FromAddress jsonrpc.Address `json:"from" validate:"required,t_addr_eoa"`
@@ -73,6 +100,14 @@ type TransactionParam struct {
 	Data        interface{}     `json:"data,omitempty"`
 }
 
+type CancelTransactionParam struct {
+	FromAddress jsonrpc.Address  `json:"from" validate:"required,t_addr_eoa"`
+	TxHash      jsonrpc.HexBytes `json:"txHash" validate:"required,t_hash"`
+	Timestamp   jsonrpc.HexInt   `json:"timestamp" validate:"required,t_int"`
+	NetworkID   jsonrpc.HexInt   `json:"nid,omitempty" validate:"optional,t_int"`
+	Signature   string           `json:"signature" validate:"required,t_sig"`
+}
+
 type DataHashParam struct {
 	Hash jsonrpc.HexBytes `json:"hash" validate:"required,t_hash"`
 }
@@ -88,6 +123,27 @@ type ProofEventsParam struct {
 	Events    []jsonrpc.HexInt `json:"events" validate:"gt=0,dive,t_int"`
 }
 
+type ProofStorageParam struct {
+	Score  jsonrpc.Address    `json:"score" validate:"required,t_addr_score"`
+	Keys   []jsonrpc.HexBytes `json:"keys" validate:"gt=0,dive,t_bytes"`
+	Height jsonrpc.HexInt     `json:"height,omitempty" validate:"optional,t_int"`
+}
+
+type AddressIndexParam struct {
+	Address    jsonrpc.Address  `json:"address" validate:"required,t_addr"`
+	FromHeight jsonrpc.HexInt   `json:"fromHeight,omitempty" validate:"optional,t_int"`
+	ToHeight   jsonrpc.HexInt   `json:"toHeight,omitempty" validate:"optional,t_int"`
+	Limit      jsonrpc.HexInt   `json:"limit,omitempty" validate:"optional,t_int"`
+	Cursor     jsonrpc.HexBytes `json:"cursor,omitempty" validate:"optional,t_bytes"`
+}
+
+type LogsParam struct {
+	EventFilter
+	FromHeight common.HexInt64  `json:"fromHeight"`
+	ToHeight   *common.HexInt64 `json:"toHeight,omitempty"`
+	Limit      *common.HexInt32 `json:"limit,omitempty"`
+}
+
 type RosettaTraceParam struct {
 	Tx     jsonrpc.HexBytes `json:"tx,omitempty" validate:"optional,t_rhash"`
 	Block  jsonrpc.HexBytes `json:"block,omitempty" validate:"optional,t_hash"`
@@ -0,0 +1,311 @@
+package v3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	"github.com/icon-project/goloop/server/metric"
+)
+
+type testBlock struct {
+	module.Block
+	height int64
+}
+
+func (b *testBlock) Height() int64 {
+	return b.height
+}
+
+func (b *testBlock) ID() []byte {
+	return []byte(fmt.Sprintf("block%d", b.height))
+}
+
+func (b *testBlock) Result() []byte {
+	return nil
+}
+
+func (b *testBlock) NextValidators() module.ValidatorList {
+	return nil
+}
+
+func (b *testBlock) NormalTransactions() module.TransactionList {
+	return nil
+}
+
+func (b *testBlock) PatchTransactions() module.TransactionList {
+	return nil
+}
+
+// testTransition sends logs of the block to the callback. If the block
+// is the one to hang, it waits until it's cancelled.
+type testTransition struct {
+	module.Transition
+	chain  *testChain
+	height int64
+}
+
+func (tr *testTransition) ExecuteForTrace(ti module.TraceInfo) (func() bool, error) {
+	c := tr.chain
+	cancelled := make(chan struct{})
+	go func() {
+		if tr.height == c.hangAt {
+			<-cancelled
+			ti.Callback.OnEnd(errors.InterruptedError.New("Cancelled"))
+			return
+		}
+		for i := 0; i < c.logs; i++ {
+			ti.Callback.OnLog(module.TSystemLevel, fmt.Sprintf("log%d.%d", tr.height, i))
+		}
+		ti.Callback.OnEnd(nil)
+	}()
+	var once sync.Once
+	return func() bool {
+		once.Do(func() {
+			close(cancelled)
+			c.cancelled <- tr.height
+		})
+		return true
+	}, nil
+}
+
+type testBlockManager struct {
+	module.BlockManager
+	last int64
+}
+
+func (bm *testBlockManager) GetBlockByHeight(height int64) (module.Block, error) {
+	if height < 0 || height > bm.last {
+		return nil, errors.NotFoundError.Errorf("NoBlock(height=%d)", height)
+	}
+	return &testBlock{height: height}, nil
+}
+
+func (bm *testBlockManager) GetLastBlock() (module.Block, error) {
+	return &testBlock{height: bm.last}, nil
+}
+
+func (bm *testBlockManager) NewConsensusInfo(blk module.Block) (module.ConsensusInfo, error) {
+	return nil, nil
+}
+
+type testServiceManager struct {
+	module.ServiceManager
+	chain *testChain
+}
+
+func (sm *testServiceManager) CreateInitialTransition(result []byte, nextValidators module.ValidatorList) (module.Transition, error) {
+	return &testTransition{chain: sm.chain}, nil
+}
+
+func (sm *testServiceManager) CreateTransition(parent module.Transition, txs module.TransactionList, bi module.BlockInfo, csi module.ConsensusInfo, validated bool) (module.Transition, error) {
+	return &testTransition{chain: sm.chain, height: bi.Height()}, nil
+}
+
+func (sm *testServiceManager) PatchTransition(transition module.Transition, patches module.TransactionList, bi module.BlockInfo) module.Transition {
+	return transition
+}
+
+type testGenesisStorage struct {
+	module.GenesisStorage
+	height int64
+}
+
+func (gs *testGenesisStorage) Height() int64 {
+	return gs.height
+}
+
+// testChain has blocks up to the last height. Tracing a block produces
+// logs, and tracing the block at hangAt never ends until it's cancelled.
+type testChain struct {
+	module.Chain
+	bm        *testBlockManager
+	sm        *testServiceManager
+	gs        *testGenesisStorage
+	logs      int
+	hangAt    int64
+	cancelled chan int64
+}
+
+func newTestChain(last int64) *testChain {
+	c := &testChain{
+		bm:        &testBlockManager{last: last},
+		gs:        &testGenesisStorage{},
+		logs:      1,
+		hangAt:    -1,
+		cancelled: make(chan int64, 1),
+	}
+	c.sm = &testServiceManager{chain: c}
+	return c
+}
+
+func (c *testChain) BlockManager() module.BlockManager {
+	return c.bm
+}
+
+func (c *testChain) ServiceManager() module.ServiceManager {
+	return c.sm
+}
+
+func (c *testChain) GenesisStorage() module.GenesisStorage {
+	return c.gs
+}
+
+func (c *testChain) MetricContext() context.Context {
+	return metric.DefaultMetricContext()
+}
+
+// invokeTraceBlocks calls debug_traceBlocks with the params in the context,
+// then it returns the result or the error of the response.
+func invokeTraceBlocks(t *testing.T, ctx context.Context, chain module.Chain, params string) (json.RawMessage, *jsonrpc.Error) {
+	reqJson := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"debug_traceBlocks","params":%s}`, params)
+	e := echo.New()
+	v := jsonrpc.NewValidator()
+	RegisterValidationRule(v)
+	e.Validator = v
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(reqJson)).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("includeDebug", false)
+	c.Set("raw", json.RawMessage(reqJson))
+	c.Set("chain", chain)
+	mr := jsonrpc.NewMethodRepository(metric.NewJsonrpcMetric(
+		metric.DefaultJsonrpcDurationsExpire, metric.DefaultJsonrpcDurationsSize, true))
+	mr.RegisterMethod("debug_traceBlocks", traceBlocks)
+	assert.NoError(t, mr.Handle(c))
+
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *jsonrpc.Error  `json:"error"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp.Result, resp.Error
+}
+
+type blockTraceJSON struct {
+	BlockHeight string `json:"blockHeight"`
+	Logs        []struct {
+		Msg string `json:"msg"`
+	} `json:"logs"`
+	Status string `json:"status"`
+}
+
+func TestTraceBlocks_Range(t *testing.T) {
+	c := newTestChain(4)
+	c.gs.height = 1
+
+	tests := []struct {
+		name   string
+		params string
+		values []string
+		code   jsonrpc.ErrorCode
+	}{
+		{"SingleBlock", `{"start":"0x1"}`, []string{"0x1:log1.0"}, 0},
+		{"Blocks", `{"start":"0x1","end":"0x3"}`, []string{"0x1:log1.0", "0x2:log2.0", "0x3:log3.0"}, 0},
+		{"Pruned", `{"start":"0x0","end":"0x1"}`, nil, jsonrpc.ErrorCodeNotFound},
+		{"Executing", `{"start":"0x3","end":"0x4"}`, nil, jsonrpc.ErrorCodeExecuting},
+		{"InvalidRange", `{"start":"0x3","end":"0x2"}`, nil, jsonrpc.ErrorCodeInvalidParams},
+		{"TooManyBlocks", `{"start":"0x1","end":"0x65"}`, nil, jsonrpc.ErrorCodeInvalidParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, jerr := invokeTraceBlocks(t, context.Background(), c, tt.params)
+			if tt.code != 0 {
+				if assert.NotNil(t, jerr) {
+					assert.Equal(t, tt.code, jerr.Code, jerr.Message)
+				}
+				return
+			}
+			if !assert.Nil(t, jerr) {
+				return
+			}
+			var blocks []blockTraceJSON
+			assert.NoError(t, json.Unmarshal(res, &blocks))
+			var values []string
+			for _, b := range blocks {
+				assert.Equal(t, "0x1", b.Status)
+				for _, l := range b.Logs {
+					values = append(values, b.BlockHeight+":"+l.Msg)
+				}
+			}
+			assert.Equal(t, tt.values, values)
+		})
+	}
+}
+
+func TestTraceBlocks_MaxSize(t *testing.T) {
+	maxSize := traceBlocksMaxSize
+	defer func() {
+		traceBlocksMaxSize = maxSize
+	}()
+
+	c := newTestChain(4)
+	c.logs = 10
+	res, jerr := invokeTraceBlocks(t, context.Background(), c, `{"start":"0x1"}`)
+	if !assert.Nil(t, jerr) {
+		return
+	}
+	// timestamps of logs may have different lengths
+	traceBlocksMaxSize = len(res) + 100
+	_, jerr = invokeTraceBlocks(t, context.Background(), c, `{"start":"0x1"}`)
+	assert.Nil(t, jerr)
+	_, jerr = invokeTraceBlocks(t, context.Background(), c, `{"start":"0x1","end":"0x2"}`)
+	if assert.NotNil(t, jerr) {
+		assert.Equal(t, jsonrpc.ErrorLackOfResource, jerr.Code)
+	}
+}
+
+func TestTraceBlocks_Timeout(t *testing.T) {
+	timeout := traceBlocksTimeout
+	traceBlocksTimeout = 300 * time.Millisecond
+	defer func() {
+		traceBlocksTimeout = timeout
+	}()
+
+	c := newTestChain(4)
+	c.hangAt = 2
+	_, jerr := invokeTraceBlocks(t, context.Background(), c, `{"start":"0x1","end":"0x3"}`)
+	if assert.NotNil(t, jerr) {
+		assert.Equal(t, jsonrpc.ErrorCodeSystemTimeout, jerr.Code)
+	}
+	assert.Equal(t, int64(2), <-c.cancelled)
+}
+
+func TestTraceBlocks_Cancel(t *testing.T) {
+	c := newTestChain(4)
+	c.hangAt = 2
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan *jsonrpc.Error, 1)
+	go func() {
+		_, jerr := invokeTraceBlocks(t, ctx, c, `{"start":"0x1","end":"0x3"}`)
+		done <- jerr
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("traceBlocks returns before cancel")
+	case <-time.After(100 * time.Millisecond):
+	}
+	cancel()
+	select {
+	case jerr := <-done:
+		if assert.NotNil(t, jerr) {
+			assert.Equal(t, jsonrpc.ErrorCodeCanceled, jerr.Code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("traceBlocks isn't cancelled")
+	}
+	assert.Equal(t, int64(2), <-c.cancelled)
+}
@@ -19,6 +19,9 @@ type traceCallback struct {
 	ts      time.Time
 	channel chan interface{}
 	bt      *trace.BalanceTracer
+	ct      *trace.CallTracer
+	sdt     *trace.StateDiffTracer
+	sp      *trace.StepProfiler
 }
 
 type traceLog struct {
@@ -56,6 +59,11 @@ func (t *traceCallback) invokeTraceToJSON() interface{} {
 	result := map[string]interface{}{
 		"logs": t.logs,
 	}
+	t.setStatusInLock(result)
+	return result
+}
+
+func (t *traceCallback) setStatusInLock(result map[string]interface{}) {
 	if t.last == nil {
 		result["status"] = "0x1"
 	} else {
@@ -66,6 +74,57 @@ func (t *traceCallback) invokeTraceToJSON() interface{} {
 			"message": t.last.Error(),
 		}
 	}
+}
+
+func (t *traceCallback) callTreeToJSON() interface{} {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	result := map[string]interface{}{
+		"calls": t.ct.CallsToJSON(),
+	}
+	t.setStatusInLock(result)
+	return result
+}
+
+func (t *traceCallback) stateDiffToJSON() interface{} {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	result := map[string]interface{}{
+		"accounts": t.sdt.AccountsToJSON(),
+	}
+	t.setStatusInLock(result)
+	return result
+}
+
+func (t *traceCallback) stepProfileToJSON() interface{} {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	result := t.sp.ProfileToJSON()
+	t.setStatusInLock(result)
+	return result
+}
+
+func (t *traceCallback) blockTraceToJSON(blk module.Block) interface{} {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	result := map[string]interface{}{
+		"blockHash":   "0x" + hex.EncodeToString(blk.ID()),
+		"blockHeight": fmt.Sprintf("%#x", blk.Height()),
+	}
+	if t.ct != nil {
+		result["transactions"] = t.ct.ToJSON()
+	} else if t.sdt != nil {
+		result["transactions"] = t.sdt.ToJSON()
+	} else if t.sp != nil {
+		result["transactions"] = t.sp.ToJSON()
+	} else {
+		result["logs"] = t.logs
+	}
+	t.setStatusInLock(result)
 	return result
 }
 
@@ -94,6 +153,21 @@ func (t *traceCallback) OnTransactionStart(txIndex int, txHash []byte, isBlockTx
 		defer t.lock.Unlock()
 		return t.bt.OnTransactionStart(txIndex, txHash, isBlockTx)
 	}
+	if t.ct != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.ct.OnTransactionStart(txIndex, txHash, isBlockTx)
+	}
+	if t.sdt != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.sdt.OnTransactionStart(txIndex, txHash, isBlockTx)
+	}
+	if t.sp != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.sp.OnTransactionStart(txIndex, txHash, isBlockTx)
+	}
 	return nil
 }
 
@@ -105,6 +179,15 @@ func (t *traceCallback) OnTransactionReset() error {
 	if t.bt != nil {
 		return t.bt.OnTransactionReset()
 	}
+	if t.ct != nil {
+		return t.ct.OnTransactionReset()
+	}
+	if t.sdt != nil {
+		return t.sdt.OnTransactionReset()
+	}
+	if t.sp != nil {
+		return t.sp.OnTransactionReset()
+	}
 	return nil
 }
 
@@ -114,6 +197,21 @@ func (t *traceCallback) OnTransactionEnd(txIndex int, txHash []byte) error {
 		defer t.lock.Unlock()
 		return t.bt.OnTransactionEnd(txIndex, txHash)
 	}
+	if t.ct != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.ct.OnTransactionEnd(txIndex, txHash)
+	}
+	if t.sdt != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.sdt.OnTransactionEnd(txIndex, txHash)
+	}
+	if t.sp != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.sp.OnTransactionEnd(txIndex, txHash)
+	}
 	return nil
 }
 
@@ -143,3 +241,58 @@ func (t *traceCallback) OnBalanceChange(opType module.OpType, from, to module.Ad
 	}
 	return nil
 }
+
+func (t *traceCallback) OnCallStart(call *module.TraceCall) error {
+	if t.ct != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.ct.OnCallStart(call)
+	}
+	if t.sp != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.sp.OnCallStart(call)
+	}
+	return nil
+}
+
+func (t *traceCallback) OnCallEnd(status error, stepUsed *big.Int) error {
+	if t.ct != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.ct.OnCallEnd(status, stepUsed)
+	}
+	if t.sp != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.sp.OnCallEnd(status, stepUsed)
+	}
+	return nil
+}
+
+func (t *traceCallback) OnEvent(addr module.Address, indexed, data [][]byte) error {
+	if t.ct != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.ct.OnEvent(addr, indexed, data)
+	}
+	return nil
+}
+
+func (t *traceCallback) OnStateDiff(diffs []*module.TraceAccountDiff) error {
+	if t.sdt != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.sdt.OnStateDiff(diffs)
+	}
+	return nil
+}
+
+func (t *traceCallback) OnSteps(stepType string, count int, steps *big.Int) error {
+	if t.sp != nil {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		return t.sp.OnSteps(stepType, count, steps)
+	}
+	return nil
+}
@@ -86,3 +86,91 @@ func TestTransactionParamValidator(t *testing.T) {
 		assert.Fail(t, "validate fail", err.Error())
 	}
 }
+
+func TestTraceParamValidator(t *testing.T) {
+	validator := jsonrpc.NewValidator()
+	RegisterValidationRule(validator)
+
+	hash := "0x4f4feed4a1d29779f84460d663e1ffb894d65dacfa3cc215a353a4b0d0d8f020"
+	for _, tc := range []struct {
+		mode  string
+		valid bool
+	}{
+		{"", true},
+		{"invoke", true},
+		{"callTree", true},
+		{"stateDiff", true},
+		{"balanceChange", false},
+	} {
+		param := &TraceParam{
+			Hash: jsonrpc.HexBytes(hash),
+			Mode: tc.mode,
+		}
+		err := validator.Validate(param)
+		if tc.valid {
+			assert.NoError(t, err, tc.mode)
+		} else {
+			assert.Error(t, err, tc.mode)
+		}
+	}
+}
+
+func TestTraceCallParamValidator(t *testing.T) {
+	validator := jsonrpc.NewValidator()
+	RegisterValidationRule(validator)
+
+	js := []byte(`{
+		"version": "0x3",
+		"from": "hx4873b94352c8c1f3b2f09aaeccea31ce9e90bd31",
+		"to": "cx059e19601bcb1424884f4ef19addc0a03de9e9cd",
+		"timestamp": "0x563a6cf330136",
+		"nid": "0x3",
+		"dataType": "call",
+		"data": {
+			"method": "transfer"
+		},
+		"height": "0x10",
+		"mode": "callTree"
+	}`)
+	var param TraceCallParam
+	assert.NoError(t, json.Unmarshal(js, &param))
+	assert.NoError(t, validator.Validate(&param))
+	assert.Equal(t, "0x10", string(param.Height))
+	assert.Equal(t, "call", param.DataType)
+
+	param.Mode = "stepProfile"
+	assert.NoError(t, validator.Validate(&param))
+
+	param.Mode = "stateDiff"
+	assert.Error(t, validator.Validate(&param))
+
+	param.Mode = ""
+	param.FromAddress = "cx4873b94352c8c1f3b2f09aaeccea31ce9e90bd31"
+	assert.Error(t, validator.Validate(&param))
+}
+
+func TestTraceBlocksParamValidator(t *testing.T) {
+	validator := jsonrpc.NewValidator()
+	RegisterValidationRule(validator)
+
+	cases := []struct {
+		js    string
+		valid bool
+	}{
+		{`{"start":"0x10"}`, true},
+		{`{"start":"0x10","end":"0x20","mode":"stateDiff"}`, true},
+		{`{"start":"0x10","mode":"callTree"}`, true},
+		{`{"end":"0x20"}`, false},
+		{`{"start":"16"}`, false},
+		{`{"start":"0x10","mode":"balanceChange"}`, false},
+	}
+	for _, c := range cases {
+		var param TraceBlocksParam
+		assert.NoError(t, json.Unmarshal([]byte(c.js), &param))
+		if c.valid {
+			assert.NoError(t, validator.Validate(&param), c.js)
+		} else {
+			assert.Error(t, validator.Validate(&param), c.js)
+		}
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"errors"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
 
+	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/server/jsonrpc"
@@ -24,12 +26,16 @@ type wsSessionManager struct {
 	maxSession int
 	logger     log.Logger
 	sessions   []*wsSession
+	pongWait   time.Duration
+	pingPeriod time.Duration
 }
 
 func newWSSessionManager(logger log.Logger, maxSession int) *wsSessionManager {
 	return &wsSessionManager{
 		maxSession: maxSession,
 		logger:     logger,
+		pongWait:   wsPongWait,
+		pingPeriod: wsPingPeriod,
 	}
 }
 
@@ -170,6 +176,15 @@ func (wss *wsSession) WriteJSON(v interface{}) error {
 
 const DefaultWSMaxSession = 10
 
+const (
+	// wsPongWait is the time allowed to read the next pong from the client.
+	wsPongWait = 60 * time.Second
+	// wsPingPeriod is the period to send ping to the client.
+	wsPingPeriod = wsPongWait / 2
+	// wsWriteWait is the time allowed to write a control message.
+	wsWriteWait = 10 * time.Second
+)
+
 type WSResponse struct {
 	Code    int    `json:"code"`
 	Message string `json:"message,omitempty"`
@@ -183,7 +198,67 @@ func Upgrader() *websocket.Upgrader {
 	}
 }
 
-func readLoop(c *websocket.Conn, ech chan<- error) {
+// ProgressNotification is sent to the client every ProgressInterval
+// blocks with the height of the last scanned block if it's requested.
+type ProgressNotification struct {
+	Progress common.HexInt64 `json:"progress"`
+}
+
+type progressReporter struct {
+	interval int64
+	count    int64
+}
+
+func newProgressReporter(interval common.HexInt64) *progressReporter {
+	return &progressReporter{interval: interval.Value}
+}
+
+// onBlock is called after the block at the height is scanned.
+func (p *progressReporter) onBlock(wss *wsSession, height int64) error {
+	if p.interval <= 0 {
+		return nil
+	}
+	p.count++
+	if p.count < p.interval {
+		return nil
+	}
+	p.count = 0
+	return wss.WriteJSON(&ProgressNotification{
+		Progress: common.HexInt64{Value: height},
+	})
+}
+
+// pingLoop sends ping to the client periodically until done is closed
+// or it fails to send.
+func pingLoop(c *websocket.Conn, period time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			err := c.WriteControl(websocket.PingMessage, nil,
+				time.Now().Add(wsWriteWait))
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readLoop reads messages from the client for handling control messages.
+// It also keeps the connection alive with ping and pong, so it returns
+// an error if the client doesn't respond to ping in time.
+func (wm *wsSessionManager) readLoop(c *websocket.Conn, ech chan<- error) {
+	pongWait := wm.pongWait
+	_ = c.SetReadDeadline(time.Now().Add(pongWait))
+	c.SetPongHandler(func(string) error {
+		return c.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	done := make(chan struct{})
+	defer close(done)
+	go pingLoop(c, wm.pingPeriod, done)
 	for {
 		if _, _, err := c.NextReader(); err != nil {
 			ech <- err
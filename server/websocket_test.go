@@ -0,0 +1,218 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/log"
+)
+
+const testWSReadTimeout = 5 * time.Second
+
+// startSession starts the session of the chain with the request by run,
+// then it returns the connection of the client and the response.
+func startSession(t *testing.T, c *testChain, run func(wm *wsSessionManager, ctx echo.Context) error, req string) (*websocket.Conn, *WSResponse, func()) {
+	return startSessionWith(t, newWSSessionManager(log.New(), DefaultWSMaxSession), c, run, req)
+}
+
+// startSessionWith is startSession with the session manager.
+func startSessionWith(t *testing.T, wm *wsSessionManager, c *testChain, run func(wm *wsSessionManager, ctx echo.Context) error, req string) (*websocket.Conn, *WSResponse, func()) {
+	e := echo.New()
+	e.GET("/session", func(ctx echo.Context) error {
+		ctx.Set("chain", c)
+		return run(wm, ctx)
+	})
+	s := httptest.NewServer(e)
+
+	url := "ws" + strings.TrimPrefix(s.URL, "http") + "/session"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if !assert.NoError(t, err) {
+		s.Close()
+		t.FailNow()
+	}
+	assert.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(req)))
+
+	var res WSResponse
+	_ = conn.SetReadDeadline(time.Now().Add(testWSReadTimeout))
+	assert.NoError(t, conn.ReadJSON(&res))
+	return conn, &res, func() {
+		conn.Close()
+		wm.StopAllSessions()
+		s.Close()
+	}
+}
+
+// startEventSession starts the event session of the chain with the
+// request, then it returns the connection of the client.
+func startEventSession(t *testing.T, c *testChain, req string) (*websocket.Conn, func()) {
+	conn, res, stop := startSession(t, c, (*wsSessionManager).RunEventSession, req)
+	assert.Equal(t, 0, res.Code, res.Message)
+	return conn, stop
+}
+
+// testEventNotification is EventNotification or ProgressNotification.
+type testEventNotification struct {
+	EventNotification
+	Progress *common.HexInt64 `json:"progress"`
+}
+
+// readNotifications reads n notifications, and returns them in the form
+// of "height:index:events", "height:index:filter:events" for notifications
+// with the filter, or "progress:height".
+func readNotifications(t *testing.T, conn *websocket.Conn, n int) []string {
+	var values []string
+	for i := 0; i < n; i++ {
+		var en testEventNotification
+		_ = conn.SetReadDeadline(time.Now().Add(testWSReadTimeout))
+		if !assert.NoError(t, conn.ReadJSON(&en)) {
+			break
+		}
+		if en.Progress != nil {
+			values = append(values, fmt.Sprintf("progress:%d", en.Progress.Value))
+			continue
+		}
+		var events []string
+		for _, e := range en.Events {
+			events = append(events, fmt.Sprint(e.Value))
+		}
+		if en.Filter != nil {
+			values = append(values, fmt.Sprintf("%d:%d:%d:%s",
+				en.Height.Value, en.Index.Value, en.Filter.Value, strings.Join(events, ",")))
+			continue
+		}
+		values = append(values, fmt.Sprintf("%d:%d:%s",
+			en.Height.Value, en.Index.Value, strings.Join(events, ",")))
+	}
+	return values
+}
+
+func TestEventSession_Progress(t *testing.T) {
+	c := newLogsTestChain(t)
+	conn, stop := startEventSession(t, c,
+		`{"height":"0x1","event":"`+testEventSignature+`","progressInterval":"0x2"}`)
+	defer stop()
+
+	assert.Equal(t, []string{
+		"2:0:0,1",
+		"progress:2",
+		"3:1:0",
+		"4:0:0,1",
+		"progress:4",
+	}, readNotifications(t, conn, 5))
+
+	c.addBlock()
+	assert.Equal(t, []string{"progress:6"}, readNotifications(t, conn, 1))
+}
+
+func TestEventSession_After(t *testing.T) {
+	tests := []struct {
+		name   string
+		after  string
+		values []string
+	}{
+		{"InEvents", `{"height":"0x2","index":"0x0","event":"0x0"}`, []string{"2:0:1", "3:1:0", "4:0:0,1"}},
+		{"LastEvent", `{"height":"0x2","index":"0x0","event":"0x1"}`, []string{"3:1:0", "4:0:0,1"}},
+		{"LastResult", `{"height":"0x3","index":"0x1","event":"0x0"}`, []string{"4:0:0,1"}},
+		{"BeforeEvents", `{"height":"0x1","index":"0x0","event":"0x0"}`, []string{"2:0:0,1", "3:1:0", "4:0:0,1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newLogsTestChain(t)
+			conn, stop := startEventSession(t, c,
+				`{"height":"0x0","event":"`+testEventSignature+`","after":`+tt.after+`}`)
+			defer stop()
+
+			assert.Equal(t, tt.values, readNotifications(t, conn, len(tt.values)))
+		})
+	}
+}
+
+func TestEventSession_AfterWithFilters(t *testing.T) {
+	// filter 0 matches events from testUser2, and filter 1 matches events
+	// from testUser1, so events of a result are interleaved by the filters.
+	filters := `"eventFilters":[` +
+		`{"event":"` + testEventSignature + `","indexed":["` + testUser2 + `"]},` +
+		`{"event":"` + testEventSignature + `","indexed":["` + testUser1 + `"]}]`
+	tests := []struct {
+		name   string
+		after  string
+		values []string
+	}{
+		{"None", `null`, []string{"2:0:0:1", "2:0:1:0", "3:1:1:0", "4:0:0:1", "4:0:1:0"}},
+		{"FirstFilter", `{"height":"0x2","index":"0x0","filter":"0x0","event":"0x1"}`, []string{"2:0:1:0", "3:1:1:0", "4:0:0:1", "4:0:1:0"}},
+		{"LastFilter", `{"height":"0x2","index":"0x0","filter":"0x1","event":"0x0"}`, []string{"3:1:1:0", "4:0:0:1", "4:0:1:0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newLogsTestChain(t)
+			conn, stop := startEventSession(t, c,
+				`{"height":"0x0",`+filters+`,"after":`+tt.after+`}`)
+			defer stop()
+
+			assert.Equal(t, tt.values, readNotifications(t, conn, len(tt.values)))
+		})
+	}
+}
+
+func TestEventSession_PongDeadline(t *testing.T) {
+	req := `{"height":"0x6","event":"` + testEventSignature + `","progressInterval":"0x1"}`
+	startWithDeadline := func(t *testing.T, c *testChain) (*wsSessionManager, *websocket.Conn, func()) {
+		wm := newWSSessionManager(log.New(), DefaultWSMaxSession)
+		wm.pongWait, wm.pingPeriod = 300*time.Millisecond, 100*time.Millisecond
+		conn, res, stop := startSessionWith(t, wm, c, (*wsSessionManager).RunEventSession, req)
+		assert.Equal(t, 0, res.Code, res.Message)
+		return wm, conn, stop
+	}
+
+	t.Run("Respond", func(t *testing.T) {
+		c := newLogsTestChain(t)
+		wm, conn, stop := startWithDeadline(t, c)
+		defer stop()
+
+		pings := make(chan struct{}, 1)
+		conn.SetPingHandler(func(data string) error {
+			select {
+			case pings <- struct{}{}:
+			default:
+			}
+			return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+		})
+		done := make(chan []string)
+		go func() {
+			done <- readNotifications(t, conn, 1)
+		}()
+		// the session is kept after pongWait with pongs for pings
+		for n := 0; n <= int(wm.pongWait/wm.pingPeriod); n++ {
+			select {
+			case <-pings:
+			case <-time.After(testWSReadTimeout):
+				assert.FailNow(t, "no ping from the session")
+			}
+		}
+		c.addBlock()
+		assert.Equal(t, []string{"progress:6"}, <-done)
+	})
+
+	t.Run("NoResponse", func(t *testing.T) {
+		c := newLogsTestChain(t)
+		_, conn, stop := startWithDeadline(t, c)
+		defer stop()
+
+		conn.SetPingHandler(func(string) error { return nil })
+		_ = conn.SetReadDeadline(time.Now().Add(testWSReadTimeout))
+		_, _, err := conn.ReadMessage()
+		if assert.Error(t, err) {
+			ne, ok := err.(net.Error)
+			assert.False(t, ok && ne.Timeout(), "session isn't closed")
+		}
+	})
+}
@@ -11,9 +11,10 @@ import (
 )
 
 type BlockRequest struct {
-	Height       common.HexInt64 `json:"height"`
-	EventFilters []*EventFilter  `json:"eventFilters,omitempty"`
-	bn           BlockNotification
+	Height           common.HexInt64 `json:"height"`
+	EventFilters     []*EventFilter  `json:"eventFilters,omitempty"`
+	ProgressInterval common.HexInt64 `json:"progressInterval,omitempty"`
+	bn               BlockNotification
 }
 
 type BlockNotification struct {
@@ -53,9 +54,10 @@ func (wm *wsSessionManager) RunBlockSession(ctx echo.Context) error {
 	_ = wss.response(0, "")
 
 	ech := make(chan error)
-	go readLoop(wss.c, ech)
+	go wm.readLoop(wss.c, ech)
 
 	var bch <-chan module.Block
+	pr := newProgressReporter(br.ProgressInterval)
 	indexes := make([][]common.HexInt32, len(br.EventFilters))
 	events := make([][][]common.HexInt32, len(br.EventFilters))
 	for i := range br.EventFilters {
@@ -90,7 +92,7 @@ loop:
 			}
 			lb := blk.LogsBloom()
 			for i, f := range br.EventFilters {
-				if lb.Contain(f.lb) {
+				if f.ContainedIn(lb) {
 					if rl == nil {
 						rl, err = sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
 						if err != nil {
@@ -103,7 +105,7 @@ loop:
 						if err != nil {
 							break loop
 						}
-						if es, ok := f.match(r); ok {
+						if es, ok := f.Match(r); ok {
 							if len(br.bn.Indexes) < 1 {
 								br.bn.Indexes = indexes[:]
 								br.bn.Events = events[:]
@@ -119,6 +121,10 @@ loop:
 				wm.logger.Infof("fail to write json BlockNotification err:%+v\n", err)
 				break loop
 			}
+			if err = pr.onBlock(wss, h); err != nil {
+				wm.logger.Infof("fail to write json ProgressNotification err:%+v\n", err)
+				break loop
+			}
 		}
 		h++
 	}
@@ -128,7 +134,7 @@ loop:
 
 func (r *BlockRequest) compile() error {
 	for i, f := range r.EventFilters {
-		if err := f.compile(); err != nil {
+		if err := f.Compile(); err != nil {
 			return fmt.Errorf("fail to compile idx:%d, err:%v", i, err)
 		}
 	}
@@ -11,10 +11,11 @@ import (
 )
 
 type BTPRequest struct {
-	Height    common.HexInt64 `json:"height"`
-	NetworkId common.HexInt64 `json:"networkID"`
-	ProofFlag bool            `json:"proofFlag"`
-	bn        BTPNotification
+	Height           common.HexInt64 `json:"height"`
+	NetworkId        common.HexInt64 `json:"networkID"`
+	ProofFlag        bool            `json:"proofFlag"`
+	ProgressInterval common.HexInt64 `json:"progressInterval,omitempty"`
+	bn               BTPNotification
 }
 
 type BTPNotification struct {
@@ -48,9 +49,10 @@ func (wm *wsSessionManager) RunBtpSession(ctx echo.Context) error {
 	_ = wss.response(0, "")
 
 	ech := make(chan error)
-	go readLoop(wss.c, ech)
+	go wm.readLoop(wss.c, ech)
 
 	var bch <-chan module.Block
+	pr := newProgressReporter(br.ProgressInterval)
 
 	block, err := bm.GetLastBlock()
 	nw, err := sm.BTPNetworkFromResult(block.Result(), br.NetworkId.Value)
@@ -105,6 +107,10 @@ loop:
 					}
 				}
 			}
+			if err = pr.onBlock(wss, h); err != nil {
+				wm.logger.Infof("fail to write json ProgressNotification err:%+v\n", err)
+				break loop
+			}
 		}
 		h++
 	}
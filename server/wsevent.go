@@ -1,7 +1,6 @@
 package server
 
 import (
-	"bytes"
 	"fmt"
 
 	"github.com/labstack/echo/v4"
@@ -10,31 +9,40 @@ import (
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/server/jsonrpc"
-	"github.com/icon-project/goloop/service/txresult"
+	"github.com/icon-project/goloop/server/v3"
 )
 
+// EventRequest is the request for the event session. It has a single
+// filter embedded or a list of filters in EventFilters for compatibility.
 type EventRequest struct {
 	EventFilter
-	Height common.HexInt64 `json:"height"`
-	Logs   common.HexInt32 `json:"logs,omitempty""`
+	Height           common.HexInt64 `json:"height"`
+	Logs             common.HexInt32 `json:"logs,omitempty""`
+	EventFilters     []*EventFilter  `json:"eventFilters,omitempty"`
+	ProgressInterval common.HexInt64 `json:"progressInterval,omitempty"`
+	After            *EventPosition  `json:"after,omitempty"`
+	filters          []*EventFilter
 }
 
-type EventFilter struct {
-	Addr       *common.Address `json:"addr,omitempty"`
-	Signature  string          `json:"event"`
-	Indexed    []*string       `json:"indexed,omitempty"`
-	Data       []*string       `json:"data,omitempty"`
-	indexedBSs [][]byte
-	dataBSs    [][]byte
-	numOfArgs  int
-	lb         module.LogsBloom
-	indexes    []int
+// EventPosition is the position of the last delivered event. The session
+// resumes from the next event of the position if it's specified. Events of
+// a result are sent for each filter in the order of the filters, so Filter
+// is a part of the position.
+// Block and BTP sessions don't have it, so they resume with the height.
+type EventPosition struct {
+	Height common.HexInt64 `json:"height"`
+	Index  common.HexInt32 `json:"index"`
+	Filter common.HexInt32 `json:"filter"`
+	Event  common.HexInt32 `json:"event"`
 }
 
+type EventFilter = v3.EventFilter
+
 type EventNotification struct {
 	Hash   common.HexBytes   `json:"hash"`
 	Height common.HexInt64   `json:"height"`
 	Index  common.HexInt32   `json:"index"`
+	Filter *common.HexInt32  `json:"filter,omitempty"`
 	Events []common.HexInt32 `json:"events"`
 	Logs   []module.EventLog `json:"logs,omitempty"`
 }
@@ -48,7 +56,7 @@ func (wm *wsSessionManager) RunEventSession(ctx echo.Context) error {
 	defer wm.StopSession(wss)
 
 	if err := er.compile(); err != nil {
-		_ = wss.response(int(jsonrpc.ErrorCodeInvalidParams), "bad event request parameter")
+		_ = wss.response(int(jsonrpc.ErrorCodeInvalidParams), err.Error())
 		return nil
 	}
 
@@ -60,6 +68,9 @@ func (wm *wsSessionManager) RunEventSession(ctx echo.Context) error {
 	}
 
 	h := er.Height.Value
+	if er.After != nil {
+		h = er.After.Height.Value
+	}
 	if gh := wss.chain.GenesisStorage().Height(); gh > h {
 		_ = wss.response(int(jsonrpc.ErrorCodeInvalidParams),
 			fmt.Sprintf("given height(%d) is lower than genesis height(%d)", h, gh))
@@ -69,9 +80,10 @@ func (wm *wsSessionManager) RunEventSession(ctx echo.Context) error {
 	_ = wss.response(0, "")
 
 	ech := make(chan error)
-	go readLoop(wss.c, ech)
+	go wm.readLoop(wss.c, ech)
 
 	var bch <-chan module.Block
+	pr := newProgressReporter(er.ProgressInterval)
 
 loop:
 	for {
@@ -83,33 +95,12 @@ loop:
 		case err = <-ech:
 			break loop
 		case blk := <-bch:
-			if !blk.LogsBloom().Contain(er.lb) {
-				h++
-				continue loop
-			}
-			rl, err := sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
-			if err != nil {
+			if err = wm.sendEventsOfBlock(wss, sm, &er, h, blk); err != nil {
 				break loop
 			}
-			index := int32(0)
-			for rit := rl.Iterator(); rit.Has(); rit.Next() {
-				r, err := rit.Get()
-				if err != nil {
-					break loop
-				}
-				if es, el, err := er.matchWithLogs(r, er.Logs.Value != 0); err == nil && len(es) > 0 {
-					var en EventNotification
-					en.Height.Value = h
-					en.Hash = blk.ID()
-					en.Index.Value = index
-					en.Events = es
-					en.Logs = el
-					if err := wss.WriteJSON(&en); err != nil {
-						wm.logger.Infof("fail to write json EventNotification err:%+v\n", err)
-						break loop
-					}
-				}
-				index++
+			if err = pr.onBlock(wss, h); err != nil {
+				wm.logger.Infof("fail to write json ProgressNotification err:%+v\n", err)
+				break loop
 			}
 		}
 		h++
@@ -118,115 +109,111 @@ loop:
 	return nil
 }
 
-func (f *EventFilter) compile() error {
-	lb := txresult.NewLogsBloom(nil)
-	if f.Addr != nil {
-		lb.AddAddressOfLog(f.Addr)
-	}
-	f.numOfArgs = len(f.Indexed) + len(f.Data)
-	name, pts := txresult.DecomposeEventSignature(f.Signature)
-	if len(name) == 0 || pts == nil || len(pts) < f.numOfArgs {
-		return errors.NewBase(errors.IllegalArgumentError, "bad event signature")
-	}
-	lb.AddIndexedOfLog(0, []byte(f.Signature))
-	idx := 0
-	f.indexedBSs = make([][]byte, len(f.Indexed))
-	for i, arg := range f.Indexed {
-		if arg != nil {
-			bs, err := txresult.EventDataStringToBytesByType(pts[idx], string(*arg))
-			if err != nil {
-				return errors.NewBase(errors.IllegalArgumentError, "bad event data")
-			}
-			lb.AddIndexedOfLog(i+1, bs)
-			f.indexedBSs[i] = bs
-		}
-		idx++
+func (wm *wsSessionManager) sendEventsOfBlock(
+	wss *wsSession, sm module.ServiceManager, er *EventRequest,
+	h int64, blk module.Block,
+) error {
+	filters := er.filtersContainedIn(blk.LogsBloom())
+	if len(filters) == 0 {
+		return nil
 	}
-	f.dataBSs = make([][]byte, len(f.Data))
-	for i, arg := range f.Data {
-		if arg != nil {
-			bs, err := txresult.EventDataStringToBytesByType(pts[idx], string(*arg))
+	rl, err := sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
+	if err != nil {
+		return err
+	}
+	index := int32(0)
+	for rit := rl.Iterator(); rit.Has(); rit.Next() {
+		r, err := rit.Get()
+		if err != nil {
+			return err
+		}
+		for _, fi := range filters {
+			f := er.filters[fi]
+			es, el, err := f.MatchWithLogs(r, er.Logs.Value != 0)
 			if err != nil {
-				return errors.NewBase(errors.IllegalArgumentError, "bad event data")
+				continue
+			}
+			es, el = er.eventsAfter(h, index, int32(fi), es, el)
+			if len(es) == 0 {
+				continue
+			}
+			var en EventNotification
+			en.Height.Value = h
+			en.Hash = blk.ID()
+			en.Index.Value = index
+			if len(er.EventFilters) > 0 {
+				en.Filter = &common.HexInt32{Value: int32(fi)}
+			}
+			en.Events = es
+			en.Logs = el
+			if err := wss.WriteJSON(&en); err != nil {
+				wm.logger.Infof("fail to write json EventNotification err:%+v\n", err)
+				return err
 			}
-			f.dataBSs[i] = bs
 		}
-		idx++
+		index++
 	}
-	f.lb = lb
 	return nil
 }
 
-// bytesEqual check equality of byte slice.
-// But it doesn't assume nil as empty bytes.
-func bytesEqual(b1 []byte, b2 []byte) bool {
-	if b1 == nil && b2 == nil {
-		return true
+// eventsAfter returns the events after the resume position of the request.
+func (r *EventRequest) eventsAfter(
+	height int64, index int32, filter int32, es []common.HexInt32, el []module.EventLog,
+) ([]common.HexInt32, []module.EventLog) {
+	p := r.After
+	if p == nil || height != p.Height.Value || index > p.Index.Value {
+		return es, el
 	}
-	if b1 == nil || b2 == nil {
-		return false
+	if index < p.Index.Value || filter < p.Filter.Value {
+		return nil, nil
 	}
-	return bytes.Equal(b1, b2)
-}
-
-func (f *EventFilter) matchWithLogs(r module.Receipt, includeLogs bool) ([]common.HexInt32, []module.EventLog, error) {
-	var indexes []common.HexInt32
-	var logs []module.EventLog
-	if err := f.filterFunc(r, func(idx int, log module.EventLog) {
-		indexes = append(indexes, common.HexInt32{Value: int32(idx)})
-		if includeLogs {
-			logs = append(logs, log)
+	if filter > p.Filter.Value {
+		return es, el
+	}
+	for i, e := range es {
+		if e.Value > p.Event.Value {
+			if len(el) > 0 {
+				el = el[i:]
+			}
+			return es[i:], el
 		}
-	}); err != nil {
-		return nil, nil, err
 	}
-	return indexes, logs, nil
+	return nil, nil
 }
 
-func (f *EventFilter) match(r module.Receipt) ([]common.HexInt32, bool) {
-	eventIndexes := make([]common.HexInt32, 0)
-	if err := f.filterFunc(r, func(idx int, log module.EventLog) {
-		eventIndexes = append(eventIndexes, common.HexInt32{int32(idx)})
-	}); err != nil {
-		return []common.HexInt32{}, false
-	} else {
-		return eventIndexes, len(eventIndexes) > 0
+func (r *EventRequest) compile() error {
+	if len(r.EventFilters) == 0 {
+		if err := r.EventFilter.Compile(); err != nil {
+			return err
+		}
+		r.filters = []*EventFilter{&r.EventFilter}
+		return nil
+	}
+	if r.Addr != nil || len(r.Addrs) > 0 || len(r.Signature) > 0 {
+		return errors.IllegalArgumentError.New(
+			"event filter and eventFilters are used together")
+	}
+	for i, f := range r.EventFilters {
+		if f == nil {
+			return errors.IllegalArgumentError.Errorf("null filter idx:%d", i)
+		}
+		if err := f.Compile(); err != nil {
+			return errors.IllegalArgumentError.Wrapf(err,
+				"fail to compile idx:%d", i)
+		}
 	}
-	return eventIndexes, false
+	r.filters = r.EventFilters
+	return nil
 }
 
-func (f *EventFilter) filterFunc(r module.Receipt, v func(idx int, log module.EventLog)) error {
-	if r.LogsBloom().Contain(f.lb) {
-	loop:
-		for it, idx := r.EventLogIterator(), 0; it.Has(); _, idx = it.Next(), idx+1 {
-			el, err := it.Get()
-			if err != nil {
-				return err
-			}
-
-			if bytes.Equal([]byte(f.Signature), el.Indexed()[0]) {
-				if f.Addr != nil && !el.Address().Equal(f.Addr) {
-					continue loop
-				}
-				if f.numOfArgs > 0 {
-					if (len(el.Indexed()) + len(el.Data())) <= f.numOfArgs {
-						continue loop
-					}
-
-					for i, arg := range f.indexedBSs {
-						if arg != nil && !bytesEqual(arg, el.Indexed()[i+1]) {
-							continue loop
-						}
-					}
-					for i, arg := range f.dataBSs {
-						if arg != nil && !bytesEqual(arg, el.Data()[i]) {
-							continue loop
-						}
-					}
-				}
-				v(idx, el)
-			}
+// filtersContainedIn returns indexes of the filters possibly matching
+// with the logs bloom.
+func (r *EventRequest) filtersContainedIn(lb module.LogsBloom) []int {
+	var filters []int
+	for i, f := range r.filters {
+		if f.ContainedIn(lb) {
+			filters = append(filters, i)
 		}
 	}
-	return nil
+	return filters
 }
@@ -0,0 +1,183 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+const testScore3 = "cx0000000000000000000000000000000000000013"
+
+func compileEventRequest(req string) (*EventRequest, error) {
+	var er EventRequest
+	if err := json.Unmarshal([]byte(req), &er); err != nil {
+		return nil, err
+	}
+	return &er, er.compile()
+}
+
+func TestEventRequest_Compile(t *testing.T) {
+	sig := `"event":"` + testEventSignature + `"`
+	tests := []struct {
+		name    string
+		req     string
+		filters int
+		ok      bool
+	}{
+		{"SingleFilter", `{"height":"0x1",` + sig + `}`, 1, true},
+		{"SingleFilterWithAddr", `{"height":"0x1","addr":"` + testScore1 + `",` + sig + `}`, 1, true},
+		{"SingleFilterWithAddrs", `{"height":"0x1","addrs":["` + testScore1 + `","` + testScore2 + `"],` + sig + `}`, 1, true},
+		{"EventFilters", `{"height":"0x1","eventFilters":[{` + sig + `},{"addr":"` + testScore1 + `",` + sig + `}]}`, 2, true},
+		{"MixedFilters", `{"height":"0x1",` + sig + `,"eventFilters":[{` + sig + `}]}`, 0, false},
+		{"MixedAddr", `{"height":"0x1","addr":"` + testScore1 + `","eventFilters":[{` + sig + `}]}`, 0, false},
+		{"NullFilter", `{"height":"0x1","eventFilters":[{` + sig + `},null]}`, 0, false},
+		{"NullAddress", `{"height":"0x1","addrs":[null],` + sig + `}`, 0, false},
+		{"BadSignature", `{"height":"0x1","eventFilters":[{"event":"Transfer"}]}`, 0, false},
+		{"NoSignature", `{"height":"0x1"}`, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			er, err := compileEventRequest(tt.req)
+			if !tt.ok {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Len(t, er.filters, tt.filters)
+			}
+		})
+	}
+}
+
+func TestEventFilter_Match(t *testing.T) {
+	r := newTestReceipt(t,
+		testLog{testScore1, testUser1, testUser2, "0x1"},
+		testLog{testScore2, testUser2, testUser1, "0x2"},
+		testLog{testScore1, testUser2, testUser2, "0x3"},
+	)
+	sig := `"event":"` + testEventSignature + `"`
+	tests := []struct {
+		name   string
+		filter string
+		events []int32
+	}{
+		{"Signature", `{` + sig + `}`, []int32{0, 1, 2}},
+		{"Addr", `{"addr":"` + testScore1 + `",` + sig + `}`, []int32{0, 2}},
+		{"Addrs", `{"addrs":["` + testScore2 + `"],` + sig + `}`, []int32{1}},
+		{"AddrAndAddrs", `{"addr":"` + testScore1 + `","addrs":["` + testScore2 + `","` + testScore1 + `"],` + sig + `}`, []int32{0, 1, 2}},
+		{"UnknownAddrs", `{"addrs":["` + testScore3 + `"],` + sig + `}`, nil},
+		{"AddrsWithIndexed", `{"addrs":["` + testScore1 + `","` + testScore2 + `"],"indexed":["` + testUser2 + `"],` + sig + `}`, []int32{1, 2}},
+		{"AddrsWithData", `{"addrs":["` + testScore1 + `","` + testScore3 + `"],"indexed":[null,null],"data":["0x3"],` + sig + `}`, []int32{2}},
+		{"OtherSignature", `{"event":"Approval(Address,Address,int)"}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var f EventFilter
+			assert.NoError(t, json.Unmarshal([]byte(tt.filter), &f))
+			if !assert.NoError(t, f.Compile()) {
+				return
+			}
+			es, el, err := f.MatchWithLogs(r, true)
+			assert.NoError(t, err)
+			var events []int32
+			for _, e := range es {
+				events = append(events, e.Value)
+			}
+			assert.Equal(t, tt.events, events)
+			assert.Len(t, el, len(es))
+			for _, l := range el {
+				assert.True(t, f.Addr == nil && len(f.Addrs) == 0 || f.HasAddress(l.Address()))
+			}
+		})
+	}
+}
+
+func TestEventRequest_FiltersContainedIn(t *testing.T) {
+	lb := txresult.NewLogsBloom(nil)
+	lb.Merge(newTestReceipt(t,
+		testLog{testScore1, testUser1, testUser2, "0x1"},
+	).LogsBloom())
+
+	sig := `"event":"` + testEventSignature + `"`
+	er, err := compileEventRequest(`{"height":"0x1","eventFilters":[` +
+		`{"addr":"` + testScore2 + `",` + sig + `},` +
+		`{"addrs":["` + testScore3 + `","` + testScore1 + `"],` + sig + `},` +
+		`{"event":"Approval(Address,Address,int)"},` +
+		`{"indexed":["` + testUser1 + `"],` + sig + `}]}`)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []int{1, 3}, er.filtersContainedIn(lb))
+	}
+}
+
+// readTaggedNotifications reads n event notifications, and returns them
+// in the form of "filter:height:index:events". filter is "-" if it's not
+// tagged.
+func readTaggedNotifications(t *testing.T, conn *websocket.Conn, n int) []string {
+	var values []string
+	for i := 0; i < n; i++ {
+		var en EventNotification
+		_ = conn.SetReadDeadline(time.Now().Add(testWSReadTimeout))
+		if !assert.NoError(t, conn.ReadJSON(&en)) {
+			break
+		}
+		filter := "-"
+		if en.Filter != nil {
+			filter = fmt.Sprint(en.Filter.Value)
+		}
+		var events []string
+		for _, e := range en.Events {
+			events = append(events, fmt.Sprint(e.Value))
+		}
+		values = append(values, fmt.Sprintf("%s:%d:%d:%s",
+			filter, en.Height.Value, en.Index.Value, strings.Join(events, ",")))
+	}
+	return values
+}
+
+func TestEventSession_Filters(t *testing.T) {
+	sig := `"event":"` + testEventSignature + `"`
+	tests := []struct {
+		name   string
+		req    string
+		values []string
+	}{
+		{
+			"SingleFilter",
+			`{"height":"0x1","addr":"` + testScore1 + `",` + sig + `}`,
+			[]string{"-:2:0:0,1", "-:4:0:0"},
+		},
+		{
+			"SingleFilterWithAddrs",
+			`{"height":"0x1","addrs":["` + testScore2 + `","` + testScore3 + `"],` + sig + `}`,
+			[]string{"-:3:1:0", "-:4:0:1"},
+		},
+		{
+			"EventFilters",
+			`{"height":"0x1","eventFilters":[` +
+				`{"addr":"` + testScore2 + `",` + sig + `},` +
+				`{"addrs":["` + testScore1 + `","` + testScore3 + `"],` + sig + `},` +
+				`{"indexed":["` + testUser1 + `"],` + sig + `}]}`,
+			[]string{
+				"1:2:0:0,1", "2:2:0:0",
+				"0:3:1:0", "2:3:1:0",
+				"0:4:0:1", "1:4:0:0", "2:4:0:0",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newLogsTestChain(t)
+			conn, stop := startEventSession(t, c, tt.req)
+			defer stop()
+
+			assert.Equal(t, tt.values, readTaggedNotifications(t, conn, len(tt.values)))
+		})
+	}
+}
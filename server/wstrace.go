@@ -0,0 +1,132 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	"github.com/icon-project/goloop/server/v3"
+)
+
+type TraceRequest struct {
+	Start            common.HexInt64  `json:"start"`
+	End              *common.HexInt64 `json:"end,omitempty"`
+	Mode             string           `json:"mode,omitempty"`
+	ProgressInterval common.HexInt64  `json:"progressInterval,omitempty"`
+}
+
+// TraceNotification is sent to the client for each traced block.
+// Result is the same as the result of debug_traceBlocks for the block.
+type TraceNotification struct {
+	Height common.HexInt64 `json:"height"`
+	Result interface{}     `json:"result,omitempty"`
+	Error  *WSResponse     `json:"error,omitempty"`
+}
+
+// RunTraceSession replays finalized blocks from the start height and sends
+// the result of each block. If the end height isn't specified, it keeps
+// tracing blocks as they are finalized. Closing the connection cancels
+// the trace in progress.
+func (wm *wsSessionManager) RunTraceSession(ctx echo.Context) error {
+	var tr TraceRequest
+	wss, err := wm.initSession(ctx, &tr)
+	if err != nil {
+		return err
+	}
+	defer wm.StopSession(wss)
+
+	if err := tr.validate(); err != nil {
+		_ = wss.response(int(jsonrpc.ErrorCodeInvalidParams), err.Error())
+		return nil
+	}
+
+	bm := wss.chain.BlockManager()
+	sm := wss.chain.ServiceManager()
+	if bm == nil || sm == nil {
+		_ = wss.response(int(jsonrpc.ErrorCodeServer), "Stopped")
+		return nil
+	}
+
+	h := tr.Start.Value
+	if gh := wss.chain.GenesisStorage().Height(); gh > h {
+		_ = wss.response(int(jsonrpc.ErrorCodeInvalidParams),
+			fmt.Sprintf("given height(%d) is lower than genesis height(%d)", h, gh))
+		return nil
+	}
+
+	_ = wss.response(0, "")
+
+	ech := make(chan error, 1)
+	go wm.readLoop(wss.c, ech)
+
+	cancel := make(chan struct{})
+	var bch <-chan module.Block
+	pr := newProgressReporter(tr.ProgressInterval)
+loop:
+	for tr.End == nil || h <= tr.End.Value {
+		// the block is finalized if the next block exists.
+		bch, err = bm.WaitForBlock(h + 1)
+		if err != nil {
+			break loop
+		}
+		select {
+		case err = <-ech:
+			break loop
+		case <-bch:
+		}
+
+		tn := TraceNotification{Height: common.HexInt64{Value: h}}
+		rch := make(chan error, 1)
+		go func() {
+			var err error
+			tn.Result, err = v3.TraceBlock(wss.chain, h, tr.Mode, cancel, false)
+			rch <- err
+		}()
+		select {
+		case err = <-ech:
+			close(cancel)
+			<-rch
+			break loop
+		case err = <-rch:
+		}
+		if err != nil {
+			if je, ok := err.(*jsonrpc.Error); ok {
+				tn.Error = &WSResponse{Code: int(je.Code), Message: je.Message}
+			} else {
+				tn.Error = &WSResponse{Code: int(jsonrpc.ErrorCodeSystem), Message: err.Error()}
+			}
+		}
+		if err = wss.WriteJSON(&tn); err != nil {
+			wm.logger.Infof("fail to write json TraceNotification err:%+v\n", err)
+			break loop
+		}
+		if tn.Error != nil {
+			break loop
+		}
+		if err = pr.onBlock(wss, h); err != nil {
+			wm.logger.Infof("fail to write json ProgressNotification err:%+v\n", err)
+			break loop
+		}
+		h++
+	}
+	if err != nil {
+		wm.logger.Warnf("%+v\n", err)
+	}
+	return nil
+}
+
+func (r *TraceRequest) validate() error {
+	switch r.Mode {
+	case "", "invoke", "callTree", "stateDiff", "stepProfile":
+	default:
+		return fmt.Errorf("invalid mode:%s", r.Mode)
+	}
+	if r.End != nil && r.End.Value < r.Start.Value {
+		return fmt.Errorf("invalid range start:%d end:%d",
+			r.Start.Value, r.End.Value)
+	}
+	return nil
+}
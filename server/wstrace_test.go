@@ -0,0 +1,221 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+func (b *testBlock) NextValidators() module.ValidatorList {
+	return nil
+}
+
+func (b *testBlock) PatchTransactions() module.TransactionList {
+	return nil
+}
+
+func (bm *testBlockManager) NewConsensusInfo(blk module.Block) (module.ConsensusInfo, error) {
+	return nil, nil
+}
+
+// testTransition sends a log for each transaction of the block to the
+// callback.
+type testTransition struct {
+	module.Transition
+	sm  *testServiceManager
+	blk module.Block
+}
+
+func (tr *testTransition) ExecuteForTrace(ti module.TraceInfo) (func() bool, error) {
+	height := tr.blk.Height()
+	if height == tr.sm.traceFailAt {
+		return nil, errors.InvalidStateError.Errorf("FailToTrace(height=%d)", height)
+	}
+	cancelled := make(chan struct{})
+	go func() {
+		if height == tr.sm.traceHangAt {
+			<-cancelled
+			ti.Callback.OnEnd(errors.InterruptedError.New("Cancelled"))
+			return
+		}
+		txs := tr.blk.NormalTransactions()
+		for i := 0; ; i++ {
+			tx, err := txs.Get(i)
+			if err != nil {
+				break
+			}
+			ti.Callback.OnLog(module.TSystemLevel, string(tx.ID()))
+		}
+		ti.Callback.OnEnd(nil)
+	}()
+	var once sync.Once
+	return func() bool {
+		once.Do(func() {
+			close(cancelled)
+			tr.sm.cancelled <- height
+		})
+		return true
+	}, nil
+}
+
+func (sm *testServiceManager) CreateInitialTransition(result []byte, nextValidators module.ValidatorList) (module.Transition, error) {
+	return &testTransition{sm: sm}, nil
+}
+
+func (sm *testServiceManager) CreateTransition(parent module.Transition, txs module.TransactionList, bi module.BlockInfo, csi module.ConsensusInfo, validated bool) (module.Transition, error) {
+	return &testTransition{sm: sm, blk: bi.(module.Block)}, nil
+}
+
+func (sm *testServiceManager) PatchTransition(transition module.Transition, patches module.TransactionList, bi module.BlockInfo) module.Transition {
+	return transition
+}
+
+// testTraceNotification is TraceNotification or ProgressNotification.
+type testTraceNotification struct {
+	Height common.HexInt64 `json:"height"`
+	Result *struct {
+		Logs []struct {
+			Msg string `json:"msg"`
+		} `json:"logs"`
+	} `json:"result"`
+	Error    *WSResponse      `json:"error"`
+	Progress *common.HexInt64 `json:"progress"`
+}
+
+// readTraceNotifications reads n notifications, and returns them in the
+// form of "height:logs", "height:error:code" or "progress:height".
+func readTraceNotifications(t *testing.T, conn *websocket.Conn, n int) []string {
+	var values []string
+	for i := 0; i < n; i++ {
+		var tn testTraceNotification
+		_ = conn.SetReadDeadline(time.Now().Add(testWSReadTimeout))
+		if !assert.NoError(t, conn.ReadJSON(&tn)) {
+			break
+		}
+		if tn.Progress != nil {
+			values = append(values, fmt.Sprintf("progress:%d", tn.Progress.Value))
+			continue
+		}
+		if tn.Error != nil {
+			values = append(values, fmt.Sprintf("%d:error:%d", tn.Height.Value, tn.Error.Code))
+			continue
+		}
+		var logs []string
+		if assert.NotNil(t, tn.Result) {
+			for _, l := range tn.Result.Logs {
+				logs = append(logs, l.Msg)
+			}
+		}
+		values = append(values, fmt.Sprintf("%d:%s", tn.Height.Value, strings.Join(logs, ",")))
+	}
+	return values
+}
+
+// assertClosed asserts that the session is closed by the server.
+func assertClosed(t *testing.T, conn *websocket.Conn) {
+	_ = conn.SetReadDeadline(time.Now().Add(testWSReadTimeout))
+	_, _, err := conn.ReadMessage()
+	if assert.Error(t, err) {
+		ne, ok := err.(net.Error)
+		assert.False(t, ok && ne.Timeout(), "session isn't closed")
+	}
+}
+
+func TestTraceSession_Range(t *testing.T) {
+	c := newLogsTestChain(t)
+	conn, res, stop := startSession(t, c, (*wsSessionManager).RunTraceSession,
+		`{"start":"0x1","end":"0x3"}`)
+	defer stop()
+
+	assert.Equal(t, 0, res.Code, res.Message)
+	assert.Equal(t, []string{
+		"1:tx1.0",
+		"2:tx2.0,tx2.1",
+		"3:tx3.0",
+	}, readTraceNotifications(t, conn, 3))
+	assertClosed(t, conn)
+}
+
+func TestTraceSession_Follow(t *testing.T) {
+	c := newLogsTestChain(t)
+	conn, res, stop := startSession(t, c, (*wsSessionManager).RunTraceSession,
+		`{"start":"0x3","progressInterval":"0x1"}`)
+	defer stop()
+
+	assert.Equal(t, 0, res.Code, res.Message)
+	assert.Equal(t, []string{
+		"3:tx3.0", "progress:3",
+		"4:", "progress:4",
+	}, readTraceNotifications(t, conn, 4))
+
+	// the block 5 is traced when the block 6 is added
+	c.addBlock()
+	assert.Equal(t, []string{"5:", "progress:5"}, readTraceNotifications(t, conn, 2))
+}
+
+func TestTraceSession_Failure(t *testing.T) {
+	c := newLogsTestChain(t)
+	c.sm.traceFailAt = 2
+	conn, res, stop := startSession(t, c, (*wsSessionManager).RunTraceSession,
+		`{"start":"0x1"}`)
+	defer stop()
+
+	assert.Equal(t, 0, res.Code, res.Message)
+	assert.Equal(t, []string{
+		"1:tx1.0",
+		fmt.Sprintf("2:error:%d", jsonrpc.ErrorCodeSystem),
+	}, readTraceNotifications(t, conn, 2))
+	assertClosed(t, conn)
+}
+
+func TestTraceSession_Close(t *testing.T) {
+	c := newLogsTestChain(t)
+	c.sm.traceHangAt = 2
+	conn, res, stop := startSession(t, c, (*wsSessionManager).RunTraceSession,
+		`{"start":"0x1"}`)
+	defer stop()
+
+	assert.Equal(t, 0, res.Code, res.Message)
+	assert.Equal(t, []string{"1:tx1.0"}, readTraceNotifications(t, conn, 1))
+	conn.Close()
+	select {
+	case height := <-c.sm.cancelled:
+		assert.Equal(t, int64(2), height)
+	case <-time.After(testWSReadTimeout):
+		t.Error("tracing isn't cancelled on close")
+	}
+}
+
+func TestTraceSession_InvalidRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  string
+		code jsonrpc.ErrorCode
+	}{
+		{"InvalidMode", `{"start":"0x1","mode":"balanceChange"}`, jsonrpc.ErrorCodeInvalidParams},
+		{"InvalidRange", `{"start":"0x3","end":"0x2"}`, jsonrpc.ErrorCodeInvalidParams},
+		{"BeforeGenesis", `{"start":"0x0"}`, jsonrpc.ErrorCodeInvalidParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newLogsTestChain(t)
+			c.gs.height = 1
+			conn, res, stop := startSession(t, c, (*wsSessionManager).RunTraceSession, tt.req)
+			defer stop()
+
+			assert.Equal(t, int(tt.code), res.Code, res.Message)
+			assertClosed(t, conn)
+		})
+	}
+}
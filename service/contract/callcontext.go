@@ -148,18 +148,32 @@ func (cc *callContext) pushFrame(handler ContractHandler, limit *big.Int) *callF
 		frame.snapshot = cc.GetSnapshot()
 	}
 	logger.OnFrameEnter(cc.frame.fid)
+	if logger.IsCallTraced() {
+		logger.OnCallStart(traceCallOf(handler))
+	}
 	frame.fid = cc.nextFID
 	cc.nextFID += 1
 	cc.frame = frame
 	return frame
 }
 
-func (cc *callContext) popFrame(success bool) *callFrame {
+// traceCallOf returns the information of the call handled by the handler
+// for TraceModeCallTree and TraceModeStepProfile.
+func traceCallOf(handler ContractHandler) *module.TraceCall {
+	if tc, ok := handler.(interface{ TraceCall() *module.TraceCall }); ok {
+		return tc.TraceCall()
+	}
+	return &module.TraceCall{}
+}
+
+func (cc *callContext) popFrame(status error) *callFrame {
 	cc.lock.Lock()
 	defer cc.lock.Unlock()
 
 	frame := cc.frame
+	success := status == nil
 	cc.frame.log.OnFrameExit(success, &frame.stepUsed)
+	cc.frame.log.OnCallEnd(status, &frame.stepUsed)
 	if !frame.isQuery {
 		if success {
 			frame.parent.applyFrameLogsOf(frame)
@@ -214,6 +228,7 @@ func (cc *callContext) addLogToFrame(addr module.Address, indexed [][]byte, data
 		addr, indexed[0],
 		common.SliceOfHexBytes(indexed[1:]),
 		common.SliceOfHexBytes(data))
+	cc.frame.log.OnEvent(addr, indexed, data)
 	cc.frame.addLog(addr, indexed, data)
 	return nil
 }
@@ -356,6 +371,7 @@ func (cc *callContext) cleanUpFrames(target *callFrame, err error) {
 	for cc.frame != nil && cc.frame.handler != nil {
 		frame := cc.frame
 		cc.frame = frame.parent
+		frame.log.OnCallEnd(err, &frame.stepUsed)
 		if ach, ok := frame.handler.(AsyncContractHandler); ok {
 			achs = append(achs, ach)
 		}
@@ -385,7 +401,7 @@ func (cc *callContext) handleResult(target *callFrame, status error, result *cod
 		return false
 	}
 
-	current := cc.popFrame(status == nil)
+	current := cc.popFrame(status)
 	if current == nil {
 		return false
 	}
@@ -517,6 +533,7 @@ func (cc *callContext) applyStepsInLock(t state.StepType, n int) bool {
 	steps := big.NewInt(cc.StepsFor(t, n))
 	ok := cc.frame.deductSteps(steps)
 	cc.frame.log.TSystemf("STEP apply type=%s count=%d cost=%s total=%s", t, n, steps, &cc.frame.stepUsed)
+	cc.frame.log.OnSteps(string(t), n, steps)
 	return ok
 }
 
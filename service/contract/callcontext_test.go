@@ -276,6 +276,10 @@ func (h *asyncHandler) OnSetFeeProportion(portion int) {
 	panic("implement me")
 }
 
+func (h *asyncHandler) OnSteps(stepType string, size int, steps *big.Int) {
+	panic("implement me")
+}
+
 func (h *asyncHandler) SetCode(code []byte) error {
 	panic("implement me")
 }
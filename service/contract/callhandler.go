@@ -139,6 +139,18 @@ func (h *CallHandler) prepareContractStore(ctx Context, wc state.WorldContext, c
 	return nil
 }
 
+func (h *CallHandler) TraceCall() *module.TraceCall {
+	call := h.CommonHandler.TraceCall()
+	call.Type = "call"
+	call.Method = h.name
+	if h.paramObj != nil {
+		call.Params, _ = common.DecodeAnyForJSON(h.paramObj)
+	} else if len(h.params) > 0 {
+		call.Params = json.RawMessage(h.params)
+	}
+	return call
+}
+
 func (h *CallHandler) Prepare(ctx Context) (state.WorldContext, error) {
 	wc, as := h.prepareWorldContextAndAccount(ctx)
 
@@ -559,6 +571,12 @@ func (h *CallHandler) OnResult(status error, flag int, steps *big.Int, result *c
 	h.cc.OnResult(status, ResultFlag(flag), steps, result, nil)
 }
 
+// OnSteps is called by the execution environment for the steps charged
+// by itself in step profile mode.
+func (h *CallHandler) OnSteps(stepType string, size int, steps *big.Int) {
+	h.Log.OnSteps(stepType, size, steps)
+}
+
 func (h *CallHandler) OnCall(from, to module.Address, value,
 	limit *big.Int, dataType string, dataObj *codec.TypedObj,
 ) {
@@ -639,6 +657,14 @@ type TransferAndCallHandler struct {
 	*CallHandler
 }
 
+func (h *TransferAndCallHandler) TraceCall() *module.TraceCall {
+	if h.To.IsContract() {
+		return h.CallHandler.TraceCall()
+	} else {
+		return h.th.TraceCall()
+	}
+}
+
 func (h *TransferAndCallHandler) Prepare(ctx Context) (state.WorldContext, error) {
 	if h.To.IsContract() {
 		return h.CallHandler.Prepare(ctx)
@@ -95,3 +95,8 @@ func (h *CommonHandler) TraceLogger() *trace.Logger {
 func (h *CommonHandler) Logger() log.Logger {
 	return h.Log
 }
+
+// TraceCall returns the information of the call for TraceModeCallTree.
+func (h *CommonHandler) TraceCall() *module.TraceCall {
+	return &module.TraceCall{From: h.From, To: h.To, Value: h.Value}
+}
@@ -223,6 +223,15 @@ func genContractAddr(from module.Address, timestamp int64, nonce, salt *big.Int)
 	return addr
 }
 
+func (h *DeployHandler) TraceCall() *module.TraceCall {
+	call := h.CommonHandler.TraceCall()
+	call.Type = "deploy"
+	if len(h.params) > 0 {
+		call.Params = json.RawMessage(h.params)
+	}
+	return call
+}
+
 func (h *DeployHandler) Prepare(ctx Context) (state.WorldContext, error) {
 	lq := []state.LockRequest{
 		{state.WorldIDStr, state.AccountWriteLock},
@@ -648,6 +657,10 @@ func (h *callGetAPIHandler) OnSetFeeProportion(portion int) {
 	h.Log.Errorf("Unexpected call OnSetFeeProportion() from GetAPI()")
 }
 
+func (h *callGetAPIHandler) OnSteps(stepType string, size int, steps *big.Int) {
+	h.Log.Errorf("Unexpected call OnSteps() from GetAPI()")
+}
+
 func (h *callGetAPIHandler) SetCode(code []byte) error {
 	h.Log.Errorf("Unexpected call SetCode() from GetAPI()")
 	return nil
@@ -20,6 +20,15 @@ type patchHandler struct {
 	patch *Patch
 }
 
+func (h *patchHandler) TraceCall() *module.TraceCall {
+	call := h.CommonHandler.TraceCall()
+	call.Type = "patch"
+	if h.patch != nil {
+		call.Method = h.patch.Type
+	}
+	return call
+}
+
 func (h *patchHandler) Prepare(ctx Context) (state.WorldContext, error) {
 	lq := []state.LockRequest{
 		{state.WorldIDStr, state.AccountWriteLock},
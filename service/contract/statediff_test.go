@@ -0,0 +1,170 @@
+package contract
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/eeproxy"
+	"github.com/icon-project/goloop/service/scoreapi"
+	"github.com/icon-project/goloop/service/state"
+)
+
+const diffTestScoreID = "diff_test"
+
+var (
+	diffTestOwner = common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+	diffTestUser  = common.MustNewAddressFromString("hx0000000000000000000000000000000000000002")
+	diffTestScore = common.MustNewAddressFromString("cx0000000000000000000000000000000000000003")
+)
+
+// diffTestScoreImpl is a system score installed at diffTestScore, which
+// writes its storage on calls.
+type diffTestScoreImpl struct {
+	cc CallContext
+}
+
+func (s *diffTestScoreImpl) Install(param []byte) error {
+	return nil
+}
+
+func (s *diffTestScoreImpl) Update(param []byte) error {
+	return nil
+}
+
+func (s *diffTestScoreImpl) GetAPI() *scoreapi.Info {
+	return scoreapi.NewInfo([]*scoreapi.Method{
+		{scoreapi.Function, "setValue",
+			scoreapi.FlagExternal | scoreapi.FlagPayable, 0,
+			[]scoreapi.Parameter{
+				{"key", scoreapi.String, nil, nil},
+				{"value", scoreapi.Integer, nil, nil},
+			},
+			nil,
+		},
+		{scoreapi.Function, "deleteValue",
+			scoreapi.FlagExternal, 0,
+			[]scoreapi.Parameter{
+				{"key", scoreapi.String, nil, nil},
+			},
+			nil,
+		},
+	})
+}
+
+func (s *diffTestScoreImpl) Ex_setValue(key string, value *common.HexInt) error {
+	as := s.cc.GetAccountState(diffTestScore.ID())
+	_, err := as.SetValue([]byte(key), value.Bytes())
+	return err
+}
+
+func (s *diffTestScoreImpl) Ex_deleteValue(key string) error {
+	as := s.cc.GetAccountState(diffTestScore.ID())
+	_, err := as.DeleteValue([]byte(key))
+	return err
+}
+
+func newDiffTestScore(cid string, cc CallContext, from module.Address, value *big.Int) (SystemScore, error) {
+	return &diffTestScoreImpl{cc: cc}, nil
+}
+
+func init() {
+	RegisterSystemScore(diffTestScoreID, &SystemScoreModule{New: newDiffTestScore})
+}
+
+func TestStateRecorder_ContractCall(t *testing.T) {
+	dir, err := ioutil.TempDir("", "statediff")
+	if err != nil {
+		t.Fatalf("Fail to make directory err=%+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	dbase := db.NewMapDB()
+	cm, err := NewContractManager(dbase, dir, log.New())
+	if err != nil {
+		t.Fatalf("Fail to make contract manager err=%+v", err)
+	}
+	sr := state.NewStateRecorder()
+	ws := sr.WorldState(state.NewWorldState(dbase, nil, nil, nil, nil))
+	wc := state.NewWorldContext(ws, common.NewBlockInfo(1, 0), nil, dummyPlatformType{})
+	newCC := func() CallContext {
+		return NewCallContext(
+			NewContext(wc, cm, nil, newDummyChain(), log.New(), nil, eeproxy.ForTransaction),
+			big.NewInt(1000000),
+			false,
+		)
+	}
+
+	if err := DeployAndInstallSystemSCORE(newCC(), diffTestScoreID,
+		diffTestOwner, diffTestScore, nil, []byte("tx0")); err != nil {
+		t.Fatalf("Fail to install score err=%+v", err)
+	}
+	ws.GetAccountState(diffTestUser.ID()).SetBalance(big.NewInt(100))
+
+	call := func(value int64, data string) error {
+		handler, err := cm.GetHandler(diffTestUser, diffTestScore,
+			big.NewInt(value), CTypeCall, []byte(data))
+		if err != nil {
+			return err
+		}
+		status, _, _, _ := newCC().Call(handler, big.NewInt(1000000))
+		return status
+	}
+
+	// set values with transfer
+	ss1 := ws.GetSnapshot()
+	sr.Reset()
+	assert.NoError(t, call(30, `{"method":"setValue","params":{"key":"k1","value":"0x1"}}`))
+	assert.NoError(t, call(0, `{"method":"setValue","params":{"key":"k2","value":"0x2"}}`))
+	ss2 := ws.GetSnapshot()
+
+	diffs := sr.Diff(ss1, ss2)
+	if assert.Len(t, diffs, 2) {
+		assert.True(t, diffs[0].Address.Equal(diffTestUser))
+		assert.Equal(t, int64(100), diffs[0].Before.Balance.Int64())
+		assert.Equal(t, int64(70), diffs[0].After.Balance.Int64())
+		assert.Nil(t, diffs[0].Storage)
+
+		assert.True(t, diffs[1].Address.Equal(diffTestScore))
+		assert.True(t, diffs[1].After.IsContract)
+		assert.Equal(t, int64(0), diffs[1].Before.Balance.Int64())
+		assert.Equal(t, int64(30), diffs[1].After.Balance.Int64())
+		if assert.Len(t, diffs[1].Storage, 2) {
+			assert.Equal(t, []byte("k1"), diffs[1].Storage[0].Key)
+			assert.Nil(t, diffs[1].Storage[0].Before)
+			assert.Equal(t, []byte{1}, diffs[1].Storage[0].After)
+			assert.Equal(t, []byte("k2"), diffs[1].Storage[1].Key)
+			assert.Equal(t, []byte{2}, diffs[1].Storage[1].After)
+		}
+	}
+
+	// overwrite and delete, then a failed call
+	sr.Reset()
+	assert.NoError(t, call(0, `{"method":"setValue","params":{"key":"k1","value":"0x3"}}`))
+	assert.NoError(t, call(0, `{"method":"deleteValue","params":{"key":"k2"}}`))
+	assert.Error(t, call(0, `{"method":"unknown","params":{}}`))
+	ss3 := ws.GetSnapshot()
+
+	diffs = sr.Diff(ss2, ss3)
+	if assert.Len(t, diffs, 1) {
+		assert.True(t, diffs[0].Address.Equal(diffTestScore))
+		if assert.Len(t, diffs[0].Storage, 2) {
+			assert.Equal(t, []byte{1}, diffs[0].Storage[0].Before)
+			assert.Equal(t, []byte{3}, diffs[0].Storage[0].After)
+			assert.Equal(t, []byte{2}, diffs[0].Storage[1].Before)
+			assert.Nil(t, diffs[0].Storage[1].After)
+		}
+	}
+
+	// a reverted call leaves no change
+	sr.Reset()
+	assert.Error(t, call(1000, `{"method":"setValue","params":{"key":"k3","value":"0x4"}}`))
+	assert.Empty(t, sr.Diff(ss3, ws.GetSnapshot()))
+}
@@ -75,6 +75,25 @@ func (ba *bucketAdaptor) Delete(key []byte) error {
 	panic("Now allowed")
 }
 
+func (ba *bucketAdaptor) NewIterator(r *db.Range, reverse bool) (db.Iterator, error) {
+	iter, err := ba.bucket.NewIterator(r, reverse)
+	if err != nil {
+		return nil, err
+	}
+	return &iteratorAdaptor{iter, ba.database}, nil
+}
+
+type iteratorAdaptor struct {
+	db.Iterator
+	database *databaseAdaptor
+}
+
+func (ia *iteratorAdaptor) Value() []byte {
+	value := ia.Iterator.Value()
+	ia.database.OnRead(len(value))
+	return value
+}
+
 func newBucketAdaptor(da *databaseAdaptor, bk db.Bucket) *bucketAdaptor {
 	return &bucketAdaptor{
 		database: da,
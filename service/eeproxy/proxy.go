@@ -37,6 +37,7 @@ const (
 	msgSETOBJGRAPH = 14
 	msgSETFEEPCT   = 15
 	msgCONTAINS    = 16
+	msgSTEPS       = 17
 )
 
 type proxyState int
@@ -71,6 +72,7 @@ type CallContext interface {
 	OnCall(from, to module.Address, value, limit *big.Int, dataType string, dataObj *codec.TypedObj)
 	OnAPI(status error, info *scoreapi.Info)
 	OnSetFeeProportion(portion int)
+	OnSteps(stepType string, size int, steps *big.Int)
 	SetCode(code []byte) error
 	GetObjGraph(bool) (int, []byte, []byte, error)
 	SetObjGraph(flags bool, nextHash int, objGraph []byte) error
@@ -130,6 +132,7 @@ type invokeFlag int
 const (
 	InvokeFlagReadOnly invokeFlag = 1 << iota
 	InvokeFlagTrace
+	InvokeFlagStepProfile
 )
 
 type invokeMessage struct {
@@ -186,6 +189,12 @@ type getAPIMessage struct {
 	Info   *scoreapi.Info
 }
 
+type stepsMessage struct {
+	Type  string
+	Size  int
+	Steps common.HexInt
+}
+
 type logFlag int
 
 const (
@@ -246,8 +255,11 @@ func (p *proxy) Invoke(
 	if isQuery {
 		m.Flag |= InvokeFlagReadOnly
 	}
-	if logger.TraceMode() == module.TraceModeInvoke {
+	switch logger.TraceMode() {
+	case module.TraceModeInvoke:
 		m.Flag |= InvokeFlagTrace
+	case module.TraceModeStepProfile:
+		m.Flag |= InvokeFlagStepProfile
 	}
 	m.From = common.AddressToPtr(from)
 	m.To.Set(to)
@@ -604,6 +616,16 @@ func (p *proxy) HandleMessage(c ipc.Connection, msg uint, data []byte) error {
 			p, m.Prefix, m.Value, m.Limit, yn, cnt, sz)
 		return p.conn.Send(msgCONTAINS, &res)
 
+	case msgSTEPS:
+		var m stepsMessage
+		if _, err := codec.MP.UnmarshalFromBytes(data, &m); err != nil {
+			return err
+		}
+		p.log.Tracef("Proxy[%p].OnSteps type=%s size=%d steps=%v",
+			p, m.Type, m.Size, &m.Steps.Int)
+		p.frame.ctx.OnSteps(m.Type, m.Size, &m.Steps.Int)
+		return nil
+
 	default:
 		p.log.Warnf("Proxy[%p].HandleMessage(msg=%d) UnknownMessage", msg)
 		return errors.ErrIllegalArgument
@@ -15,6 +15,9 @@ const (
 	NotContractAddressError
 	InvalidPatchDataError
 	CommittedTransactionError
+	ReplacedTransactionError
+	CanceledTransactionError
+	SenderLimitExceededError
 )
 
 var (
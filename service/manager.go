@@ -33,6 +33,10 @@ import (
 const ConfigTransitionResultCacheEntryCount = 10
 const ConfigTransitionResultCacheEntrySize = 1024 * 1024
 
+// ConfigCancelTimestampThreshold is the maximum difference between the
+// timestamp of the cancel request and the current time.
+const ConfigCancelTimestampThreshold = 5 * time.Minute
+
 type manager struct {
 	// tx pool should be connected to transition for more than one branches.
 	// Currently, it doesn't allow another branch, so add tx pool here.
@@ -81,6 +85,7 @@ func NewManager(chain module.Chain, nm module.NetworkManager,
 	}
 	pTxPool := NewTransactionPool(module.TransactionGroupPatch, chain.PatchTxPoolSize(), tim, pMetric, logger)
 	nTxPool := NewTransactionPool(module.TransactionGroupNormal, chain.NormalTxPoolSize(), tim, nMetric, logger)
+	nTxPool.SetSenderLimit(chain.MaxSenderTxs(), chain.MaxSenderTxBytes())
 	tm := NewTransactionManager(chain.NID(), tsc, pTxPool, nTxPool, tim, logger)
 	syncm := ssync.NewSyncManager(chain.Database(), chain.NetworkManager(), plt, logger)
 
@@ -665,6 +670,86 @@ func (m *manager) GetSCOREStatus(result []byte, addr module.Address) (module.SCO
 	}, nil
 }
 
+type storageProof struct {
+	key   []byte
+	value []byte
+	proof [][]byte
+}
+
+type accountProof struct {
+	addr      module.Address
+	stateHash []byte
+	account   []byte
+	proof     [][]byte
+	storage   []byte
+	values    []storageProof
+}
+
+func proofToJSON(proof [][]byte) []interface{} {
+	ret := make([]interface{}, len(proof))
+	for i, p := range proof {
+		ret[i] = common.HexBytes(p)
+	}
+	return ret
+}
+
+func (p *accountProof) ToJSON(version module.JSONVersion) (interface{}, error) {
+	ret := make(map[string]interface{})
+	ret["address"] = p.addr
+	ret["stateHash"] = common.HexBytes(p.stateHash)
+	ret["account"] = common.HexBytes(p.account)
+	ret["proof"] = proofToJSON(p.proof)
+	if len(p.values) > 0 {
+		ret["storageHash"] = common.HexBytes(p.storage)
+		values := make([]interface{}, len(p.values))
+		for i, v := range p.values {
+			values[i] = map[string]interface{}{
+				"key":   common.HexBytes(v.key),
+				"value": common.HexBytes(v.value),
+				"proof": proofToJSON(v.proof),
+			}
+		}
+		ret["storageProofs"] = values
+	}
+	return ret, nil
+}
+
+func (m *manager) GetAccountProof(result []byte, addr module.Address, keys [][]byte) (module.AccountProof, error) {
+	if len(keys) > 0 && !addr.IsContract() {
+		return nil, errors.IllegalArgumentError.Errorf("Given Address(%s) isn't contract", addr)
+	}
+	wss, err := m.trc.GetWorldSnapshot(result, nil)
+	if err != nil {
+		return nil, err
+	}
+	p := &accountProof{
+		addr:      addr,
+		stateHash: wss.StateHash(),
+		proof:     wss.GetAccountProof(addr.ID()),
+	}
+	ass := wss.GetAccountSnapshot(addr.ID())
+	if ass != nil {
+		if ass.IsContract() != addr.IsContract() {
+			return nil, errors.IllegalArgumentError.Errorf(
+				"InvalidAddressPrefix(valid=%s)",
+				common.NewAddressWithTypeAndID(!addr.IsContract(), addr.ID()))
+		}
+		p.account = ass.Bytes()
+		p.storage = ass.StorageHash()
+	}
+	for _, k := range keys {
+		sp := storageProof{key: k}
+		if ass != nil {
+			if sp.value, err = ass.GetValue(k); err != nil {
+				return nil, err
+			}
+			sp.proof = ass.GetValueProof(k)
+		}
+		p.values = append(p.values, sp)
+	}
+	return p, nil
+}
+
 func (m *manager) GetMembers(result []byte) (module.MemberList, error) {
 	wss, err := m.trc.GetWorldSnapshot(result, nil)
 	if err != nil {
@@ -801,6 +886,58 @@ func (m *manager) HasTransaction(id []byte) bool {
 	return m.tm.HasTx(id)
 }
 
+func newCancelRequest(reqi interface{}) (*transaction.CancelRequest, error) {
+	var js []byte
+	switch reqo := reqi.(type) {
+	case []byte:
+		js = reqo
+	case string:
+		js = []byte(reqo)
+	default:
+		return nil, errors.IllegalArgumentError.Errorf("UnknownType(%T)", reqi)
+	}
+	req, err := transaction.NewCancelRequestFromJSON(js)
+	if err != nil {
+		return nil, errors.WithCode(err, errors.IllegalArgumentError)
+	}
+	return req, nil
+}
+
+func (m *manager) CancelTransaction(reqi interface{}) ([]byte, error) {
+	req, err := newCancelRequest(reqi)
+	if err != nil {
+		return nil, err
+	}
+	if !req.ValidateNetwork(m.chain.NID()) {
+		return nil, errors.InvalidNetworkError.Errorf(
+			"ValidateNetwork(nid=%#x) fail", m.chain.NID())
+	}
+	diff := time.Duration(time.Now().UnixNano()/1000-req.Timestamp()) * time.Microsecond
+	if diff < -ConfigCancelTimestampThreshold || diff > ConfigCancelTimestampThreshold {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"InvalidTimestamp(diff=%s)", diff)
+	}
+	if err := req.Verify(); err != nil {
+		return nil, err
+	}
+	if err := m.tm.Cancel(req.TxHash(), req.From()); err != nil {
+		return nil, err
+	}
+	return req.TxHash(), nil
+}
+
+func (m *manager) GetTransactionPoolStatus() []module.TransactionPoolStatus {
+	return m.tm.PoolStatus()
+}
+
+func (m *manager) GetPendingTransactions(from module.Address) []*module.PendingTransaction {
+	return m.tm.PendingTransactions(from)
+}
+
+func (m *manager) GetPendingTransaction(id []byte) *module.PendingTransaction {
+	return m.tm.PendingTransaction(id)
+}
+
 func (m *manager) WaitForTransaction(
 	parent module.Transition,
 	bi module.BlockInfo,
@@ -840,6 +977,22 @@ func (m *manager) ImportResult(result []byte, vh []byte, src db.Database) error
 }
 
 func (m *manager) ExecuteTransaction(result []byte, vh []byte, js []byte, bi module.BlockInfo) (module.Receipt, error) {
+	return m.executeTransaction(result, vh, js, bi, nil)
+}
+
+func (m *manager) TraceTransaction(result []byte, vh []byte, js []byte, bi module.BlockInfo, ti module.TraceInfo) (module.Receipt, error) {
+	if ti.Callback == nil {
+		return nil, errors.IllegalArgumentError.New("TraceCallbackIsNil")
+	}
+	ti.Range = module.TraceRangeTransaction
+	ti.Group = module.TransactionGroupNormal
+	ti.Index = 0
+	rct, err := m.executeTransaction(result, vh, js, bi, &ti)
+	ti.Callback.OnEnd(err)
+	return rct, err
+}
+
+func (m *manager) executeTransaction(result []byte, vh []byte, js []byte, bi module.BlockInfo, ti *module.TraceInfo) (module.Receipt, error) {
 	tx, err := transaction.NewTransactionFromJSON(js)
 	if err != nil {
 		return nil, err
@@ -865,7 +1018,7 @@ func (m *manager) ExecuteTransaction(result []byte, vh []byte, js []byte, bi mod
 	} else {
 		return nil, err
 	}
-	ctx := contract.NewContext(wc, m.cm, m.eem, m.chain, m.log, nil, eeproxy.ForQuery)
+	ctx := contract.NewContext(wc, m.cm, m.eem, m.chain, m.log, ti, eeproxy.ForQuery)
 	ctx.SetTransactionInfo(&state.TransactionInfo{
 		Group:     module.TransactionGroupNormal,
 		Index:     0,
@@ -876,7 +1029,17 @@ func (m *manager) ExecuteTransaction(result []byte, vh []byte, js []byte, bi mod
 	})
 	ctx.UpdateSystemInfo()
 
-	return txh.Execute(ctx, wss, true)
+	if ti == nil {
+		return txh.Execute(ctx, wss, true)
+	}
+	tlog := ctx.GetTraceLogger(module.EPhaseTransaction)
+	tlog.OnTransactionStart(0, tx.ID())
+	rct, err := txh.Execute(ctx, wss, true)
+	if err != nil {
+		return nil, err
+	}
+	tlog.OnTransactionEnd(0, tx.ID(), tx.From(), ctx.Treasury(), ctx.Revision(), rct)
+	return rct, nil
 }
 
 func (m *manager) AddSyncRequest(id db.BucketID, key []byte) error {
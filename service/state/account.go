@@ -46,6 +46,8 @@ type AccountSnapshot interface {
 	IsContract() bool
 	IsEmpty() bool
 	GetValue(k []byte) ([]byte, error)
+	GetValueProof(k []byte) [][]byte
+	StorageHash() []byte
 	StorageChangedAfter(snapshot AccountSnapshot) bool
 
 	IsContractOwner(owner module.Address) bool
@@ -501,6 +503,24 @@ func (s *accountSnapshotImpl) NextContract() ContractSnapshot {
 	return s.nextContract
 }
 
+// GetValueProof returns merkle proof of the value in the storage.
+// It returns nil if there is no value for the key.
+func (s *accountSnapshotImpl) GetValueProof(k []byte) [][]byte {
+	if store := s.Store(); store != nil {
+		return store.GetProof(k)
+	}
+	return nil
+}
+
+// StorageHash returns root hash of the storage. It returns nil if the
+// storage is empty.
+func (s *accountSnapshotImpl) StorageHash() []byte {
+	if store := s.Store(); store != nil {
+		return store.Hash()
+	}
+	return nil
+}
+
 func (s *accountSnapshotImpl) Store() trie.Immutable {
 	store, _ := s.store.(trie.Immutable)
 	return store
@@ -0,0 +1,248 @@
+package state
+
+import (
+	"bytes"
+	"math/big"
+	"sync"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/module"
+)
+
+type touchedAccount struct {
+	id     []byte
+	listed bool
+	keys   map[string]bool
+	// order of keys for stable output
+	order [][]byte
+}
+
+func (a *touchedAccount) addKey(k []byte) {
+	if a.keys[string(k)] {
+		return
+	}
+	a.keys[string(k)] = true
+	a.order = append(a.order, append([]byte{}, k...))
+}
+
+func (a *touchedAccount) clear() {
+	a.listed = false
+	a.keys = make(map[string]bool)
+	a.order = nil
+}
+
+// StateRecorder records accounts and storage keys modified through
+// the WorldState returned by WorldState(). Recorded accounts are compared
+// between two snapshots by Diff().
+type StateRecorder struct {
+	lock     sync.Mutex
+	accounts map[string]*touchedAccount
+	order    []*touchedAccount
+}
+
+func (r *StateRecorder) listInLock(a *touchedAccount) {
+	if !a.listed {
+		a.listed = true
+		r.order = append(r.order, a)
+	}
+}
+
+func (r *StateRecorder) accountOf(id []byte) *touchedAccount {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	a, ok := r.accounts[string(id)]
+	if !ok {
+		a = &touchedAccount{id: append([]byte{}, id...)}
+		a.clear()
+		r.accounts[string(id)] = a
+	}
+	r.listInLock(a)
+	return a
+}
+
+func (r *StateRecorder) onChange(a *touchedAccount) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.listInLock(a)
+}
+
+func (r *StateRecorder) onWrite(a *touchedAccount, k []byte) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// account state may be kept over Reset()
+	r.listInLock(a)
+	a.addKey(k)
+}
+
+// Reset clears recorded accounts and keys.
+func (r *StateRecorder) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, a := range r.order {
+		a.clear()
+	}
+	r.order = nil
+}
+
+// WorldState returns WorldState recording modifications to the recorder.
+func (r *StateRecorder) WorldState(ws WorldState) WorldState {
+	return &recordingWorldState{
+		WorldState: ws,
+		recorder:   r,
+	}
+}
+
+// Diff returns changes of recorded accounts between two snapshots.
+// Accounts without changes are not included.
+func (r *StateRecorder) Diff(before, after WorldSnapshot) []*module.TraceAccountDiff {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var diffs []*module.TraceAccountDiff
+	for _, a := range r.order {
+		as1 := accountSnapshotOf(before, a.id)
+		as2 := accountSnapshotOf(after, a.id)
+		if diff := diffAccount(a, as1, as2); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
+
+func accountSnapshotOf(wss WorldSnapshot, id []byte) AccountSnapshot {
+	if as := wss.GetAccountSnapshot(id); as != nil {
+		return as
+	}
+	return newAccountSnapshot(wss.Database())
+}
+
+func traceContractStateOf(c ContractSnapshot) *module.TraceContractState {
+	if c == nil {
+		return nil
+	}
+	return &module.TraceContractState{
+		Status:       c.Status().String(),
+		CodeHash:     c.CodeHash(),
+		EEType:       string(c.EEType()),
+		ContentType:  c.ContentType(),
+		DeployTxHash: c.DeployTxHash(),
+		AuditTxHash:  c.AuditTxHash(),
+	}
+}
+
+func traceAccountStateOf(as AccountSnapshot) *module.TraceAccountState {
+	if as.IsEmpty() {
+		return nil
+	}
+	return &module.TraceAccountState{
+		Balance:       as.GetBalance(),
+		IsContract:    as.IsContract(),
+		ContractOwner: as.ContractOwner(),
+		Disabled:      as.IsDisabled(),
+		Blocked:       as.IsBlocked(),
+		Contract:      traceContractStateOf(as.Contract()),
+		NextContract:  traceContractStateOf(as.NextContract()),
+	}
+}
+
+func contractEqual(c1, c2 ContractSnapshot) bool {
+	if c1 == nil || c2 == nil {
+		return c1 == c2
+	}
+	return c1.Equal(c2)
+}
+
+func accountEqual(as1, as2 AccountSnapshot) bool {
+	return as1.GetBalance().Cmp(as2.GetBalance()) == 0 &&
+		as1.IsContract() == as2.IsContract() &&
+		common.AddressEqual(as1.ContractOwner(), as2.ContractOwner()) &&
+		as1.IsDisabled() == as2.IsDisabled() &&
+		as1.IsBlocked() == as2.IsBlocked() &&
+		contractEqual(as1.Contract(), as2.Contract()) &&
+		contractEqual(as1.NextContract(), as2.NextContract())
+}
+
+func addressOfAccount(id []byte, as1, as2 AccountSnapshot) module.Address {
+	if bytes.Equal(id, SystemID) {
+		return SystemAddress
+	}
+	if as1.IsContract() || as2.IsContract() {
+		return common.NewContractAddress(id)
+	}
+	return common.NewAccountAddress(id)
+}
+
+func diffAccount(a *touchedAccount, as1, as2 AccountSnapshot) *module.TraceAccountDiff {
+	var storage []*module.TraceStorageChange
+	if as1.StorageChangedAfter(as2) {
+		for _, k := range a.order {
+			v1, _ := as1.GetValue(k)
+			v2, _ := as2.GetValue(k)
+			if !bytes.Equal(v1, v2) {
+				storage = append(storage, &module.TraceStorageChange{
+					Key:    k,
+					Before: v1,
+					After:  v2,
+				})
+			}
+		}
+	}
+	if len(storage) == 0 && accountEqual(as1, as2) {
+		return nil
+	}
+	return &module.TraceAccountDiff{
+		Address: addressOfAccount(a.id, as1, as2),
+		Before:  traceAccountStateOf(as1),
+		After:   traceAccountStateOf(as2),
+		Storage: storage,
+	}
+}
+
+// NewStateRecorder returns a new StateRecorder.
+func NewStateRecorder() *StateRecorder {
+	return &StateRecorder{
+		accounts: make(map[string]*touchedAccount),
+	}
+}
+
+type recordingWorldState struct {
+	WorldState
+	recorder *StateRecorder
+}
+
+func (ws *recordingWorldState) GetAccountState(id []byte) AccountState {
+	as := ws.WorldState.GetAccountState(id)
+	if as == nil || id == nil {
+		return as
+	}
+	return &recordingAccountState{
+		AccountState: as,
+		recorder:     ws.recorder,
+		account:      ws.recorder.accountOf(id),
+	}
+}
+
+type recordingAccountState struct {
+	AccountState
+	recorder *StateRecorder
+	account  *touchedAccount
+}
+
+func (as *recordingAccountState) SetBalance(v *big.Int) {
+	as.recorder.onChange(as.account)
+	as.AccountState.SetBalance(v)
+}
+
+func (as *recordingAccountState) SetValue(k, v []byte) ([]byte, error) {
+	as.recorder.onWrite(as.account, k)
+	return as.AccountState.SetValue(k, v)
+}
+
+func (as *recordingAccountState) DeleteValue(k []byte) ([]byte, error) {
+	as.recorder.onWrite(as.account, k)
+	return as.AccountState.DeleteValue(k)
+}
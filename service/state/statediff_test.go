@@ -0,0 +1,90 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+)
+
+func TestStateRecorder_Diff(t *testing.T) {
+	database := db.NewMapDB()
+	sr := NewStateRecorder()
+	ws := sr.WorldState(NewWorldState(database, nil, nil, nil, nil))
+
+	eoa := common.MustNewAddressFromString("hx0000000000000000000000000000000000000001")
+	score := common.MustNewAddressFromString("cx0000000000000000000000000000000000000002")
+
+	as := ws.GetAccountState(score.ID())
+	as.InitContractAccount(eoa)
+	_, err := as.SetValue([]byte("k1"), []byte("v1"))
+	assert.NoError(t, err)
+	_, err = as.SetValue([]byte("k2"), []byte("v2"))
+	assert.NoError(t, err)
+	ws.GetAccountState(eoa.ID()).SetBalance(big.NewInt(100))
+	ss1 := ws.GetSnapshot()
+
+	sr.Reset()
+	as = ws.GetAccountState(score.ID())
+	_, err = as.SetValue([]byte("k1"), []byte("v1-new"))
+	assert.NoError(t, err)
+	_, err = as.DeleteValue([]byte("k2"))
+	assert.NoError(t, err)
+	_, err = as.SetValue([]byte("k3"), []byte("v3"))
+	assert.NoError(t, err)
+	// same value, no change
+	_, err = as.SetValue([]byte("k3"), []byte("v3"))
+	assert.NoError(t, err)
+
+	// touched without change
+	ws.GetAccountState(eoa.ID()).SetBalance(big.NewInt(100))
+	ss2 := ws.GetSnapshot()
+
+	diffs := sr.Diff(ss1, ss2)
+	assert.Len(t, diffs, 1)
+
+	diff := diffs[0]
+	assert.True(t, diff.Address.Equal(score))
+	assert.NotNil(t, diff.Before)
+	assert.NotNil(t, diff.After)
+	assert.True(t, diff.After.IsContract)
+	assert.True(t, eoa.Equal(diff.After.ContractOwner))
+	assert.Len(t, diff.Storage, 3)
+	assert.Equal(t, []byte("k1"), diff.Storage[0].Key)
+	assert.Equal(t, []byte("v1"), diff.Storage[0].Before)
+	assert.Equal(t, []byte("v1-new"), diff.Storage[0].After)
+	assert.Equal(t, []byte("k2"), diff.Storage[1].Key)
+	assert.Nil(t, diff.Storage[1].After)
+	assert.Equal(t, []byte("k3"), diff.Storage[2].Key)
+	assert.Nil(t, diff.Storage[2].Before)
+
+	sr.Reset()
+	ws.GetAccountState(eoa.ID()).SetBalance(big.NewInt(50))
+	ss3 := ws.GetSnapshot()
+
+	diffs = sr.Diff(ss2, ss3)
+	assert.Len(t, diffs, 1)
+	assert.True(t, diffs[0].Address.Equal(eoa))
+	assert.Equal(t, int64(100), diffs[0].Before.Balance.Int64())
+	assert.Equal(t, int64(50), diffs[0].After.Balance.Int64())
+	assert.Nil(t, diffs[0].Storage)
+}
+
+func TestStateRecorder_NewAccount(t *testing.T) {
+	database := db.NewMapDB()
+	sr := NewStateRecorder()
+	ws := sr.WorldState(NewWorldState(database, nil, nil, nil, nil))
+	ss1 := ws.GetSnapshot()
+
+	eoa := common.MustNewAddressFromString("hx0000000000000000000000000000000000000003")
+	ws.GetAccountState(eoa.ID()).SetBalance(big.NewInt(10))
+	ss2 := ws.GetSnapshot()
+
+	diffs := sr.Diff(ss1, ss2)
+	assert.Len(t, diffs, 1)
+	assert.Nil(t, diffs[0].Before)
+	assert.Equal(t, int64(10), diffs[0].After.Balance.Int64())
+}
@@ -17,6 +17,7 @@ import (
 // It can be use to WorldState recover state of WorldState to at some point.
 type WorldSnapshot interface {
 	GetAccountSnapshot(id []byte) AccountSnapshot
+	GetAccountProof(id []byte) [][]byte
 	GetValidatorSnapshot() ValidatorSnapshot
 	GetExtensionSnapshot() ExtensionSnapshot
 	GetBTPSnapshot() BTPSnapshot
@@ -123,6 +124,12 @@ func (ws *worldSnapshotImpl) GetAccountSnapshot(id []byte) AccountSnapshot {
 	}
 }
 
+// GetAccountProof returns merkle proof of the account in the world state.
+// It returns nil if the account doesn't exist.
+func (ws *worldSnapshotImpl) GetAccountProof(id []byte) [][]byte {
+	return ws.accounts.GetProof(addressIDToKey(id))
+}
+
 type worldStateImpl struct {
 	mutex sync.Mutex
 
@@ -13,6 +13,7 @@ import (
 
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/trie/trie_manager"
 	"github.com/icon-project/goloop/module"
 )
 
@@ -404,3 +405,42 @@ func BenchmarkWorldStateImpl_ResetN(b *testing.B) {
 		})
 	}
 }
+
+func TestWorldSnapshot_GetAccountProof(t *testing.T) {
+	database := db.NewMapDB()
+	ws := NewWorldState(database, nil, nil, nil, nil)
+
+	ids := [][]byte{[]byte("id1"), []byte("id2"), []byte("id3")}
+	for i, id := range ids {
+		as := ws.GetAccountState(id)
+		as.SetBalance(big.NewInt(int64(i+1) * 100))
+		for j := 0; j < 10; j++ {
+			_, err := as.SetValue([]byte(fmt.Sprintf("key%d", j)), []byte(fmt.Sprintf("value%d%d", i, j)))
+			assert.NoError(t, err)
+		}
+	}
+	wss := ws.GetSnapshot()
+	assert.NoError(t, wss.Flush())
+
+	accounts := trie_manager.NewImmutableForObject(db.NewMapDB(), wss.StateHash(), AccountType)
+	for _, id := range ids {
+		ass := wss.GetAccountSnapshot(id)
+		proof := wss.GetAccountProof(id)
+		assert.NotNil(t, proof)
+
+		obj, err := accounts.Prove(addressIDToKey(id), proof)
+		assert.NoError(t, err)
+		assert.Equal(t, ass.Bytes(), obj.Bytes())
+
+		store := trie_manager.NewImmutable(db.NewMapDB(), ass.StorageHash())
+		key := []byte("key3")
+		value, err := ass.GetValue(key)
+		assert.NoError(t, err)
+		proved, err := store.Prove(key, ass.GetValueProof(key))
+		assert.NoError(t, err)
+		assert.Equal(t, value, proved)
+
+		assert.Nil(t, ass.GetValueProof([]byte("nokey")))
+	}
+	assert.Nil(t, wss.GetAccountProof([]byte("unknown")))
+}
@@ -565,6 +565,13 @@ func (wvss *worldVirtualSnapshot) StateHash() []byte {
 	return wvss.base.StateHash()
 }
 
+func (wvss *worldVirtualSnapshot) GetAccountProof(id []byte) [][]byte {
+	if err := wvss.realize(); err != nil {
+		return nil
+	}
+	return wvss.base.GetAccountProof(id)
+}
+
 func (wvss *worldVirtualSnapshot) Database() db.Database {
 	return wvss.base.Database()
 }
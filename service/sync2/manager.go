@@ -15,6 +15,7 @@ const (
 	configMaxExpiredTime            = 1200 // in millisecond
 	configMigrationInterval         = 1    // second
 	configDataSyncMigrationInterval = 3    // second
+	configSendInterval              = 100  // in millisecond
 )
 
 var (
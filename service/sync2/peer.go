@@ -13,6 +13,7 @@ import (
 
 type DataSender interface {
 	RequestData(peer module.PeerID, reqID uint32, reqData []BucketIDAndBytes) error
+	ReportPeer(peer module.PeerID, r module.PeerReport)
 }
 
 type DataHandler func(reqID uint32, sender *peer, data []BucketIDAndBytes)
@@ -46,6 +47,10 @@ func (p *peer) String() string {
 	return fmt.Sprintf("peer=%v, reqID=%d", p.id, p.reqID)
 }
 
+func (p *peer) report(r module.PeerReport) {
+	p.sender.ReportPeer(p.id, r)
+}
+
 func (p *peer) getExpired() time.Duration {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -78,8 +83,11 @@ func (p *peer) OnData(reqID uint32, status errCode, data []BucketIDAndBytes) err
 	defer locker.Unlock()
 
 	p.logger.Tracef("OnData() peer=%s reqID=%d status=%s data=%d", p.id, reqID, status, len(data))
-	if status == ErrTimeExpired && p.expired < configMaxExpiredTime {
-		p.expired += 200
+	if status == ErrTimeExpired {
+		if p.expired < configMaxExpiredTime {
+			p.expired += 200
+		}
+		p.sender.ReportPeer(p.id, module.ReportTimeout)
 	}
 
 	if request, ok := p.reqMap[reqID]; ok {
@@ -4,6 +4,7 @@ package sync2
 
 import (
 	"sync"
+	"time"
 
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/db"
@@ -68,6 +69,10 @@ func (r *ReactorCommon) OnLeave(id module.PeerID) {
 	})
 }
 
+func (r *ReactorCommon) ReportPeer(id module.PeerID, rp module.PeerReport) {
+	r.ph.Report(id, rp)
+}
+
 func (r *ReactorCommon) GetVersion() byte {
 	return r.version
 }
@@ -80,6 +85,25 @@ func (r *ReactorCommon) WatchPeers(watcher PeerWatcher) []*peer {
 	return r.readyPool.peerList()
 }
 
+// sendResponse sends the response to the peer. Temporary errors like the
+// exhausted bandwidth budget of the protocol are retried while the requester
+// may wait for the response, so it doesn't report timeout of the peer.
+func (r *ReactorCommon) sendResponse(pi module.ProtocolInfo, b []byte, id module.PeerID) error {
+	deadline := time.Now().Add(configMaxExpiredTime * time.Millisecond)
+	for {
+		err := r.ph.Unicast(pi, b, id)
+		if err == nil || !isTemporary(err) || time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(configSendInterval * time.Millisecond)
+	}
+}
+
+func isTemporary(err error) bool {
+	ne, ok := err.(module.NetworkError)
+	return ok && ne.Temporary()
+}
+
 type ReactorV1 struct {
 	ReactorCommon
 	merkleTrie  db.Bucket
@@ -174,7 +198,7 @@ func (r *ReactorV1) onHasNode(msg []byte, id module.PeerID) {
 
 	if b, err := c.MarshalToBytes(res); err != nil {
 		r.logger.Warnf("Failed to marshal result error=%+v", err)
-	} else if err = r.ph.Unicast(protoResult, b, id); err != nil {
+	} else if err = r.sendResponse(protoResult, b, id); err != nil {
 		r.logger.Infof("Failed to send result error=%+v", err)
 	}
 }
@@ -189,7 +213,7 @@ func (r *ReactorV1) onRequestNodeData(msg []byte, id module.PeerID) {
 		return
 	}
 	r.logger.Tracef("responseNode ReqID=%d, Status=%d, Type=%d to peer=%v", res.ReqID, res.Status, res.Type, id)
-	if err = r.ph.Unicast(protoNodeData, b, id); err != nil {
+	if err = r.sendResponse(protoNodeData, b, id); err != nil {
 		r.logger.Info("Failed to send data peerID=%v", id)
 	}
 }
@@ -200,6 +224,7 @@ func (r *ReactorV1) processMsg(msg []byte, id module.PeerID) (*nodeData, error)
 
 	if err != nil {
 		r.logger.Infof("Failed onReceive. receivedReqID=%d, err=%+v", data.ReqID, err)
+		r.ph.Report(id, module.ReportInvalidMessage)
 		return nil, errors.New("parse nodeData failed")
 	}
 
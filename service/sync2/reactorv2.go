@@ -81,7 +81,7 @@ func (r *ReactorV2) onRequest(msg []byte, id module.PeerID) {
 		return
 	}
 	r.logger.Tracef("onRequest() responseData ReqID=%d, Status=%d, peer=%v", res.ReqID, res.Status, id)
-	if err = r.ph.Unicast(protoV2Response, b, id); err != nil {
+	if err = r.sendResponse(protoV2Response, b, id); err != nil {
 		r.logger.Infof("onRequest() Failed to send data peer=%v", id)
 	}
 }
@@ -93,6 +93,7 @@ func (r *ReactorV2) processMsg(msg []byte, id module.PeerID) (*responseData, err
 
 	if err != nil {
 		r.logger.Infof("Failed onReceive. ReqID=%d, err=%v", data.ReqID, err)
+		r.ph.Report(id, module.ReportInvalidMessage)
 		return nil, errors.New("parse responseData failed")
 	}
 	return data, nil
@@ -9,6 +9,7 @@ import (
 	"runtime"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -49,6 +50,9 @@ type tNetworkManager struct {
 	peers        []*tNetworkManager
 	drop         bool
 	recvBuf      []*tPacket
+	sendQueue    *network.ShapingQueue
+	overflows    int32
+	timeouts     int32
 }
 
 type tProtocolHandler struct {
@@ -159,10 +163,35 @@ func (ph *tProtocolHandler) Multicast(pi module.ProtocolInfo, b []byte, role mod
 	panic("not implemented")
 }
 
+// setSendPolicies applies bandwidth budgets of the policies to unicast
+// like the network manager does.
+func (nm *tNetworkManager) setSendPolicies(s string) error {
+	policies, err := network.ParseSendPolicies(s)
+	if err != nil {
+		return err
+	}
+	q := network.NewShapingQueue(1, network.DefaultSendQueueMaxPriority+1, network.DefaultSendPriority)
+	for id, sp := range policies {
+		if err = q.SetBandwidth(int(id), sp.Bandwidth); err != nil {
+			return err
+		}
+	}
+	nm.sendQueue = q
+	return nil
+}
+
 func (ph *tProtocolHandler) Unicast(pi module.ProtocolInfo, b []byte, id module.PeerID) error {
 	if ph.nm.drop {
 		return nil
 	}
+	if q := ph.nm.sendQueue; q != nil {
+		idx := int(ph.ri.pi.ID())
+		if !q.Available(idx) {
+			atomic.AddInt32(&ph.nm.overflows, 1)
+			return network.NewUnicastError(network.ErrQueueOverflow, id)
+		}
+		q.Consume(idx, len(b))
+	}
 	for _, p := range ph.nm.peers {
 		if p.id.Equal(id) {
 			for _, r := range p.joinReactors {
@@ -179,6 +208,12 @@ func (ph *tProtocolHandler) GetPeers() []module.PeerID {
 	return ph.nm.GetPeers()
 }
 
+func (ph *tProtocolHandler) Report(id module.PeerID, r module.PeerReport) {
+	if r == module.ReportTimeout {
+		atomic.AddInt32(&ph.nm.timeouts, 1)
+	}
+}
+
 func createAPeerID() module.PeerID {
 	return network.NewPeerIDFromAddress(wallet.New().Address())
 }
@@ -455,6 +490,38 @@ func TestSyncAccountSync(t *testing.T) {
 	t.Logf("FINISH\n")
 }
 
+func TestSyncAccountSyncWithBandwidth(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.FatalLevel)
+
+	srcdb := db.NewMapDB()
+	dstdb := db.NewMapDB()
+	nm1 := newTNetworkManager(createAPeerID())
+	nm2 := newTNetworkManager(createAPeerID())
+	assert.NoError(t, nm1.setSendPolicies("statesync:3:16384"))
+
+	NewSyncManager(srcdb, nm1, dummyExBuilder, logger)
+	manager2 := NewSyncManager(dstdb, nm2, dummyExBuilder, logger)
+	nm1.join(nm2)
+
+	ws := state.NewWorldState(srcdb, nil, nil, nil, nil)
+	for i := 0; i < 200; i++ {
+		v := []byte(strconv.Itoa(i))
+		ws.GetAccountState(v).SetValue(v, bytes.Repeat(v, 10))
+	}
+	ss := ws.GetSnapshot()
+	assert.NoError(t, ss.Flush())
+
+	// responses exceeding the budget are sent after the budget is refilled
+	// instead of being dropped, so the requester doesn't report timeout of
+	// the honest peer.
+	result, err := manager2.NewSyncer(ss.StateHash(), nil, nil, nil, nil, nil, false).ForceSync()
+	assert.NoError(t, err)
+	assert.Equal(t, ss.StateHash(), result.Wss.StateHash())
+	assert.Greater(t, atomic.LoadInt32(&nm1.overflows), int32(0))
+	assert.Zero(t, atomic.LoadInt32(&nm2.timeouts))
+}
+
 var receiptRevisions = []module.Revision{0, module.UseMPTOnEvents}
 
 func TestSyncReceiptsSync(t *testing.T) {
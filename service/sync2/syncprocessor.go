@@ -9,6 +9,7 @@ import (
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/common/merkle"
+	"github.com/icon-project/goloop/module"
 )
 
 const (
@@ -356,6 +357,11 @@ func (s *syncProcessor) HandleData(reqID uint32, sender *peer, data []BucketIDAn
 	}
 
 	s.logger.Tracef("HandleData() reqID=%d data=%d received=%d hasError=%v", reqID, len(data), received, hasError)
+	if hasError {
+		p.report(module.ReportInvalidData)
+	} else if received > 0 {
+		p.report(module.ReportValidMessage)
+	}
 	if len(data) > 0 && !hasError {
 		s.readyPool.push(p)
 	} else {
@@ -61,6 +61,9 @@ func (r *mockReactor) WatchPeers(w PeerWatcher) []*peer {
 	return r.readyPool.peerList()
 }
 
+func (r *mockReactor) ReportPeer(id module.PeerID, rp module.PeerReport) {
+}
+
 func (r *mockReactor) RequestData(id module.PeerID, reqID uint32, reqData []BucketIDAndBytes) error {
 	r.logger.Debugf("mockReactor(%v) RequestData() reqID=%d", r.version, reqID)
 
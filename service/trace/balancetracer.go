@@ -35,6 +35,33 @@ func opTypeToString(o module.OpType) string {
 	return opTypeNames[o]
 }
 
+// OpTypeName returns the name of the operation type used in JSON.
+func OpTypeName(o module.OpType) string {
+	return opTypeToString(o)
+}
+
+// OpTypeNames returns names of all operation types.
+func OpTypeNames() []string {
+	return append([]string{}, opTypeNames...)
+}
+
+// BalanceOperation is a change of balance traced by BalanceTracer.
+// From or To is nil for the operation without sender or receiver.
+type BalanceOperation struct {
+	Type   module.OpType
+	From   module.Address
+	To     module.Address
+	Amount *big.Int
+}
+
+// BalanceTransaction has the changes of balance made by a transaction.
+type BalanceTransaction struct {
+	Index     int
+	Hash      []byte
+	IsBlockTx bool
+	Ops       []*BalanceOperation
+}
+
 type operation struct {
 	depth  int
 	opType module.OpType
@@ -229,6 +256,34 @@ func (bt *BalanceTracer) ToJSON(height int64) interface{} {
 	return jso
 }
 
+// Transactions returns the changes of balance by the transactions.
+// Hashes of transactions are replaced in the same way as ToJSON.
+func (bt *BalanceTracer) Transactions(height int64) []*BalanceTransaction {
+	txs := make([]*BalanceTransaction, 0, len(bt.txs))
+	for _, tx := range bt.txs {
+		hash := tx.hash
+		if bt.thr != nil {
+			hash = bt.thr(height, hash)
+		}
+		ops := make([]*BalanceOperation, len(tx.ops))
+		for i, op := range tx.ops {
+			ops[i] = &BalanceOperation{
+				Type:   op.opType,
+				From:   op.from,
+				To:     op.to,
+				Amount: new(big.Int).Set(&op.amount.Int),
+			}
+		}
+		txs = append(txs, &BalanceTransaction{
+			Index:     tx.index,
+			Hash:      hash,
+			IsBlockTx: tx.isBlockTx,
+			Ops:       ops,
+		})
+	}
+	return txs
+}
+
 func NewBalanceTracer(capacity int, thr TxHashReplacer) *BalanceTracer {
 	return &BalanceTracer{
 		txs: make([]*transaction, 0, capacity),
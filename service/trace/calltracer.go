@@ -0,0 +1,232 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trace
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+type callNode struct {
+	parent   *callNode
+	call     *module.TraceCall
+	ended    bool
+	status   error
+	stepUsed *big.Int
+	events   []interface{}
+	calls    []*callNode
+}
+
+func (n *callNode) toJSON() map[string]interface{} {
+	jso := map[string]interface{}{}
+	if c := n.call; c != nil {
+		if c.Type != "" {
+			jso["type"] = c.Type
+		}
+		if c.From != nil {
+			jso["from"] = c.From
+		}
+		if c.To != nil {
+			jso["to"] = c.To
+		}
+		if c.Value != nil && c.Value.Sign() != 0 {
+			jso["value"] = &common.HexInt{Int: *c.Value}
+		}
+		if c.Method != "" {
+			jso["method"] = c.Method
+		}
+		if c.Params != nil {
+			jso["params"] = c.Params
+		}
+	}
+	if n.stepUsed != nil {
+		jso["stepUsed"] = &common.HexInt{Int: *n.stepUsed}
+	}
+	if n.ended && n.status == nil {
+		jso["status"] = "0x1"
+	} else {
+		jso["status"] = "0x0"
+		failure := map[string]interface{}{}
+		if n.status != nil {
+			code, _ := scoreresult.StatusOf(n.status)
+			failure["code"] = fmt.Sprintf("%#x", int(code))
+			failure["message"] = n.status.Error()
+		} else {
+			failure["message"] = "NotEnded"
+		}
+		jso["failure"] = failure
+	}
+	if len(n.events) > 0 {
+		jso["eventLogs"] = n.events
+	}
+	if len(n.calls) > 0 {
+		jso["calls"] = callsToJSON(n.calls)
+	}
+	return jso
+}
+
+func callsToJSON(calls []*callNode) []interface{} {
+	jso := make([]interface{}, len(calls))
+	for i, c := range calls {
+		jso[i] = c.toJSON()
+	}
+	return jso
+}
+
+type callTx struct {
+	index     int
+	hash      []byte
+	isBlockTx bool
+	calls     []*callNode
+}
+
+func (t *callTx) toJSON() map[string]interface{} {
+	prefix := "0x"
+	if t.isBlockTx {
+		prefix = "bx"
+	}
+	return map[string]interface{}{
+		"txIndex": fmt.Sprintf("%#x", t.index),
+		"txHash":  prefix + hex.EncodeToString(t.hash),
+		"calls":   callsToJSON(t.calls),
+	}
+}
+
+// CallTracer builds call trees of transactions with TraceModeCallTree.
+type CallTracer struct {
+	txs     []*callTx
+	current *callNode
+}
+
+func (ct *CallTracer) getCurrentTx() (*callTx, error) {
+	if len(ct.txs) == 0 {
+		return nil, errors.InvalidStateError.New("No transaction")
+	}
+	return ct.txs[len(ct.txs)-1], nil
+}
+
+func (ct *CallTracer) OnTransactionStart(txIndex int, txHash []byte, isBlockTx bool) error {
+	if ct.current != nil {
+		return errors.InvalidStateError.Errorf(
+			"Invalid current call: txIndex=%d txHash=%#x", txIndex, txHash)
+	}
+	ct.txs = append(ct.txs, &callTx{
+		index:     txIndex,
+		hash:      txHash,
+		isBlockTx: isBlockTx,
+	})
+	return nil
+}
+
+func (ct *CallTracer) OnTransactionReset() error {
+	tx, err := ct.getCurrentTx()
+	if err != nil {
+		return err
+	}
+	tx.calls = nil
+	ct.current = nil
+	return nil
+}
+
+func (ct *CallTracer) OnTransactionEnd(txIndex int, txHash []byte) error {
+	tx, err := ct.getCurrentTx()
+	if err != nil {
+		return err
+	}
+	if tx.index != txIndex {
+		return errors.InvalidStateError.Errorf(
+			"Invalid txIndex: cur=%d index=%d", tx.index, txIndex)
+	}
+	// calls aren't ended if the transaction is interrupted.
+	ct.current = nil
+	return nil
+}
+
+func (ct *CallTracer) OnCallStart(call *module.TraceCall) error {
+	tx, err := ct.getCurrentTx()
+	if err != nil {
+		return err
+	}
+	if call.Value != nil {
+		c := *call
+		c.Value = new(big.Int).Set(call.Value)
+		call = &c
+	}
+	node := &callNode{
+		parent: ct.current,
+		call:   call,
+	}
+	if ct.current == nil {
+		tx.calls = append(tx.calls, node)
+	} else {
+		ct.current.calls = append(ct.current.calls, node)
+	}
+	ct.current = node
+	return nil
+}
+
+func (ct *CallTracer) OnCallEnd(status error, stepUsed *big.Int) error {
+	node := ct.current
+	if node == nil {
+		return errors.InvalidStateError.New("No call")
+	}
+	node.ended = true
+	node.status = status
+	if stepUsed != nil {
+		node.stepUsed = new(big.Int).Set(stepUsed)
+	}
+	ct.current = node.parent
+	return nil
+}
+
+func (ct *CallTracer) OnEvent(addr module.Address, indexed, data [][]byte) error {
+	node := ct.current
+	if node == nil {
+		return errors.InvalidStateError.New("No call")
+	}
+	node.events = append(node.events, txresult.EventLogToJSON(addr, indexed, data))
+	return nil
+}
+
+// CallsToJSON returns call trees of the first transaction. It's used for
+// tracing a transaction.
+func (ct *CallTracer) CallsToJSON() []interface{} {
+	if len(ct.txs) == 0 {
+		return []interface{}{}
+	}
+	return callsToJSON(ct.txs[0].calls)
+}
+
+// ToJSON returns call trees of the transactions.
+func (ct *CallTracer) ToJSON() []interface{} {
+	jso := make([]interface{}, len(ct.txs))
+	for i, tx := range ct.txs {
+		jso[i] = tx.toJSON()
+	}
+	return jso
+}
+
+func NewCallTracer() *CallTracer {
+	return &CallTracer{}
+}
@@ -0,0 +1,116 @@
+package trace
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+func TestCallTracer_Basic(t *testing.T) {
+	var err error
+	ct := NewCallTracer()
+
+	txIndex := 0
+	txHash := newRandomHash(32)
+	err = ct.OnTransactionStart(txIndex, txHash, false)
+	assert.NoError(t, err)
+
+	eoa := common.MustNewAddressFromString("hx100")
+	score1 := common.MustNewAddressFromString("cx101")
+	score2 := common.MustNewAddressFromString("cx102")
+
+	value := big.NewInt(1000)
+	err = ct.OnCallStart(&module.TraceCall{
+		Type:   "call",
+		From:   eoa,
+		To:     score1,
+		Value:  value,
+		Method: "transfer",
+	})
+	assert.NoError(t, err)
+	// value is copied on start
+	value.SetInt64(0)
+
+	err = ct.OnEvent(score1, [][]byte{[]byte("Transfer(Address,int)")}, nil)
+	assert.NoError(t, err)
+
+	err = ct.OnCallStart(&module.TraceCall{
+		Type:   "call",
+		From:   score1,
+		To:     score2,
+		Method: "onReceive",
+	})
+	assert.NoError(t, err)
+
+	err = ct.OnCallEnd(scoreresult.New(module.StatusReverted+3, "Rejected"), big.NewInt(200))
+	assert.NoError(t, err)
+
+	err = ct.OnCallEnd(nil, big.NewInt(1000))
+	assert.NoError(t, err)
+
+	err = ct.OnTransactionEnd(txIndex, txHash)
+	assert.NoError(t, err)
+
+	calls := ct.CallsToJSON()
+	assert.Equal(t, 1, len(calls))
+
+	root, ok := calls[0].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "call", root["type"])
+	assert.Equal(t, "transfer", root["method"])
+	assert.Equal(t, "0x3e8", root["value"].(*common.HexInt).String())
+	assert.Equal(t, "0x3e8", root["stepUsed"].(*common.HexInt).String())
+	assert.Equal(t, "0x1", root["status"])
+	assert.Equal(t, 1, len(root["eventLogs"].([]interface{})))
+
+	sub := root["calls"].([]interface{})
+	assert.Equal(t, 1, len(sub))
+	child := sub[0].(map[string]interface{})
+	assert.Equal(t, "onReceive", child["method"])
+	assert.Equal(t, "0x0", child["status"])
+	failure := child["failure"].(map[string]interface{})
+	assert.Equal(t, "0x23", failure["code"])
+	assert.Nil(t, child["calls"])
+
+	txs := ct.ToJSON()
+	assert.Equal(t, 1, len(txs))
+}
+
+func TestCallTracer_Reset(t *testing.T) {
+	ct := NewCallTracer()
+	txHash := newRandomHash(32)
+
+	assert.NoError(t, ct.OnTransactionStart(0, txHash, false))
+	assert.NoError(t, ct.OnCallStart(&module.TraceCall{Type: "call"}))
+	assert.NoError(t, ct.OnTransactionReset())
+	assert.NoError(t, ct.OnCallStart(&module.TraceCall{Type: "transfer"}))
+	assert.NoError(t, ct.OnTransactionEnd(0, txHash))
+
+	calls := ct.CallsToJSON()
+	assert.Equal(t, 1, len(calls))
+	call := calls[0].(map[string]interface{})
+	assert.Equal(t, "transfer", call["type"])
+	// interrupted call is not ended
+	assert.Equal(t, "0x0", call["status"])
+	assert.Equal(t, "NotEnded", call["failure"].(map[string]interface{})["message"])
+}
+
+func TestCallTracer_ErrorCase(t *testing.T) {
+	ct := NewCallTracer()
+	txHash := newRandomHash(32)
+
+	assert.Error(t, ct.OnCallStart(&module.TraceCall{}))
+	assert.Error(t, ct.OnCallEnd(nil, nil))
+	assert.Error(t, ct.OnEvent(nil, nil, nil))
+	assert.Error(t, ct.OnTransactionEnd(0, txHash))
+
+	assert.NoError(t, ct.OnTransactionStart(0, txHash, false))
+	assert.Error(t, ct.OnCallEnd(nil, nil))
+	assert.Error(t, ct.OnTransactionEnd(1, txHash))
+	assert.Equal(t, 0, len(ct.CallsToJSON()))
+}
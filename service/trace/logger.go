@@ -230,6 +230,62 @@ func (l *Logger) OnBalanceChange(opType module.OpType, from, to module.Address,
 	}
 }
 
+// IsCallTraced returns whether OnCallStart and OnCallEnd are delivered to
+// the callback.
+func (l *Logger) IsCallTraced() bool {
+	mode := l.TraceMode()
+	return mode == module.TraceModeCallTree || mode == module.TraceModeStepProfile
+}
+
+// OnCallStart notifies the start of the call. Use IsCallTraced to avoid
+// building the call information if it's not required.
+func (l *Logger) OnCallStart(call *module.TraceCall) {
+	if !l.IsCallTraced() {
+		return
+	}
+	if err := l.cb.OnCallStart(call); err != nil {
+		l.Warnf("OnCallStart() error: type=%s from=%s to=%s method=%s err=%#v",
+			call.Type, call.From, call.To, call.Method, err)
+	}
+}
+
+func (l *Logger) OnCallEnd(status error, stepUsed *big.Int) {
+	if !l.IsCallTraced() {
+		return
+	}
+	if err := l.cb.OnCallEnd(status, stepUsed); err != nil {
+		l.Warnf("OnCallEnd() error: status=%v err=%#v", status, err)
+	}
+}
+
+func (l *Logger) OnEvent(addr module.Address, indexed, data [][]byte) {
+	if l.TraceMode() != module.TraceModeCallTree {
+		return
+	}
+	if err := l.cb.OnEvent(addr, indexed, data); err != nil {
+		l.Warnf("OnEvent() error: addr=%s err=%#v", addr, err)
+	}
+}
+
+func (l *Logger) OnStateDiff(diffs []*module.TraceAccountDiff) {
+	if l.TraceMode() != module.TraceModeStateDiff {
+		return
+	}
+	if err := l.cb.OnStateDiff(diffs); err != nil {
+		l.Warnf("OnStateDiff() error: accounts=%d err=%#v", len(diffs), err)
+	}
+}
+
+func (l *Logger) OnSteps(stepType string, count int, steps *big.Int) {
+	if l.TraceMode() != module.TraceModeStepProfile {
+		return
+	}
+	if err := l.cb.OnSteps(stepType, count, steps); err != nil {
+		l.Warnf("OnSteps() error: type=%s count=%d steps=%v err=%#v",
+			stepType, count, steps, err)
+	}
+}
+
 func NewLogger(l log.Logger, ti *module.TraceInfo) *Logger {
 	tlog := &Logger{
 		Logger: l,
@@ -0,0 +1,229 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trace
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/containerdb"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+func bytesToJSON(bs []byte) interface{} {
+	if bs == nil {
+		return nil
+	}
+	return "0x" + hex.EncodeToString(bs)
+}
+
+func contractStateToJSON(c *module.TraceContractState) interface{} {
+	jso := map[string]interface{}{
+		"status":   c.Status,
+		"type":     c.EEType,
+		"codeHash": bytesToJSON(c.CodeHash),
+	}
+	if c.DeployTxHash != nil {
+		jso["deployTxHash"] = bytesToJSON(c.DeployTxHash)
+	}
+	if c.AuditTxHash != nil {
+		jso["auditTxHash"] = bytesToJSON(c.AuditTxHash)
+	}
+	return jso
+}
+
+func accountStateToJSON(as *module.TraceAccountState) interface{} {
+	if as == nil {
+		return nil
+	}
+	jso := map[string]interface{}{
+		"balance": &common.HexInt{Int: *as.Balance},
+	}
+	if as.ContractOwner != nil {
+		jso["owner"] = as.ContractOwner
+	}
+	if as.Contract != nil {
+		jso["current"] = contractStateToJSON(as.Contract)
+	}
+	if as.NextContract != nil {
+		jso["next"] = contractStateToJSON(as.NextContract)
+	}
+	if as.Disabled {
+		jso["disabled"] = "0x1"
+	}
+	if as.Blocked {
+		jso["blocked"] = "0x1"
+	}
+	return jso
+}
+
+// splitStorageKey returns parts of the key if the key is composed by
+// containerdb.AppendKeys (ex. RLPBuilder). It returns nil for hashed keys
+// or raw keys.
+func splitStorageKey(key []byte) [][]byte {
+	keys, err := containerdb.SplitKeys(key)
+	if err != nil || len(keys) < 2 {
+		return nil
+	}
+	for _, k := range keys {
+		if len(k) != 1 {
+			return keys
+		}
+	}
+	// any raw bytes less than 0x80 are split into single bytes.
+	return nil
+}
+
+func storageChangeToJSON(c *module.TraceStorageChange) interface{} {
+	jso := map[string]interface{}{
+		"key":    bytesToJSON(c.Key),
+		"before": bytesToJSON(c.Before),
+		"after":  bytesToJSON(c.After),
+	}
+	if keys := splitStorageKey(c.Key); keys != nil {
+		parts := make([]interface{}, len(keys))
+		for i, k := range keys {
+			parts[i] = bytesToJSON(k)
+		}
+		jso["keys"] = parts
+	}
+	return jso
+}
+
+func accountDiffToJSON(d *module.TraceAccountDiff) interface{} {
+	jso := map[string]interface{}{
+		"address": d.Address,
+		"before":  accountStateToJSON(d.Before),
+		"after":   accountStateToJSON(d.After),
+	}
+	if len(d.Storage) > 0 {
+		storage := make([]interface{}, len(d.Storage))
+		for i, c := range d.Storage {
+			storage[i] = storageChangeToJSON(c)
+		}
+		jso["storage"] = storage
+	}
+	return jso
+}
+
+type stateDiffTx struct {
+	index     int
+	hash      []byte
+	isBlockTx bool
+	accounts  []*module.TraceAccountDiff
+}
+
+func (t *stateDiffTx) accountsToJSON() []interface{} {
+	jso := make([]interface{}, len(t.accounts))
+	for i, d := range t.accounts {
+		jso[i] = accountDiffToJSON(d)
+	}
+	return jso
+}
+
+func (t *stateDiffTx) toJSON() map[string]interface{} {
+	prefix := "0x"
+	if t.isBlockTx {
+		prefix = "bx"
+	}
+	return map[string]interface{}{
+		"txIndex":  fmt.Sprintf("%#x", t.index),
+		"txHash":   prefix + hex.EncodeToString(t.hash),
+		"accounts": t.accountsToJSON(),
+	}
+}
+
+// StateDiffTracer collects changes of accounts made by transactions with
+// TraceModeStateDiff.
+type StateDiffTracer struct {
+	blockID []byte
+	txs     []*stateDiffTx
+}
+
+func (st *StateDiffTracer) getCurrentTx() (*stateDiffTx, error) {
+	if len(st.txs) == 0 {
+		return nil, errors.InvalidStateError.New("No transaction")
+	}
+	return st.txs[len(st.txs)-1], nil
+}
+
+func (st *StateDiffTracer) OnTransactionStart(txIndex int, txHash []byte, isBlockTx bool) error {
+	if isBlockTx && txHash == nil {
+		// In case of blockTransaction, use blockHash as a txHash
+		txHash = st.blockID
+	}
+	st.txs = append(st.txs, &stateDiffTx{
+		index:     txIndex,
+		hash:      txHash,
+		isBlockTx: isBlockTx,
+	})
+	return nil
+}
+
+func (st *StateDiffTracer) OnTransactionReset() error {
+	tx, err := st.getCurrentTx()
+	if err != nil {
+		return err
+	}
+	tx.accounts = nil
+	return nil
+}
+
+func (st *StateDiffTracer) OnTransactionEnd(txIndex int, txHash []byte) error {
+	tx, err := st.getCurrentTx()
+	if err != nil {
+		return err
+	}
+	if tx.index != txIndex {
+		return errors.InvalidStateError.Errorf(
+			"Invalid txIndex: cur=%d index=%d", tx.index, txIndex)
+	}
+	return nil
+}
+
+func (st *StateDiffTracer) OnStateDiff(diffs []*module.TraceAccountDiff) error {
+	tx, err := st.getCurrentTx()
+	if err != nil {
+		return err
+	}
+	tx.accounts = diffs
+	return nil
+}
+
+// AccountsToJSON returns changes of accounts of the first transaction.
+// It's used for tracing a transaction.
+func (st *StateDiffTracer) AccountsToJSON() []interface{} {
+	if len(st.txs) == 0 {
+		return []interface{}{}
+	}
+	return st.txs[0].accountsToJSON()
+}
+
+// ToJSON returns changes of accounts of the transactions.
+func (st *StateDiffTracer) ToJSON() []interface{} {
+	jso := make([]interface{}, len(st.txs))
+	for i, tx := range st.txs {
+		jso[i] = tx.toJSON()
+	}
+	return jso
+}
+
+func NewStateDiffTracer(blockID []byte) *StateDiffTracer {
+	return &StateDiffTracer{blockID: blockID}
+}
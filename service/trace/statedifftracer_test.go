@@ -0,0 +1,85 @@
+package trace
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/containerdb"
+	"github.com/icon-project/goloop/module"
+)
+
+func TestStateDiffTracer_Basic(t *testing.T) {
+	st := NewStateDiffTracer(newRandomHash(32))
+
+	txHash := newRandomHash(32)
+	assert.NoError(t, st.OnTransactionStart(0, txHash, false))
+
+	score := common.MustNewAddressFromString("cx101")
+	key := containerdb.ToKey(containerdb.RLPBuilder, "balances", score).Build()
+	diffs := []*module.TraceAccountDiff{
+		{
+			Address: score,
+			Before: &module.TraceAccountState{
+				Balance: big.NewInt(10),
+			},
+			After: &module.TraceAccountState{
+				Balance:  big.NewInt(20),
+				Disabled: true,
+				Contract: &module.TraceContractState{
+					Status:   "active",
+					CodeHash: []byte{0x01},
+				},
+			},
+			Storage: []*module.TraceStorageChange{
+				{Key: key, After: []byte{0x0a}},
+				{Key: []byte("raw_key"), Before: []byte{0x01}},
+			},
+		},
+	}
+	assert.NoError(t, st.OnStateDiff(diffs))
+	assert.NoError(t, st.OnTransactionEnd(0, txHash))
+
+	accounts := st.AccountsToJSON()
+	assert.Len(t, accounts, 1)
+	acc := accounts[0].(map[string]interface{})
+	assert.Equal(t, score, acc["address"])
+
+	before := acc["before"].(map[string]interface{})
+	assert.Equal(t, "0xa", before["balance"].(*common.HexInt).String())
+	after := acc["after"].(map[string]interface{})
+	assert.Equal(t, "0x1", after["disabled"])
+	assert.Equal(t, "active", after["current"].(map[string]interface{})["status"])
+
+	storage := acc["storage"].([]interface{})
+	assert.Len(t, storage, 2)
+	s0 := storage[0].(map[string]interface{})
+	assert.Nil(t, s0["before"])
+	assert.Equal(t, "0x0a", s0["after"])
+	assert.Len(t, s0["keys"], 2)
+	s1 := storage[1].(map[string]interface{})
+	assert.Nil(t, s1["keys"])
+	assert.Nil(t, s1["after"])
+
+	txs := st.ToJSON()
+	assert.Len(t, txs, 1)
+}
+
+func TestStateDiffTracer_BlockTx(t *testing.T) {
+	blockID := newRandomHash(32)
+	st := NewStateDiffTracer(blockID)
+
+	assert.NoError(t, st.OnTransactionStart(0, nil, true))
+	assert.NoError(t, st.OnStateDiff(nil))
+	assert.NoError(t, st.OnTransactionReset())
+	assert.NoError(t, st.OnTransactionEnd(0, nil))
+	assert.Error(t, st.OnTransactionEnd(1, nil))
+
+	tx := st.ToJSON()[0].(map[string]interface{})
+	assert.Equal(t, "0x0", tx["txIndex"])
+	assert.Equal(t, "bx"+hex.EncodeToString(blockID), tx["txHash"])
+	assert.Len(t, tx["accounts"], 0)
+}
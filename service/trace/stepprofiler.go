@@ -0,0 +1,330 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trace
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/state"
+)
+
+// StepTypeExecution is the pseudo step type for the steps used by
+// the execution environment for the frame except the steps of
+// the operations reported with their step types (ex. storage access and
+// event logs).
+const StepTypeExecution = "execution"
+
+// operationTypes are the step types counted in operations of the frame.
+var operationTypes = map[string]bool{
+	state.StepTypeGet:      true,
+	state.StepTypeSet:      true,
+	state.StepTypeReplace:  true,
+	state.StepTypeDelete:   true,
+	state.StepTypeEventLog: true,
+}
+
+type stepOperation struct {
+	count int64
+	size  int64
+}
+
+type stepNode struct {
+	parent   *stepNode
+	call     *module.TraceCall
+	ended    bool
+	stepUsed *big.Int
+	steps    map[string]*big.Int
+	ops      map[string]*stepOperation
+	calls    []*stepNode
+}
+
+func newStepNode(parent *stepNode, call *module.TraceCall) *stepNode {
+	return &stepNode{
+		parent: parent,
+		call:   call,
+		steps:  make(map[string]*big.Int),
+		ops:    make(map[string]*stepOperation),
+	}
+}
+
+func (n *stepNode) addSteps(t string, steps *big.Int) {
+	if v, ok := n.steps[t]; ok {
+		v.Add(v, steps)
+	} else {
+		n.steps[t] = new(big.Int).Set(steps)
+	}
+}
+
+func (n *stepNode) addOperation(t string, size int) {
+	op, ok := n.ops[t]
+	if !ok {
+		op = new(stepOperation)
+		n.ops[t] = op
+	}
+	op.count += 1
+	op.size += int64(size)
+}
+
+// sumOfSteps returns the sum of the steps charged for the frame and
+// used by the children.
+func (n *stepNode) sumOfSteps() *big.Int {
+	sum := new(big.Int)
+	for _, v := range n.steps {
+		sum.Add(sum, v)
+	}
+	for _, c := range n.calls {
+		if c.stepUsed != nil {
+			sum.Add(sum, c.stepUsed)
+		}
+	}
+	return sum
+}
+
+// end sets used steps of the frame. Steps not explained by charged steps
+// and the children are used by the execution environment. It returns an
+// error if charged steps and the children use more steps than the frame.
+func (n *stepNode) end(stepUsed *big.Int) error {
+	n.ended = true
+	if stepUsed == nil {
+		return nil
+	}
+	n.stepUsed = new(big.Int).Set(stepUsed)
+	charged := n.sumOfSteps()
+	exec := new(big.Int).Sub(stepUsed, charged)
+	switch exec.Sign() {
+	case 1:
+		n.addSteps(StepTypeExecution, exec)
+	case -1:
+		return errors.InvalidStateError.Errorf(
+			"InvalidStepUsed(used=%s,charged=%s)", stepUsed, charged)
+	}
+	return nil
+}
+
+// collectSteps adds steps of the frame and its children to the sum.
+func (n *stepNode) collectSteps(sum map[string]*big.Int) {
+	for t, v := range n.steps {
+		if s, ok := sum[t]; ok {
+			s.Add(s, v)
+		} else {
+			sum[t] = new(big.Int).Set(v)
+		}
+	}
+	for _, c := range n.calls {
+		c.collectSteps(sum)
+	}
+}
+
+func stepsToJSON(steps map[string]*big.Int) map[string]interface{} {
+	jso := make(map[string]interface{}, len(steps))
+	for t, v := range steps {
+		jso[t] = &common.HexInt{Int: *v}
+	}
+	return jso
+}
+
+func (n *stepNode) toJSON() map[string]interface{} {
+	jso := map[string]interface{}{}
+	if c := n.call; c != nil {
+		if c.Type != "" {
+			jso["type"] = c.Type
+		}
+		if c.From != nil {
+			jso["from"] = c.From
+		}
+		if c.To != nil {
+			jso["to"] = c.To
+		}
+		if c.Method != "" {
+			jso["method"] = c.Method
+		}
+	}
+	if n.stepUsed != nil {
+		jso["stepUsed"] = &common.HexInt{Int: *n.stepUsed}
+	}
+	jso["steps"] = stepsToJSON(n.steps)
+	if len(n.ops) > 0 {
+		ops := make(map[string]interface{}, len(n.ops))
+		for t, op := range n.ops {
+			ops[t] = map[string]interface{}{
+				"count": common.HexInt64{Value: op.count},
+				"size":  common.HexInt64{Value: op.size},
+			}
+		}
+		jso["operations"] = ops
+	}
+	if len(n.calls) > 0 {
+		jso["calls"] = stepNodesToJSON(n.calls)
+	}
+	return jso
+}
+
+func stepNodesToJSON(nodes []*stepNode) []interface{} {
+	jso := make([]interface{}, len(nodes))
+	for i, n := range nodes {
+		jso[i] = n.toJSON()
+	}
+	return jso
+}
+
+type stepTx struct {
+	index     int
+	hash      []byte
+	isBlockTx bool
+	root      *stepNode
+}
+
+// profileToJSON returns the profile of the transaction. The root frame
+// has the steps charged by the transaction itself (ex. default and input).
+func (t *stepTx) profileToJSON() map[string]interface{} {
+	steps := make(map[string]*big.Int)
+	t.root.collectSteps(steps)
+	return map[string]interface{}{
+		"stepUsed": &common.HexInt{Int: *t.root.sumOfSteps()},
+		"steps":    stepsToJSON(steps),
+		"calls":    stepNodesToJSON(t.root.calls),
+	}
+}
+
+func (t *stepTx) toJSON() map[string]interface{} {
+	prefix := "0x"
+	if t.isBlockTx {
+		prefix = "bx"
+	}
+	jso := t.profileToJSON()
+	jso["txIndex"] = fmt.Sprintf("%#x", t.index)
+	jso["txHash"] = prefix + hex.EncodeToString(t.hash)
+	return jso
+}
+
+// StepProfiler attributes used steps of transactions by step type and
+// by call frame with TraceModeStepProfile.
+type StepProfiler struct {
+	txs     []*stepTx
+	current *stepNode
+}
+
+func (sp *StepProfiler) getCurrentTx() (*stepTx, error) {
+	if len(sp.txs) == 0 {
+		return nil, errors.InvalidStateError.New("No transaction")
+	}
+	return sp.txs[len(sp.txs)-1], nil
+}
+
+func (sp *StepProfiler) OnTransactionStart(txIndex int, txHash []byte, isBlockTx bool) error {
+	if sp.current != nil {
+		return errors.InvalidStateError.Errorf(
+			"Invalid current call: txIndex=%d txHash=%#x", txIndex, txHash)
+	}
+	tx := &stepTx{
+		index:     txIndex,
+		hash:      txHash,
+		isBlockTx: isBlockTx,
+		root:      newStepNode(nil, nil),
+	}
+	sp.txs = append(sp.txs, tx)
+	sp.current = tx.root
+	return nil
+}
+
+func (sp *StepProfiler) OnTransactionReset() error {
+	tx, err := sp.getCurrentTx()
+	if err != nil {
+		return err
+	}
+	tx.root = newStepNode(nil, nil)
+	sp.current = tx.root
+	return nil
+}
+
+func (sp *StepProfiler) OnTransactionEnd(txIndex int, txHash []byte) error {
+	tx, err := sp.getCurrentTx()
+	if err != nil {
+		return err
+	}
+	if tx.index != txIndex {
+		return errors.InvalidStateError.Errorf(
+			"Invalid txIndex: cur=%d index=%d", tx.index, txIndex)
+	}
+	sp.current = nil
+	return nil
+}
+
+func (sp *StepProfiler) OnCallStart(call *module.TraceCall) error {
+	if sp.current == nil {
+		return errors.InvalidStateError.New("No transaction")
+	}
+	node := newStepNode(sp.current, call)
+	sp.current.calls = append(sp.current.calls, node)
+	sp.current = node
+	return nil
+}
+
+func (sp *StepProfiler) OnCallEnd(status error, stepUsed *big.Int) error {
+	node := sp.current
+	if node == nil || node.parent == nil {
+		return errors.InvalidStateError.New("No call")
+	}
+	sp.current = node.parent
+	return node.end(stepUsed)
+}
+
+func (sp *StepProfiler) OnSteps(stepType string, count int, steps *big.Int) error {
+	node := sp.current
+	if node == nil {
+		return errors.InvalidStateError.New("No transaction")
+	}
+	if steps != nil {
+		node.addSteps(stepType, steps)
+	}
+	// negative count is for adjusting steps of the previous operation
+	if operationTypes[stepType] && count >= 0 {
+		node.addOperation(stepType, count)
+	}
+	return nil
+}
+
+// ProfileToJSON returns the profile of the first transaction. It's used for
+// tracing a transaction.
+func (sp *StepProfiler) ProfileToJSON() map[string]interface{} {
+	if len(sp.txs) == 0 {
+		return map[string]interface{}{
+			"stepUsed": "0x0",
+			"steps":    map[string]interface{}{},
+			"calls":    []interface{}{},
+		}
+	}
+	return sp.txs[0].profileToJSON()
+}
+
+// ToJSON returns the profiles of the transactions.
+func (sp *StepProfiler) ToJSON() []interface{} {
+	jso := make([]interface{}, len(sp.txs))
+	for i, tx := range sp.txs {
+		jso[i] = tx.toJSON()
+	}
+	return jso
+}
+
+func NewStepProfiler() *StepProfiler {
+	return &StepProfiler{}
+}
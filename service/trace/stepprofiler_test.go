@@ -0,0 +1,160 @@
+package trace
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/module"
+)
+
+func hexIntOf(t *testing.T, v interface{}) string {
+	hv, ok := v.(*common.HexInt)
+	if !assert.True(t, ok) {
+		return ""
+	}
+	return hv.String()
+}
+
+func TestStepProfiler_Basic(t *testing.T) {
+	sp := NewStepProfiler()
+
+	txIndex := 0
+	txHash := newRandomHash(32)
+	assert.NoError(t, sp.OnTransactionStart(txIndex, txHash, false))
+	assert.NoError(t, sp.OnSteps("default", 1, big.NewInt(100000)))
+	assert.NoError(t, sp.OnSteps("input", 20, big.NewInt(4000)))
+
+	eoa := common.MustNewAddressFromString("hx100")
+	score1 := common.MustNewAddressFromString("cx101")
+	score2 := common.MustNewAddressFromString("cx102")
+
+	assert.NoError(t, sp.OnCallStart(&module.TraceCall{
+		Type:   "call",
+		From:   eoa,
+		To:     score1,
+		Method: "transfer",
+	}))
+	assert.NoError(t, sp.OnSteps("set", 32, big.NewInt(1600)))
+	assert.NoError(t, sp.OnSteps("set", 8, big.NewInt(400)))
+
+	assert.NoError(t, sp.OnCallStart(&module.TraceCall{
+		Type:   "call",
+		From:   score1,
+		To:     score2,
+		Method: "tokenFallback",
+	}))
+	assert.NoError(t, sp.OnSteps("eventLog", 10, big.NewInt(500)))
+	assert.NoError(t, sp.OnCallEnd(nil, big.NewInt(3000)))
+
+	assert.NoError(t, sp.OnSteps("contractCall", 1, big.NewInt(25000)))
+	assert.NoError(t, sp.OnCallEnd(nil, big.NewInt(50000)))
+	assert.NoError(t, sp.OnTransactionEnd(txIndex, txHash))
+
+	profile := sp.ProfileToJSON()
+	assert.Equal(t, "0x25990", hexIntOf(t, profile["stepUsed"]))
+
+	steps := profile["steps"].(map[string]interface{})
+	assert.Equal(t, "0x186a0", hexIntOf(t, steps["default"]))
+	assert.Equal(t, "0xfa0", hexIntOf(t, steps["input"]))
+	assert.Equal(t, "0x61a8", hexIntOf(t, steps["contractCall"]))
+	assert.Equal(t, "0x7d0", hexIntOf(t, steps["set"]))
+	assert.Equal(t, "0x1f4", hexIntOf(t, steps["eventLog"]))
+	// 20000 (score1) + 2500 (score2)
+	assert.Equal(t, "0x57e4", hexIntOf(t, steps[StepTypeExecution]))
+
+	calls := profile["calls"].([]interface{})
+	assert.Equal(t, 1, len(calls))
+	frame := calls[0].(map[string]interface{})
+	assert.Equal(t, "transfer", frame["method"])
+	assert.Equal(t, "0xc350", hexIntOf(t, frame["stepUsed"]))
+	fsteps := frame["steps"].(map[string]interface{})
+	assert.Equal(t, "0x4e20", hexIntOf(t, fsteps[StepTypeExecution]))
+	assert.Equal(t, "0x7d0", hexIntOf(t, fsteps["set"]))
+	ops := frame["operations"].(map[string]interface{})
+	set := ops["set"].(map[string]interface{})
+	assert.Equal(t, int64(2), set["count"].(common.HexInt64).Value)
+	assert.Equal(t, int64(40), set["size"].(common.HexInt64).Value)
+
+	sub := frame["calls"].([]interface{})
+	assert.Equal(t, 1, len(sub))
+	child := sub[0].(map[string]interface{})
+	assert.Equal(t, "tokenFallback", child["method"])
+	assert.Equal(t, "0xbb8", hexIntOf(t, child["stepUsed"]))
+	assert.NotNil(t, child["operations"].(map[string]interface{})["eventLog"])
+	csteps := child["steps"].(map[string]interface{})
+	assert.Equal(t, "0x9c4", hexIntOf(t, csteps[StepTypeExecution]))
+
+	txs := sp.ToJSON()
+	assert.Equal(t, 1, len(txs))
+	tx := txs[0].(map[string]interface{})
+	assert.Equal(t, "0x0", tx["txIndex"])
+	assert.Equal(t, "0x25990", hexIntOf(t, tx["stepUsed"]))
+}
+
+func TestStepProfiler_Reset(t *testing.T) {
+	sp := NewStepProfiler()
+
+	txHash := newRandomHash(32)
+	assert.NoError(t, sp.OnTransactionStart(0, txHash, false))
+	assert.NoError(t, sp.OnSteps("default", 1, big.NewInt(100)))
+	assert.NoError(t, sp.OnCallStart(&module.TraceCall{Type: "call"}))
+	assert.NoError(t, sp.OnTransactionReset())
+
+	assert.NoError(t, sp.OnSteps("default", 1, big.NewInt(200)))
+	assert.NoError(t, sp.OnTransactionEnd(0, txHash))
+
+	profile := sp.ProfileToJSON()
+	assert.Equal(t, "0xc8", hexIntOf(t, profile["stepUsed"]))
+	assert.Equal(t, 0, len(profile["calls"].([]interface{})))
+}
+
+func TestStepProfiler_ErrorCase(t *testing.T) {
+	sp := NewStepProfiler()
+
+	assert.Error(t, sp.OnSteps("default", 1, big.NewInt(100)))
+	assert.Error(t, sp.OnCallStart(&module.TraceCall{}))
+	assert.Error(t, sp.OnTransactionEnd(0, nil))
+
+	assert.NoError(t, sp.OnTransactionStart(0, nil, false))
+	assert.Error(t, sp.OnCallEnd(nil, big.NewInt(0)))
+	assert.Error(t, sp.OnTransactionStart(1, nil, false))
+}
+
+func TestStepProfiler_Adjustment(t *testing.T) {
+	sp := NewStepProfiler()
+
+	assert.NoError(t, sp.OnTransactionStart(0, nil, false))
+	assert.NoError(t, sp.OnCallStart(&module.TraceCall{Type: "call"}))
+	assert.NoError(t, sp.OnSteps("set", 8, big.NewInt(400)))
+	assert.NoError(t, sp.OnSteps("set", -1, big.NewInt(-100)))
+	assert.NoError(t, sp.OnCallEnd(nil, big.NewInt(1000)))
+	assert.NoError(t, sp.OnTransactionEnd(0, nil))
+
+	frame := sp.ProfileToJSON()["calls"].([]interface{})[0].(map[string]interface{})
+	fsteps := frame["steps"].(map[string]interface{})
+	assert.Equal(t, "0x12c", hexIntOf(t, fsteps["set"]))
+	assert.Equal(t, "0x2bc", hexIntOf(t, fsteps[StepTypeExecution]))
+	set := frame["operations"].(map[string]interface{})["set"].(map[string]interface{})
+	assert.Equal(t, int64(1), set["count"].(common.HexInt64).Value)
+	assert.Equal(t, int64(8), set["size"].(common.HexInt64).Value)
+}
+
+func TestStepProfiler_OverCharged(t *testing.T) {
+	sp := NewStepProfiler()
+
+	assert.NoError(t, sp.OnTransactionStart(0, nil, false))
+	assert.NoError(t, sp.OnCallStart(&module.TraceCall{Type: "call"}))
+	assert.NoError(t, sp.OnSteps("set", 8, big.NewInt(400)))
+	assert.Error(t, sp.OnCallEnd(nil, big.NewInt(300)))
+
+	// the frame is ended even though the steps are inconsistent
+	assert.Error(t, sp.OnCallEnd(nil, big.NewInt(300)))
+	assert.NoError(t, sp.OnTransactionEnd(0, nil))
+
+	frame := sp.ProfileToJSON()["calls"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "0x12c", hexIntOf(t, frame["stepUsed"]))
+	assert.Nil(t, frame["steps"].(map[string]interface{})[StepTypeExecution])
+}
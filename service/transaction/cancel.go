@@ -0,0 +1,105 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transaction
+
+import (
+	"encoding/json"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+const cancelSalt = "icx_cancelTransaction."
+
+var cancelExclusion = map[string]bool{
+	"signature": true,
+}
+
+type cancelRequestJSON struct {
+	From      common.Address   `json:"from"`
+	TxHash    common.HexBytes  `json:"txHash"`
+	TimeStamp common.HexInt64  `json:"timestamp"`
+	NID       *common.HexInt64 `json:"nid,omitempty"`
+	Signature common.Signature `json:"signature"`
+}
+
+// CancelRequest is a request to remove a transaction from the transaction
+// pool. It's signed by the sender of the transaction in the same way as
+// the transaction, but the hash is salted with "icx_cancelTransaction".
+type CancelRequest struct {
+	cancelRequestJSON
+	raw  []byte
+	hash []byte
+}
+
+func (r *CancelRequest) From() module.Address {
+	return &r.cancelRequestJSON.From
+}
+
+// TxHash returns hash of the transaction to cancel.
+func (r *CancelRequest) TxHash() []byte {
+	return r.cancelRequestJSON.TxHash.Bytes()
+}
+
+func (r *CancelRequest) Timestamp() int64 {
+	return r.TimeStamp.Value
+}
+
+func (r *CancelRequest) Hash() []byte {
+	return r.hash
+}
+
+func (r *CancelRequest) ValidateNetwork(nid int) bool {
+	if r.NID == nil {
+		return true
+	}
+	return int(r.NID.Value) == nid
+}
+
+// Verify checks whether the request is signed by the sender.
+func (r *CancelRequest) Verify() error {
+	if r.Signature.Signature == nil {
+		return InvalidSignatureError.New("NoSignature")
+	}
+	pk, err := r.Signature.RecoverPublicKey(r.hash)
+	if err != nil {
+		return InvalidSignatureError.Wrap(err, "fail to recover public key")
+	}
+	addr := common.NewAccountAddressFromPublicKey(pk)
+	if !addr.Equal(r.From()) {
+		return InvalidSignatureError.New("fail to verify signature")
+	}
+	return nil
+}
+
+func NewCancelRequestFromJSON(js []byte) (*CancelRequest, error) {
+	r := &CancelRequest{raw: js}
+	if err := json.Unmarshal(js, &r.cancelRequestJSON); err != nil {
+		return nil, InvalidFormat.Wrap(err, "InvalidJSON")
+	}
+	if len(r.cancelRequestJSON.TxHash) == 0 {
+		return nil, errors.IllegalArgumentError.New("NoTxHash")
+	}
+	bs, err := SerializeJSON(js, nil, cancelExclusion)
+	if err != nil {
+		return nil, InvalidFormat.Wrap(err, "FailToSerialize")
+	}
+	r.hash = crypto.SHA3Sum256(append([]byte(cancelSalt), bs...))
+	return r, nil
+}
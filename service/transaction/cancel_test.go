@@ -0,0 +1,72 @@
+/*
+ * Copyright 2022 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transaction
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
+)
+
+func TestCancelRequest_Verify(t *testing.T) {
+	priv, pub := crypto.GenerateKeyPair()
+	from := common.NewAccountAddressFromPublicKey(pub)
+	params := map[string]interface{}{
+		"from":      from.String(),
+		"txHash":    "0x402b630c5ed80d1b8f0d89ca14a091084bcc0f6a98bc52329bccc045415bc0bd",
+		"timestamp": "0x563a6cf330136",
+		"nid":       "0x3",
+	}
+	sign := func() []byte {
+		bs, err := SerializeMap(params, nil, cancelExclusion)
+		assert.NoError(t, err)
+		sig, err := crypto.NewSignature(crypto.SHA3Sum256(append([]byte(cancelSalt), bs...)), priv)
+		assert.NoError(t, err)
+		rsv, err := sig.SerializeRSV()
+		assert.NoError(t, err)
+		params["signature"] = base64.StdEncoding.EncodeToString(rsv)
+		js, err := json.Marshal(params)
+		assert.NoError(t, err)
+		return js
+	}
+
+	req, err := NewCancelRequestFromJSON(sign())
+	assert.NoError(t, err)
+	assert.NoError(t, req.Verify())
+	assert.True(t, req.ValidateNetwork(3))
+	assert.False(t, req.ValidateNetwork(1))
+	assert.True(t, from.Equal(req.From()))
+	assert.EqualValues(t, 0x563a6cf330136, req.Timestamp())
+
+	// signature made for another sender
+	sign()
+	params["from"] = "hx1111111111111111111111111111111111111111"
+	bs, _ := json.Marshal(params)
+	req, err = NewCancelRequestFromJSON(bs)
+	assert.NoError(t, err)
+	assert.True(t, InvalidSignatureError.Equals(req.Verify()))
+
+	delete(params, "txHash")
+	bs, _ = json.Marshal(params)
+	_, err = NewCancelRequestFromJSON(bs)
+	assert.Error(t, err)
+}
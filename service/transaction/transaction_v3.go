@@ -345,6 +345,11 @@ func (tx *transactionV3) Nonce() *big.Int {
 	return nil
 }
 
+// GetStepLimit returns the step limit of the transaction.
+func (tx *transactionV3) GetStepLimit() *big.Int {
+	return &tx.transactionV3Data.StepLimit.Int
+}
+
 func (tx *transactionV3) To() module.Address {
 	return &tx.transactionV3Data.To
 }
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/icon-project/goloop/module"
@@ -27,6 +28,7 @@ type transactionList struct {
 type txElement struct {
 	value transaction.Transaction
 	ts    int64
+	added time.Time
 	err   error
 
 	list               *transactionList
@@ -120,10 +122,11 @@ func (l *transactionList) Add(tx transaction.Transaction, ts bool) error {
 
 	e := &txElement{
 		value: tx,
+		added: time.Now(),
 		list:  l,
 	}
 	if ts {
-		e.ts = time.Now().UnixNano()
+		e.ts = e.added.UnixNano()
 	}
 
 	l.idMap[tidBk][tidSlot] = e
@@ -243,6 +246,80 @@ func (l *transactionList) Len() int {
 	return l.size
 }
 
+func (l *transactionList) Get(id []byte) *txElement {
+	tidBk, tidSlot := indexAndBucketKeyFromKey(string(id))
+	return l.idMap[tidBk][tidSlot]
+}
+
+// GetBySenderAndNonce returns the transaction from the sender with the
+// nonce. It returns nil if there is no such transaction.
+func (l *transactionList) GetBySenderAndNonce(from module.Address, nonce *big.Int) *txElement {
+	uidBk, uidSlot := indexAndBucketKeyFromKey(string(from.ID()))
+	for e := l.srcMapToLast[uidBk][uidSlot]; e != nil; e = e.srcPrev {
+		if n := e.value.Nonce(); n != nil && n.Cmp(nonce) == 0 {
+			return e
+		}
+	}
+	return nil
+}
+
+// roundRobin iterates the transactions of the list taking one from each
+// sender in turn. Transactions of a sender keep their order. Senders are
+// found in the first round while it goes, so it costs only for the
+// transactions taken.
+type roundRobin struct {
+	next    *txElement
+	senders []*txElement
+	later   []*txElement
+	index   int
+}
+
+func (r *roundRobin) take(e *txElement) *txElement {
+	if e.srcNext != nil {
+		r.later = append(r.later, e.srcNext)
+	}
+	return e
+}
+
+// Next returns the next transaction. It returns nil at the end.
+func (r *roundRobin) Next() *txElement {
+	for r.next != nil {
+		e := r.next
+		r.next = e.listNext
+		if e.srcPrev == nil {
+			return r.take(e)
+		}
+	}
+	if r.index >= len(r.senders) {
+		r.senders, r.later = r.later, r.senders[:0]
+		r.index = 0
+		if len(r.senders) == 0 {
+			return nil
+		}
+	}
+	e := r.senders[r.index]
+	r.index += 1
+	return r.take(e)
+}
+
+// RoundRobin returns the iterator taking the transactions from each sender
+// in turn. The list must not be changed while it's used.
+func (l *transactionList) RoundRobin() *roundRobin {
+	return &roundRobin{next: l.listFront}
+}
+
+// SenderUsage returns the number of transactions and the size of them
+// from the sender.
+func (l *transactionList) SenderUsage(from module.Address) (int, int) {
+	uidBk, uidSlot := indexAndBucketKeyFromKey(string(from.ID()))
+	var count, size int
+	for e := l.srcMapToLast[uidBk][uidSlot]; e != nil; e = e.srcPrev {
+		count += 1
+		size += len(e.value.Bytes())
+	}
+	return count, size
+}
+
 func (l *transactionList) HasTx(id []byte) bool {
 	tidBk, tidSlot := indexAndBucketKeyFromKey(string(id))
 	_, ok := l.idMap[tidBk][tidSlot]
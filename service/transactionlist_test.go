@@ -212,3 +212,40 @@ func TestTransactionList_TestSort(t *testing.T) {
 		t.Errorf("First item should be tx4 but tx=%x", tx.ID())
 	}
 }
+
+func TestTransactionList_RoundRobin(t *testing.T) {
+	l := newTransactionList()
+	addr1 := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	addr2 := common.MustNewAddressFromString("hx2222222222222222222222222222222222222222")
+	addr3 := common.MustNewAddressFromString("hx3333333333333333333333333333333333333333")
+	txs := []*mockTransaction{
+		newMockTransaction([]byte("a1"), addr1, 1),
+		newMockTransaction([]byte("a2"), addr1, 2),
+		newMockTransaction([]byte("a3"), addr1, 3),
+		newMockTransaction([]byte("b1"), addr2, 4),
+		newMockTransaction([]byte("a4"), addr1, 5),
+		newMockTransaction([]byte("c1"), addr3, 6),
+		newMockTransaction([]byte("b2"), addr2, 7),
+	}
+	for _, tx := range txs {
+		if err := l.Add(tx, false); err != nil {
+			t.Fatalf("Fail to add transaction err=%+v", err)
+		}
+	}
+
+	var ids []string
+	rr := l.RoundRobin()
+	for e := rr.Next(); e != nil; e = rr.Next() {
+		ids = append(ids, string(e.Value().ID()))
+	}
+	expected := []string{"a1", "b1", "c1", "a2", "b2", "a3", "a4"}
+	if len(ids) != len(expected) {
+		t.Fatalf("Unexpected transactions %v", ids)
+	}
+	for i, id := range expected {
+		if ids[i] != id {
+			t.Errorf("Unexpected order %v exp=%v", ids, expected)
+			break
+		}
+	}
+}
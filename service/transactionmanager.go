@@ -123,6 +123,34 @@ func (m *TransactionManager) removeWaitersInLock(id []byte) []chan<- interface{}
 	return nil
 }
 
+func (m *TransactionManager) addWaiter(id []byte, rc chan<- interface{}) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.addWaiterInLock(id, rc)
+}
+
+// removeWaiter removes the waiter only. Other waiters for the
+// transaction are kept.
+func (m *TransactionManager) removeWaiter(id []byte, rc chan<- interface{}) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var hv hashValue
+	copy(hv[:], id)
+	ws := m.txWaiters[hv]
+	for i, c := range ws {
+		if c == rc {
+			ws = append(ws[:i], ws[i+1:]...)
+			break
+		}
+	}
+	if len(ws) == 0 {
+		delete(m.txWaiters, hv)
+	} else {
+		m.txWaiters[hv] = ws
+	}
+}
+
 func (m *TransactionManager) removeWaiters(id []byte) []chan<- interface{} {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -147,19 +175,21 @@ func (m *TransactionManager) OnTxDrops(drops []TxDrop) {
 	}
 }
 
+// AddAndWait adds the transaction, and it returns the channel for the
+// result. The waiter is registered before adding, so it may receive the
+// drop of the transaction while it's added.
 func (m *TransactionManager) AddAndWait(tx transaction.Transaction) (
 	<-chan interface{}, error,
 ) {
-	m.lock.Lock()
-	defer m.lock.Unlock()
+	rc := make(chan interface{}, 1)
+	m.addWaiter(tx.ID(), rc)
 
-	if err := m.addInLock(tx, true); err != nil {
+	if err := m.add(tx, true); err != nil {
 		if err != ErrDuplicateTransaction {
+			m.removeWaiter(tx.ID(), rc)
 			return nil, err
 		}
 	}
-	rc := make(chan interface{}, 1)
-	m.addWaiterInLock(tx.ID(), rc)
 	return rc, nil
 }
 
@@ -191,9 +221,7 @@ func (m *TransactionManager) Add(tx transaction.Transaction, direct bool, verifi
 			return err
 		}
 	}
-	m.lock.Lock()
-	defer m.lock.Unlock()
-	return m.addInLock(tx, direct)
+	return m.add(tx, direct)
 }
 
 func (m *TransactionManager) VerifyTx(tx transaction.Transaction) error {
@@ -210,7 +238,10 @@ func (m *TransactionManager) VerifyTx(tx transaction.Transaction) error {
 	}
 	return nil
 }
-func (m *TransactionManager) addInLock(tx transaction.Transaction, direct bool) error {
+
+// add adds the transaction to the pool. It's called without the lock,
+// because the pool notifies dropped transactions after it's added.
+func (m *TransactionManager) add(tx transaction.Transaction, direct bool) error {
 	if err := m.tim.CheckTXForAdd(tx); err != nil {
 		return err
 	}
@@ -219,6 +250,9 @@ func (m *TransactionManager) addInLock(tx transaction.Transaction, direct bool)
 	if err := pool.Add(tx, direct); err != nil {
 		return err
 	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
 	if m.callback != nil {
 		cb := m.callback
 		m.callback = nil
@@ -227,6 +261,32 @@ func (m *TransactionManager) addInLock(tx transaction.Transaction, direct bool)
 	return nil
 }
 
+// Cancel removes the normal transaction sent by the address.
+func (m *TransactionManager) Cancel(id []byte, from module.Address) error {
+	return m.normalTxPool.Cancel(id, from)
+}
+
+func (m *TransactionManager) PoolStatus() []module.TransactionPoolStatus {
+	return []module.TransactionPoolStatus{
+		m.normalTxPool.Status(),
+		m.patchTxPool.Status(),
+	}
+}
+
+func (m *TransactionManager) PendingTransactions(from module.Address) []*module.PendingTransaction {
+	return append(
+		m.patchTxPool.PendingTransactions(from),
+		m.normalTxPool.PendingTransactions(from)...,
+	)
+}
+
+func (m *TransactionManager) PendingTransaction(id []byte) *module.PendingTransaction {
+	if ptx := m.normalTxPool.PendingTransaction(id); ptx != nil {
+		return ptx
+	}
+	return m.patchTxPool.PendingTransaction(id)
+}
+
 func (m *TransactionManager) Wait(wc state.WorldContext, cb func()) bool {
 	m.lock.Lock()
 	defer m.lock.Unlock()
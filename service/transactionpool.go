@@ -17,6 +17,7 @@
 package service
 
 import (
+	"math/big"
 	"sync"
 	"time"
 
@@ -38,6 +39,9 @@ type Monitor interface {
 	OnDropTx(n int, user bool)
 	OnAddTx(n int, user bool)
 	OnRemoveTx(n int, user bool)
+	OnReplaceTx(n int, user bool)
+	OnCancelTx(n int, user bool)
+	OnRejectTx(n int, user bool)
 	OnCommit(id []byte, ts time.Time, d time.Duration)
 }
 
@@ -67,6 +71,10 @@ type TransactionPool struct {
 	size int
 	tim  TXIDManager
 
+	// limits for transactions from a sender, zero for no limit
+	senderTxs   int
+	senderBytes int
+
 	list *transactionList
 
 	mutex sync.Mutex
@@ -147,7 +155,19 @@ func (tp *TransactionPool) Candidate(wc state.WorldContext, maxBytes int, maxCou
 	dropped := make([]*txElement, 0, configDefaultTxSliceCapacity)
 	poolSize := tp.list.Len()
 	txSize := int(0)
-	for e := tp.list.Front(); e != nil && txSize < maxBytes && len(txs) < maxCount; e = e.Next() {
+	// transactions are taken from each sender in turn only with sender
+	// limits, otherwise they are taken in the order of arrival.
+	e, next := tp.list.Front(), (*txElement).Next
+	if tp.senderTxs > 0 || tp.senderBytes > 0 {
+		rr := tp.list.RoundRobin()
+		e, next = rr.Next(), func(*txElement) *txElement {
+			return rr.Next()
+		}
+	}
+	for ; e != nil; e = next(e) {
+		if txSize >= maxBytes || len(txs) >= maxCount {
+			break
+		}
 		tx := e.Value()
 		if err := tsr.CheckTx(tx); err != nil {
 			if ExpiredTransactionError.Equals(err) {
@@ -213,27 +233,129 @@ func (tp *TransactionPool) CheckTxs(wc state.WorldContext) bool {
 	return false
 }
 
+type stepLimitGetter interface {
+	GetStepLimit() *big.Int
+}
+
+func stepLimitOf(tx transaction.Transaction) *big.Int {
+	if sg, ok := transaction.Unwrap(tx).(stepLimitGetter); ok {
+		return sg.GetStepLimit()
+	}
+	return nil
+}
+
+// replaceableBy returns the transaction in the pool to be replaced by
+// the transaction. A transaction replaces the one from the same sender
+// with the same nonce if its step limit is higher.
+func (tp *TransactionPool) replaceableBy(tx transaction.Transaction) *txElement {
+	stepLimit := stepLimitOf(tx)
+	if stepLimit == nil || tx.From() == nil {
+		return nil
+	}
+	nonce := tx.Nonce()
+	if nonce == nil {
+		return nil
+	}
+	e := tp.list.GetBySenderAndNonce(tx.From(), nonce)
+	if e == nil {
+		return nil
+	}
+	if old := stepLimitOf(e.Value()); old == nil || old.Cmp(stepLimit) >= 0 {
+		return nil
+	}
+	return e
+}
+
+// checkSenderLimit returns SenderLimitExceededError if the transaction
+// makes the sender exceed the limits. The transaction to be replaced by it
+// isn't counted.
+func (tp *TransactionPool) checkSenderLimit(tx transaction.Transaction, old *txElement) error {
+	if tp.senderTxs <= 0 && tp.senderBytes <= 0 {
+		return nil
+	}
+	if tp.list.HasTx(tx.ID()) {
+		return nil
+	}
+	count, size := tp.list.SenderUsage(tx.From())
+	if old != nil {
+		count -= 1
+		size -= len(old.Value().Bytes())
+	}
+	count += 1
+	size += len(tx.Bytes())
+	if (tp.senderTxs > 0 && count > tp.senderTxs) ||
+		(tp.senderBytes > 0 && size > tp.senderBytes) {
+		return SenderLimitExceededError.Errorf(
+			"SenderLimitExceeded(from=%s,txs=%d,bytes=%d)", tx.From(), count, size)
+	}
+	return nil
+}
+
 /*
 	return nil if tx is nil or tx is added to pool
 	return ErrTransactionPoolOverFlow if pool is full
+	return SenderLimitExceededError if the sender has too many transactions
 */
 func (tp *TransactionPool) Add(tx transaction.Transaction, direct bool) error {
 	if tx == nil {
 		return nil
 	}
-	tp.mutex.Lock()
-	defer tp.mutex.Unlock()
+	lock := common.LockForAutoCall(&tp.mutex)
+	defer lock.Unlock()
 
-	if tp.list.Len() >= tp.size {
+	old := tp.replaceableBy(tx)
+	if old == nil && tp.list.Len() >= tp.size {
 		return ErrTransactionPoolOverFlow
 	}
+	if err := tp.checkSenderLimit(tx, old); err != nil {
+		tp.monitor.OnRejectTx(len(tx.Bytes()), direct)
+		return err
+	}
 
-	err := tp.list.Add(tx, direct)
-	if err == nil {
-		tp.monitor.OnAddTx(len(tx.Bytes()), direct)
-		tp.pcm.OnPoolCapacityUpdated(tp.group, tp.size, tp.list.Len())
+	if err := tp.list.Add(tx, direct); err != nil {
+		return err
+	}
+	tp.monitor.OnAddTx(len(tx.Bytes()), direct)
+	if old != nil {
+		tp.list.Remove(old)
+		otx := old.Value()
+		old.err = ReplacedTransactionError.Errorf(
+			"ReplacedTransaction(by=%#x)", tx.ID())
+		tp.log.Debugf("REPLACE TX: id=%#x by=%#x", otx.ID(), tx.ID())
+		tp.monitor.OnReplaceTx(len(otx.Bytes()), old.ts != 0)
+		drops := []TxDrop{{otx.ID(), old.err}}
+		lock.CallAfterUnlock(func() {
+			tp.txm.OnTxDrops(drops)
+		})
+	}
+	tp.pcm.OnPoolCapacityUpdated(tp.group, tp.size, tp.list.Len())
+	return nil
+}
+
+// Cancel removes the transaction sent by the address from the pool.
+func (tp *TransactionPool) Cancel(id []byte, from module.Address) error {
+	lock := common.LockForAutoCall(&tp.mutex)
+	defer lock.Unlock()
+
+	e := tp.list.Get(id)
+	if e == nil {
+		return errors.NotFoundError.Errorf("NotFound(id=%#x)", id)
 	}
-	return err
+	tx := e.Value()
+	if !from.Equal(tx.From()) {
+		return errors.InvalidStateError.Errorf(
+			"NotSender(id=%#x,from=%s)", id, from)
+	}
+	tp.list.Remove(e)
+	e.err = CanceledTransactionError.New("CanceledTransaction")
+	tp.log.Debugf("CANCEL TX: id=%#x", id)
+	tp.monitor.OnCancelTx(len(tx.Bytes()), e.ts != 0)
+	drops := []TxDrop{{tx.ID(), e.err}}
+	lock.CallAfterUnlock(func() {
+		tp.txm.OnTxDrops(drops)
+	})
+	tp.pcm.OnPoolCapacityUpdated(tp.group, tp.size, tp.list.Len())
+	return nil
 }
 
 // removeList remove transactions when transactions are finalized.
@@ -291,6 +413,60 @@ func (tp *TransactionPool) Used() int {
 	return tp.list.Len()
 }
 
+// Status returns the status of the pool.
+func (tp *TransactionPool) Status() module.TransactionPoolStatus {
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+
+	bytes := 0
+	for e := tp.list.Front(); e != nil; e = e.Next() {
+		bytes += len(e.Value().Bytes())
+	}
+	return module.TransactionPoolStatus{
+		Group: tp.group,
+		Size:  tp.size,
+		Used:  tp.list.Len(),
+		Bytes: bytes,
+	}
+}
+
+func (tp *TransactionPool) pendingTransactionOf(e *txElement) *module.PendingTransaction {
+	return &module.PendingTransaction{
+		Transaction: e.Value(),
+		Group:       tp.group,
+		AddedAt:     e.added,
+		Direct:      e.ts != 0,
+	}
+}
+
+// PendingTransactions returns transactions in the pool in the order of
+// the pool. If from is not nil, it returns only transactions sent by it.
+func (tp *TransactionPool) PendingTransactions(from module.Address) []*module.PendingTransaction {
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+
+	ptxs := make([]*module.PendingTransaction, 0)
+	for e := tp.list.Front(); e != nil; e = e.Next() {
+		if from != nil && !from.Equal(e.Value().From()) {
+			continue
+		}
+		ptxs = append(ptxs, tp.pendingTransactionOf(e))
+	}
+	return ptxs
+}
+
+// PendingTransaction returns the transaction in the pool or nil if there
+// is no such transaction.
+func (tp *TransactionPool) PendingTransaction(id []byte) *module.PendingTransaction {
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+
+	if e := tp.list.Get(id); e != nil {
+		return tp.pendingTransactionOf(e)
+	}
+	return nil
+}
+
 func (tp *TransactionPool) SetTxManager(txm TxWaiterManager) {
 	tp.mutex.Lock()
 	defer tp.mutex.Unlock()
@@ -298,6 +474,16 @@ func (tp *TransactionPool) SetTxManager(txm TxWaiterManager) {
 	tp.txm = txm
 }
 
+// SetSenderLimit sets the maximum number and size of transactions from a
+// sender. Zero means no limit.
+func (tp *TransactionPool) SetSenderLimit(txs, bytes int) {
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+
+	tp.senderTxs = txs
+	tp.senderBytes = bytes
+}
+
 func (tp *TransactionPool) SetPoolCapacityMonitor(pcm PoolCapacityMonitor) {
 	tp.mutex.Lock()
 	defer tp.mutex.Unlock()
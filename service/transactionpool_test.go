@@ -1,11 +1,13 @@
 package service
 
 import (
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/module"
 )
@@ -25,6 +27,18 @@ func (m *mockMonitor) OnRemoveTx(n int, user bool) {
 	// do nothing
 }
 
+func (m *mockMonitor) OnReplaceTx(n int, user bool) {
+	// do nothing
+}
+
+func (m *mockMonitor) OnCancelTx(n int, user bool) {
+	// do nothing
+}
+
+func (m *mockMonitor) OnRejectTx(n int, user bool) {
+	// do nothing
+}
+
 func (m *mockMonitor) OnCommit(id []byte, ts time.Time, d time.Duration) {
 	// do nothing
 }
@@ -43,3 +57,229 @@ func TestTransactionPool_Add(t *testing.T) {
 		t.Error("Fail to add transaction with valid network ID")
 	}
 }
+
+func TestTransactionPool_PendingTransactions(t *testing.T) {
+	dbase := db.NewMapDB()
+	tsc := NewTimestampChecker()
+	tim, _ := NewTXIDManager(dbase, tsc)
+	pool := NewTransactionPool(module.TransactionGroupNormal, 5000, tim, &mockMonitor{}, log.New())
+
+	addr1 := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	addr2 := common.MustNewAddressFromString("hx2222222222222222222222222222222222222222")
+	tx1 := newMockTransaction([]byte("tx1"), addr1, 1)
+	tx2 := newMockTransaction([]byte("tx2"), addr2, 2)
+	tx3 := newMockTransaction([]byte("tx3"), addr1, 3)
+	for i, tx := range []*mockTransaction{tx1, tx2, tx3} {
+		if err := pool.Add(tx, i != 1); err != nil {
+			t.Fatalf("Fail to add transaction err=%+v", err)
+		}
+	}
+
+	status := pool.Status()
+	if status.Size != 5000 || status.Used != 3 {
+		t.Errorf("Unexpected status %+v", status)
+	}
+
+	ptxs := pool.PendingTransactions(nil)
+	if len(ptxs) != 3 {
+		t.Fatalf("Unexpected number of transactions %d", len(ptxs))
+	}
+	if !ptxs[0].Direct || ptxs[1].Direct || ptxs[0].AddedAt.IsZero() {
+		t.Errorf("Unexpected pending transaction %+v", ptxs[1])
+	}
+
+	ptxs = pool.PendingTransactions(addr1)
+	if len(ptxs) != 2 || ptxs[0].Transaction != tx1 || ptxs[1].Transaction != tx3 {
+		t.Errorf("Unexpected transactions of addr1 %+v", ptxs)
+	}
+
+	if ptx := pool.PendingTransaction(tx2.ID()); ptx == nil || ptx.Transaction != tx2 {
+		t.Errorf("Fail to get transaction tx2")
+	}
+	if ptx := pool.PendingTransaction([]byte("tx4")); ptx != nil {
+		t.Errorf("Unexpected transaction %+v", ptx)
+	}
+}
+
+type mockTransactionV3 struct {
+	*mockTransaction
+	nonce     *big.Int
+	stepLimit *big.Int
+}
+
+func (t *mockTransactionV3) Nonce() *big.Int {
+	return t.nonce
+}
+
+func (t *mockTransactionV3) GetStepLimit() *big.Int {
+	return t.stepLimit
+}
+
+func newMockTransactionV3(id []byte, from module.Address, nonce, stepLimit int64) *mockTransactionV3 {
+	return &mockTransactionV3{
+		mockTransaction: newMockTransaction(id, from, 1),
+		nonce:           big.NewInt(nonce),
+		stepLimit:       big.NewInt(stepLimit),
+	}
+}
+
+type mockTxWaiterManager struct {
+	drops chan []TxDrop
+}
+
+func (m *mockTxWaiterManager) OnTxDrops(drops []TxDrop) {
+	m.drops <- drops
+}
+
+func TestTransactionPool_Replace(t *testing.T) {
+	dbase := db.NewMapDB()
+	tsc := NewTimestampChecker()
+	tim, _ := NewTXIDManager(dbase, tsc)
+	pool := NewTransactionPool(module.TransactionGroupNormal, 2, tim, &mockMonitor{}, log.New())
+	txm := &mockTxWaiterManager{drops: make(chan []TxDrop, 1)}
+	pool.SetTxManager(txm)
+
+	addr := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	tx1 := newMockTransactionV3([]byte("tx1"), addr, 1, 100)
+	tx2 := newMockTransactionV3([]byte("tx2"), addr, 2, 100)
+	for _, tx := range []*mockTransactionV3{tx1, tx2} {
+		if err := pool.Add(tx, true); err != nil {
+			t.Fatalf("Fail to add transaction err=%+v", err)
+		}
+	}
+
+	// same nonce without higher step limit is not a replacement
+	tx3 := newMockTransactionV3([]byte("tx3"), addr, 1, 100)
+	if err := pool.Add(tx3, true); err != ErrTransactionPoolOverFlow {
+		t.Errorf("It should return ErrTransactionPoolOverFlow err=%+v", err)
+	}
+
+	// replacement is allowed even if the pool is full
+	tx4 := newMockTransactionV3([]byte("tx4"), addr, 1, 200)
+	if err := pool.Add(tx4, true); err != nil {
+		t.Fatalf("Fail to replace transaction err=%+v", err)
+	}
+	if pool.HasTx(tx1.ID()) || !pool.HasTx(tx4.ID()) || pool.Used() != 2 {
+		t.Errorf("Unexpected pool state used=%d", pool.Used())
+	}
+	select {
+	case drops := <-txm.drops:
+		if len(drops) != 1 || string(drops[0].ID) != "tx1" ||
+			!ReplacedTransactionError.Equals(drops[0].Err) {
+			t.Errorf("Unexpected drops %+v", drops)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("No drop notification for the replaced transaction")
+	}
+}
+
+func TestTransactionManager_AddAndWaitReplaced(t *testing.T) {
+	dbase := db.NewMapDB()
+	tsc := NewTimestampChecker()
+	tim, _ := NewTXIDManager(dbase, tsc)
+	ptp := NewTransactionPool(module.TransactionGroupPatch, 5000, tim, &mockMonitor{}, log.New())
+	ntp := NewTransactionPool(module.TransactionGroupNormal, 5000, tim, &mockMonitor{}, log.New())
+	tm := NewTransactionManager(1, tsc, ptp, ntp, tim, log.New())
+
+	addr := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	tx1 := newMockTransactionV3([]byte("tx1"), addr, 1, 100)
+	rc, err := tm.AddAndWait(tx1)
+	if err != nil {
+		t.Fatalf("Fail to add transaction err=%+v", err)
+	}
+
+	tx2 := newMockTransactionV3([]byte("tx2"), addr, 1, 200)
+	if err := tm.Add(tx2, true, true); err != nil {
+		t.Fatalf("Fail to replace transaction err=%+v", err)
+	}
+	select {
+	case r := <-rc:
+		if err, ok := r.(error); !ok || !ReplacedTransactionError.Equals(err) {
+			t.Errorf("Unexpected result %+v", r)
+		}
+	default:
+		t.Errorf("No result for the replaced transaction")
+	}
+
+	// a failed request doesn't leave its waiter
+	tx3 := newMockTransactionV3([]byte("tx3"), addr, 2, 100)
+	ntp.size = 1
+	if _, err := tm.AddAndWait(tx3); err == nil {
+		t.Fatalf("It should fail to add transaction")
+	}
+	if ws := tm.removeWaiters(tx3.ID()); len(ws) != 0 {
+		t.Errorf("Unexpected waiters %d", len(ws))
+	}
+}
+
+func TestTransactionPool_Cancel(t *testing.T) {
+	dbase := db.NewMapDB()
+	tsc := NewTimestampChecker()
+	tim, _ := NewTXIDManager(dbase, tsc)
+	pool := NewTransactionPool(module.TransactionGroupNormal, 5000, tim, &mockMonitor{}, log.New())
+	txm := &mockTxWaiterManager{drops: make(chan []TxDrop, 1)}
+	pool.SetTxManager(txm)
+
+	addr1 := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	addr2 := common.MustNewAddressFromString("hx2222222222222222222222222222222222222222")
+	tx1 := newMockTransaction([]byte("tx1"), addr1, 1)
+	if err := pool.Add(tx1, true); err != nil {
+		t.Fatalf("Fail to add transaction err=%+v", err)
+	}
+
+	if err := pool.Cancel([]byte("tx2"), addr1); !errors.NotFoundError.Equals(err) {
+		t.Errorf("It should return NotFoundError err=%+v", err)
+	}
+	if err := pool.Cancel(tx1.ID(), addr2); !errors.InvalidStateError.Equals(err) {
+		t.Errorf("It should return InvalidStateError err=%+v", err)
+	}
+	if err := pool.Cancel(tx1.ID(), addr1); err != nil {
+		t.Fatalf("Fail to cancel transaction err=%+v", err)
+	}
+	if pool.HasTx(tx1.ID()) {
+		t.Errorf("Canceled transaction remains in the pool")
+	}
+	drops := <-txm.drops
+	if len(drops) != 1 || !CanceledTransactionError.Equals(drops[0].Err) {
+		t.Errorf("Unexpected drops %+v", drops)
+	}
+}
+
+func TestTransactionPool_SenderLimit(t *testing.T) {
+	dbase := db.NewMapDB()
+	tsc := NewTimestampChecker()
+	tim, _ := NewTXIDManager(dbase, tsc)
+	pool := NewTransactionPool(module.TransactionGroupNormal, 5000, tim, &mockMonitor{}, log.New())
+	pool.SetSenderLimit(2, 0)
+
+	addr1 := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	addr2 := common.MustNewAddressFromString("hx2222222222222222222222222222222222222222")
+	tx1 := newMockTransactionV3([]byte("tx1"), addr1, 1, 100)
+	tx2 := newMockTransactionV3([]byte("tx2"), addr1, 2, 100)
+	for _, tx := range []*mockTransactionV3{tx1, tx2} {
+		if err := pool.Add(tx, true); err != nil {
+			t.Fatalf("Fail to add transaction err=%+v", err)
+		}
+	}
+	if err := pool.Add(newMockTransaction([]byte("tx3"), addr1, 3), true); !SenderLimitExceededError.Equals(err) {
+		t.Errorf("It should return SenderLimitExceededError err=%+v", err)
+	}
+	if err := pool.Add(tx1, true); err != ErrDuplicateTransaction {
+		t.Errorf("It should return ErrDuplicateTransaction err=%+v", err)
+	}
+	if err := pool.Add(newMockTransactionV3([]byte("tx4"), addr1, 1, 200), true); err != nil {
+		t.Errorf("Fail to replace transaction with sender limit err=%+v", err)
+	}
+	if err := pool.Add(newMockTransaction([]byte("tx5"), addr2, 1), true); err != nil {
+		t.Errorf("Fail to add transaction of another sender err=%+v", err)
+	}
+
+	// size of mock transaction is the length of ID
+	pool.SetSenderLimit(0, 5)
+	if err := pool.Add(newMockTransaction([]byte("tx6"), addr2, 2), true); !SenderLimitExceededError.Equals(err) {
+		t.Errorf("It should return SenderLimitExceededError err=%+v", err)
+	}
+	if err := pool.Add(newMockTransaction([]byte("t7"), addr2, 2), true); err != nil {
+		t.Errorf("Fail to add transaction within the limit err=%+v", err)
+	}
+}
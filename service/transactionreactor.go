@@ -35,6 +35,9 @@ type TransactionReactor struct {
 	ts         *TransactionShare
 }
 
+// OnReceive handles messages of the peer. A transaction failing to decode
+// may be of a type added by a newer version, so the peer relaying it is
+// reported lightly.
 func (r *TransactionReactor) OnReceive(subProtocol module.ProtocolInfo, buf []byte, peerId module.PeerID) (bool, error) {
 	switch subProtocol {
 	case protoPropagateTransaction:
@@ -42,6 +45,7 @@ func (r *TransactionReactor) OnReceive(subProtocol module.ProtocolInfo, buf []by
 		if err != nil {
 			r.log.Warnf("InvalidPacket(PropagateTransaction) from=%s", peerId.String())
 			r.log.Debugf("Failed to unmarshal transaction. buf=%x, err=%+v", buf, err)
+			r.membership.Report(peerId, module.ReportFailedResponse)
 			return false, err
 		}
 
@@ -54,6 +58,7 @@ func (r *TransactionReactor) OnReceive(subProtocol module.ProtocolInfo, buf []by
 		if err != nil {
 			r.log.Warnf("InvalidPacket(ResponseTransaction) from=%s", peerId.String())
 			r.log.Debugf("Failed to unmarshal transaction. buf=%x, err=%+v", buf, err)
+			r.membership.Report(peerId, module.ReportFailedResponse)
 			return false, err
 		}
 
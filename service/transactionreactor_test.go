@@ -0,0 +1,53 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/wallet"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/network"
+)
+
+type reportRecorder struct {
+	module.ProtocolHandler
+	score int
+}
+
+func (r *reportRecorder) Report(id module.PeerID, rp module.PeerReport) {
+	r.score += int(rp)
+}
+
+func TestTransactionReactor_UndecodableTransaction(t *testing.T) {
+	ph := new(reportRecorder)
+	r := &TransactionReactor{membership: ph, log: log.New()}
+	peer := network.NewPeerIDFromAddress(wallet.New().Address())
+
+	// a few transactions of a type unknown to this version don't get the
+	// peer relaying them banned
+	for i := 0; i < 5; i++ {
+		for _, pi := range []module.ProtocolInfo{protoPropagateTransaction, protoResponseTransaction} {
+			_, err := r.OnReceive(pi, []byte(`{"version":"0x9"}`), peer)
+			assert.Error(t, err)
+		}
+	}
+	assert.Greater(t, ph.score, network.DefaultBanThreshold)
+}
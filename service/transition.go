@@ -147,6 +147,7 @@ type transition struct {
 	syncer ssync.Syncer
 
 	ti *module.TraceInfo
+	sr *state.StateRecorder
 
 	ptxIDs   TXIDLogger
 	ntxIDs   TXIDLogger
@@ -411,6 +412,10 @@ func (t *transition) newWorldContext(execution bool) (state.WorldContext, error)
 	}
 	if execution {
 		ws.EnableNodeCache()
+		if t.ti != nil && t.ti.TraceMode == module.TraceModeStateDiff {
+			t.sr = state.NewStateRecorder()
+			ws = t.sr.WorldState(ws)
+		}
 	}
 	return state.NewWorldContext(ws, t.bi, t.csi, t.plt), nil
 }
@@ -794,6 +799,10 @@ func (t *transition) executeTxs(l module.TransactionList, ctx contract.Context,
 		// it will skip skippable transactions
 		return t.executeTxsSequential(l, ctx, rctBuf)
 	}
+	if t.sr != nil {
+		// state recorder works only with sequential execution
+		return t.executeTxsSequential(l, ctx, rctBuf)
+	}
 	if cc := t.chain.ConcurrencyLevel(); cc > 1 {
 		return t.executeTxsConcurrent(cc, l, ctx, rctBuf)
 	}
@@ -47,6 +47,9 @@ func (t *transition) executeTxsSequential(l module.TransactionList, ctx contract
 		}
 		ctx.SetTransactionInfo(txInfo)
 		wcs := ctx.GetSnapshot()
+		if t.sr != nil {
+			t.sr.Reset()
+		}
 		traceLogger := ctx.GetTraceLogger(module.EPhaseTransaction)
 		traceLogger.OnTransactionStart(cnt, txo.ID())
 
@@ -82,6 +85,9 @@ func (t *transition) executeTxsSequential(l module.TransactionList, ctx contract
 			traceLogger.OnTransactionReset()
 		}
 
+		if t.sr != nil && traceLogger.TraceMode() == module.TraceModeStateDiff {
+			traceLogger.OnStateDiff(t.sr.Diff(wcs, ctx.GetSnapshot()))
+		}
 		traceLogger.OnTransactionEnd(cnt, txo.ID(), txInfo.From, ctx.Treasury(), ctx.Revision(), rctBuf[cnt])
 		duration := time.Now().Sub(ts)
 		t.log.Tracef("END   TX <0x%x> duration=%s", txo.ID(), duration)
@@ -98,6 +98,16 @@ func (log *eventLog) ToJSON(v module.JSONVersion) *eventLogJSON {
 	return log.toFallbackJSON()
 }
 
+// EventLogToJSON returns JSON object of the event log in the same format
+// as the event logs of the receipt.
+func EventLogToJSON(addr module.Address, indexed, data [][]byte) interface{} {
+	log := new(eventLog)
+	log.eventLogData.Addr.Set(addr)
+	log.eventLogData.Indexed = indexed
+	log.eventLogData.Data = data
+	return log.ToJSON(module.JSONVersionLast)
+}
+
 func (log *eventLog) toFallbackJSON() *eventLogJSON {
 	indexed := make([]interface{}, len(log.eventLogData.Indexed))
 	data := make([]interface{}, len(log.eventLogData.Data))
@@ -327,6 +327,7 @@ func (ts *TransactionShare) HandleRequestTransaction(buf []byte, peer module.Pee
 	if err := req.SetBytes(buf); err != nil {
 		ts.log.Warn("InvalidPacket(TransactionRequest)")
 		ts.log.Debugf("Failed to unmarshal msgTransactionRequest. buf=%x, err=%+v\n", buf, err)
+		ts.ph.Report(peer, module.ReportInvalidMessage)
 		return false, err
 	}
 	ts.lock.Lock()
@@ -38,6 +38,10 @@ type NetworkManager struct {
 	peers    []Peer
 	handlers []*nmHandler
 	roles    map[string]module.Role
+	sim      *SimNetwork
+
+	closeMu sync.RWMutex
+	closed  bool
 }
 
 func indexOf(pl []Peer, id module.PeerID) int {
@@ -68,7 +72,30 @@ func (n *NetworkManager) handlePacketLoop() {
 }
 
 func (n *NetworkManager) Close() {
-	close(n.rCh)
+	n.closeMu.Lock()
+	defer n.closeMu.Unlock()
+
+	if !n.closed {
+		n.closed = true
+		close(n.rCh)
+	}
+}
+
+func (n *NetworkManager) setSimNetwork(sn *SimNetwork) {
+	al := common.Lock(&nmMu)
+	defer al.Unlock()
+
+	n.sim = sn
+}
+
+// send delivers the packet to the peer through the simulated network if
+// it's attached.
+func (n *NetworkManager) send(sn *SimNetwork, p Peer, pk *Packet) {
+	if sn != nil {
+		sn.send(n.id, p, pk)
+	} else {
+		p.notifyPacket(pk, nil)
+	}
 }
 
 func (n *NetworkManager) attach(p Peer) {
@@ -112,7 +139,12 @@ func (n *NetworkManager) detach(p Peer) {
 }
 
 func (n *NetworkManager) notifyPacket(pk *Packet, cb func(rebroadcast bool, err error)) {
-	n.rCh <- packetEntry{pk, cb}
+	n.closeMu.RLock()
+	defer n.closeMu.RUnlock()
+
+	if !n.closed {
+		n.rCh <- packetEntry{pk, cb}
+	}
 }
 
 func (n *NetworkManager) handlePacket(pk *Packet, cb func(rebroadcast bool, err error)) {
@@ -148,6 +180,13 @@ func (n *NetworkManager) RegisterReactor(name string, mpi module.ProtocolInfo, r
 	al := common.Lock(&nmMu)
 	defer al.Unlock()
 
+	// replace reactor as network.manager does for registered protocol
+	for _, h := range n.handlers {
+		if h.mpi == mpi {
+			h.reactor = reactor
+			return h, nil
+		}
+	}
 	h := &nmHandler{
 		n,
 		mpi,
@@ -234,10 +273,11 @@ func (h *nmHandler) Broadcast(pi module.ProtocolInfo, b []byte, bt module.Broadc
 		b,
 	}
 	peers := append([]Peer(nil), h.n.peers...)
+	sn := h.n.sim
 	al.Unlock()
 
 	for _, p := range peers {
-		p.notifyPacket(pk, nil)
+		h.n.send(sn, p, pk)
 	}
 	return nil
 }
@@ -260,9 +300,10 @@ func (h *nmHandler) Multicast(pi module.ProtocolInfo, b []byte, role module.Role
 			peers = append(peers, p)
 		}
 	}
+	sn := h.n.sim
 	al.Unlock()
 	for _, p := range peers {
-		p.notifyPacket(pk, nil)
+		h.n.send(sn, p, pk)
 	}
 	return nil
 }
@@ -281,9 +322,10 @@ func (h *nmHandler) Unicast(pi module.ProtocolInfo, b []byte, id module.PeerID)
 			b,
 		}
 		p := h.n.peers[idx]
+		sn := h.n.sim
 		al.Unlock()
 
-		p.notifyPacket(pk, nil)
+		h.n.send(sn, p, pk)
 		return nil
 	}
 	return errors.New("no peer")
@@ -292,3 +334,6 @@ func (h *nmHandler) Unicast(pi module.ProtocolInfo, b []byte, id module.PeerID)
 func (h *nmHandler) GetPeers() []module.PeerID {
 	return h.n.GetPeers()
 }
+
+func (h *nmHandler) Report(id module.PeerID, r module.PeerReport) {
+}
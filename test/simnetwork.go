@@ -0,0 +1,301 @@
+/*
+ * Copyright 2021 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"container/heap"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/icon-project/goloop/module"
+)
+
+// LinkConfig is the condition of the link from a peer to another peer.
+// Loss is the probability of dropping a packet (0 to 1), and Bandwidth is
+// in bytes per second (0: unlimited). Packets are reordered if Jitter is
+// not zero.
+type LinkConfig struct {
+	Latency   time.Duration
+	Jitter    time.Duration
+	Loss      float64
+	Bandwidth int
+}
+
+func (c *LinkConfig) isZero() bool {
+	return c.Latency == 0 && c.Jitter == 0 && c.Loss == 0 && c.Bandwidth == 0
+}
+
+type linkKey struct {
+	from string
+	to   string
+}
+
+type delivery struct {
+	at  time.Time
+	seq int64
+	dst Peer
+	pk  *Packet
+}
+
+type deliveryQueue []*delivery
+
+func (q deliveryQueue) Len() int { return len(q) }
+
+func (q deliveryQueue) Less(i, j int) bool {
+	if q[i].at.Equal(q[j].at) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].at.Before(q[j].at)
+}
+
+func (q deliveryQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *deliveryQueue) Push(x interface{}) {
+	*q = append(*q, x.(*delivery))
+}
+
+func (q *deliveryQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	d := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return d
+}
+
+// SimNetwork simulates links between network managers in the process.
+// Packets sent through attached network managers are delivered with the
+// condition of the link, and dropped between partitioned peers.
+type SimNetwork struct {
+	mtx    sync.Mutex
+	rand   *rand.Rand
+	def    LinkConfig
+	links  map[linkKey]*LinkConfig
+	busy   map[linkKey]time.Time
+	groups map[string]int
+
+	queue   deliveryQueue
+	seq     int64
+	wakeup  chan struct{}
+	closeCh chan struct{}
+	closed  bool
+
+	delivered int
+	dropped   int
+}
+
+// NewSimNetwork returns a new simulated network. seed is used for loss and
+// jitter, so the same seed makes the same decisions for the same packets.
+func NewSimNetwork(seed int64) *SimNetwork {
+	sn := &SimNetwork{
+		rand:    rand.New(rand.NewSource(seed)),
+		links:   make(map[linkKey]*LinkConfig),
+		busy:    make(map[linkKey]time.Time),
+		groups:  make(map[string]int),
+		wakeup:  make(chan struct{}, 1),
+		closeCh: make(chan struct{}),
+	}
+	go sn.deliverLoop()
+	return sn
+}
+
+// SetDefaultLink sets the condition of links without SetLink.
+func (sn *SimNetwork) SetDefaultLink(cfg LinkConfig) {
+	sn.mtx.Lock()
+	defer sn.mtx.Unlock()
+
+	sn.def = cfg
+}
+
+// SetLink sets the condition of the link from a peer to another peer.
+func (sn *SimNetwork) SetLink(from, to module.PeerID, cfg LinkConfig) {
+	sn.mtx.Lock()
+	defer sn.mtx.Unlock()
+
+	c := cfg
+	sn.links[linkKey{string(from.Bytes()), string(to.Bytes())}] = &c
+}
+
+// SetLinks sets the condition of links in both directions between peers.
+func (sn *SimNetwork) SetLinks(p1, p2 module.PeerID, cfg LinkConfig) {
+	sn.SetLink(p1, p2, cfg)
+	sn.SetLink(p2, p1, cfg)
+}
+
+// ResetLinks clears conditions set by SetLink.
+func (sn *SimNetwork) ResetLinks() {
+	sn.mtx.Lock()
+	defer sn.mtx.Unlock()
+
+	sn.links = make(map[linkKey]*LinkConfig)
+}
+
+// Partition splits peers into the groups. Packets between peers in
+// different groups are dropped. Peers not in the groups belong to a
+// group together.
+func (sn *SimNetwork) Partition(groups ...[]module.PeerID) {
+	sn.mtx.Lock()
+	defer sn.mtx.Unlock()
+
+	sn.groups = make(map[string]int)
+	for i, g := range groups {
+		for _, id := range g {
+			sn.groups[string(id.Bytes())] = i + 1
+		}
+	}
+}
+
+// Heal removes partitions.
+func (sn *SimNetwork) Heal() {
+	sn.Partition()
+}
+
+// Isolate makes a partition having only the peers.
+func (sn *SimNetwork) Isolate(ids ...module.PeerID) {
+	sn.Partition(ids)
+}
+
+// Stats returns the number of delivered and dropped packets.
+func (sn *SimNetwork) Stats() (delivered, dropped int) {
+	sn.mtx.Lock()
+	defer sn.mtx.Unlock()
+
+	return sn.delivered, sn.dropped
+}
+
+// Attach makes packets from the network manager go through the network.
+func (sn *SimNetwork) Attach(nm *NetworkManager) {
+	nm.setSimNetwork(sn)
+}
+
+// Interconnect attaches network managers of the nodes and connects them
+// each other.
+func (sn *SimNetwork) Interconnect(nodes []*Node) {
+	for _, n := range nodes {
+		sn.Attach(n.NM)
+	}
+	NodeInterconnect(nodes)
+}
+
+// Close stops delivery. Packets in flight are dropped.
+func (sn *SimNetwork) Close() {
+	sn.mtx.Lock()
+	defer sn.mtx.Unlock()
+
+	if !sn.closed {
+		sn.closed = true
+		sn.queue = nil
+		close(sn.closeCh)
+	}
+}
+
+func (sn *SimNetwork) send(src module.PeerID, dst Peer, pk *Packet) {
+	if sn.enqueue(src, dst, pk) {
+		dst.notifyPacket(pk, nil)
+	}
+}
+
+// enqueue schedules delivery of the packet. It returns true if the packet
+// needs to be delivered immediately.
+func (sn *SimNetwork) enqueue(src module.PeerID, dst Peer, pk *Packet) bool {
+	sn.mtx.Lock()
+	defer sn.mtx.Unlock()
+
+	if sn.closed {
+		return false
+	}
+	key := linkKey{string(src.Bytes()), string(dst.ID().Bytes())}
+	if sn.groups[key.from] != sn.groups[key.to] {
+		sn.dropped++
+		return false
+	}
+	cfg := &sn.def
+	if c, ok := sn.links[key]; ok {
+		cfg = c
+	}
+	if cfg.Loss > 0 && sn.rand.Float64() < cfg.Loss {
+		sn.dropped++
+		return false
+	}
+	sn.delivered++
+	if cfg.isZero() && len(sn.queue) == 0 {
+		return true
+	}
+
+	now := time.Now()
+	at := now
+	if cfg.Bandwidth > 0 {
+		if busy := sn.busy[key]; busy.After(at) {
+			at = busy
+		}
+		at = at.Add(time.Duration(len(pk.Data)) * time.Second / time.Duration(cfg.Bandwidth))
+		sn.busy[key] = at
+	}
+	at = at.Add(cfg.Latency)
+	if cfg.Jitter > 0 {
+		at = at.Add(time.Duration(sn.rand.Int63n(int64(cfg.Jitter))))
+	}
+	sn.seq++
+	heap.Push(&sn.queue, &delivery{at, sn.seq, dst, pk})
+	select {
+	case sn.wakeup <- struct{}{}:
+	default:
+	}
+	return false
+}
+
+func (sn *SimNetwork) nextDelivery(now time.Time) (*delivery, time.Duration) {
+	sn.mtx.Lock()
+	defer sn.mtx.Unlock()
+
+	if len(sn.queue) == 0 {
+		return nil, -1
+	}
+	if d := sn.queue[0]; d.at.After(now) {
+		return nil, d.at.Sub(now)
+	}
+	return heap.Pop(&sn.queue).(*delivery), 0
+}
+
+func (sn *SimNetwork) deliverLoop() {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		d, wait := sn.nextDelivery(time.Now())
+		if d != nil {
+			d.dst.notifyPacket(d.pk, nil)
+			continue
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		if wait < 0 {
+			wait = time.Hour
+		}
+		timer.Reset(wait)
+		select {
+		case <-sn.closeCh:
+			return
+		case <-sn.wakeup:
+		case <-timer.C:
+		}
+	}
+}